@@ -0,0 +1,340 @@
+// Package abstraction contains bucketed hand abstractions, for use with
+// solvers.
+package abstraction
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+
+	"github.com/cardrank/cardrank"
+)
+
+// Metric is a hand strength metric.
+type Metric uint8
+
+// Metrics.
+const (
+	// EHS2 is the expected hand strength squared (E[HS²]) over the remaining
+	// board cards.
+	EHS2 Metric = iota
+	// Equity is the expected hand strength (E[HS]) over the remaining board
+	// cards.
+	Equity
+)
+
+// Name returns the metric name.
+func (m Metric) Name() string {
+	switch m {
+	case EHS2:
+		return "E[HS²]"
+	case Equity:
+		return "Equity"
+	}
+	return ""
+}
+
+// Abstraction buckets hands for each street of a type into clusters by hand
+// strength.
+type Abstraction struct {
+	typ       cardrank.Type
+	seed      int64
+	buckets   []int
+	samples   int
+	rollouts  int
+	opponents int
+	metric    Metric
+	boards    []int
+	centroids [][]float64
+}
+
+// New creates a new hand abstraction for the type.
+func New(typ cardrank.Type, opts ...Option) (*Abstraction, error) {
+	if typ.Board() == 0 {
+		return nil, fmt.Errorf("type %s has no board", typ)
+	}
+	a := &Abstraction{
+		typ:       typ,
+		seed:      1,
+		buckets:   []int{8},
+		samples:   500,
+		rollouts:  50,
+		opponents: 50,
+		metric:    EHS2,
+	}
+	for _, o := range opts {
+		o(a)
+	}
+	var n int
+	for _, street := range typ.Streets() {
+		if n += street.Board; len(a.boards) == 0 || a.boards[len(a.boards)-1] != n {
+			a.boards = append(a.boards, n)
+		}
+	}
+	for _, k := range a.buckets {
+		if k < 1 {
+			return nil, fmt.Errorf("invalid bucket count %d", k)
+		}
+	}
+	return a, nil
+}
+
+// Streets returns the count of streets, where each street is a distinct
+// board length.
+func (a *Abstraction) Streets() int {
+	return len(a.boards)
+}
+
+// Buckets returns the bucket count for the street, or 0 when there is no
+// street.
+func (a *Abstraction) Buckets(street int) int {
+	if street < 0 || len(a.boards) <= street {
+		return 0
+	}
+	return a.buckets[min(street, len(a.buckets)-1)]
+}
+
+// Centroids returns the trained centroids for the street, ordered weakest to
+// strongest.
+func (a *Abstraction) Centroids(street int) []float64 {
+	if street < 0 || len(a.centroids) <= street {
+		return nil
+	}
+	return a.centroids[street]
+}
+
+// Street returns the street for the board length, or -1 when there is no
+// street with the board length.
+func (a *Abstraction) Street(board int) int {
+	return slices.Index(a.boards, board)
+}
+
+// Train trains the abstraction, by sampling random pockets and boards for
+// each street, and clustering their hand strengths. Training is
+// deterministic for the seed.
+func (a *Abstraction) Train(ctx context.Context) error {
+	r := rand.New(rand.NewSource(a.seed))
+	deck := a.typ.Deck()
+	pocket := a.typ.Pocket()
+	centroids := make([][]float64, len(a.boards))
+	for street, board := range a.boards {
+		v := make([]float64, a.samples)
+		for i := range a.samples {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+			deck.Reset()
+			deck.Shuffle(r, 1)
+			v[i] = a.value(r, deck.Draw(pocket), deck.Draw(board))
+		}
+		centroids[street] = Cluster(v, a.Buckets(street), a.seed+int64(street))
+	}
+	a.centroids = centroids
+	return nil
+}
+
+// Bucket returns the bucket for the pocket and board, where buckets are
+// ordered weakest to strongest. The bucket for a pocket and board is
+// deterministic for the seed.
+func (a *Abstraction) Bucket(pocket, board []cardrank.Card) (int, error) {
+	street := a.Street(len(board))
+	switch {
+	case street == -1:
+		return 0, fmt.Errorf("invalid board length %d", len(board))
+	case len(a.centroids) <= street:
+		return 0, fmt.Errorf("street %d not trained", street)
+	}
+	return Nearest(a.centroids[street], a.value(a.rand(pocket, board), pocket, board)), nil
+}
+
+// Strength returns the expected hand strength (E[HS]) and the expected hand
+// strength squared (E[HS²]) for the pocket and board, estimated by sampling
+// the remaining board cards and opposing pockets. The strength for a pocket
+// and board is deterministic for the seed.
+func (a *Abstraction) Strength(pocket, board []cardrank.Card) (float64, float64) {
+	return a.strength(a.rand(pocket, board), pocket, board)
+}
+
+// value returns the metric value for the pocket and board.
+func (a *Abstraction) value(r *rand.Rand, pocket, board []cardrank.Card) float64 {
+	hs, ehs2 := a.strength(r, pocket, board)
+	if a.metric == Equity {
+		return hs
+	}
+	return ehs2
+}
+
+// strength returns the expected hand strength and expected hand strength
+// squared for the pocket and board.
+func (a *Abstraction) strength(r *rand.Rand, pocket, board []cardrank.Card) (float64, float64) {
+	// build unused cards
+	var unused []cardrank.Card
+	for _, c := range a.typ.Deck().All() {
+		if !slices.Contains(pocket, c) && !slices.Contains(board, c) {
+			unused = append(unused, c)
+		}
+	}
+	need, rollouts := a.boards[len(a.boards)-1]-len(board), a.rollouts
+	if need == 0 {
+		rollouts = 1
+	}
+	full := make([]cardrank.Card, len(board)+need)
+	copy(full, board)
+	var hs, ehs2 float64
+	for range rollouts {
+		// deal remaining board cards to the front of unused
+		sample(r, unused, need)
+		copy(full[len(board):], unused[:need])
+		b := a.typ.EvalBoard(full)
+		ev, rest := b.Eval(pocket), unused[need:]
+		var score float64
+		for range a.opponents {
+			sample(r, rest, len(pocket))
+			switch ev.Comp(b.Eval(rest[:len(pocket)]), false) {
+			case -1:
+				score += 1
+			case 0:
+				score += 0.5
+			}
+		}
+		s := score / float64(a.opponents)
+		hs, ehs2 = hs+s, ehs2+s*s
+	}
+	return hs / float64(rollouts), ehs2 / float64(rollouts)
+}
+
+// rand returns a rand source seeded by the seed and the pocket and board.
+func (a *Abstraction) rand(pocket, board []cardrank.Card) *rand.Rand {
+	seed := uint64(a.seed)
+	for _, c := range slices.Concat(pocket, board) {
+		seed = seed*1099511628211 ^ uint64(c)
+	}
+	return rand.New(rand.NewSource(int64(seed)))
+}
+
+// Option is a hand abstraction option.
+type Option func(*Abstraction)
+
+// WithSeed is a hand abstraction option to set the seed.
+func WithSeed(seed int64) Option {
+	return func(a *Abstraction) {
+		a.seed = seed
+	}
+}
+
+// WithBuckets is a hand abstraction option to set the bucket count for each
+// street. The last count is used for any remaining streets.
+func WithBuckets(buckets ...int) Option {
+	return func(a *Abstraction) {
+		if len(buckets) != 0 {
+			a.buckets = buckets
+		}
+	}
+}
+
+// WithSamples is a hand abstraction option to set the count of sampled hands
+// for each street when training.
+func WithSamples(samples int) Option {
+	return func(a *Abstraction) {
+		a.samples = samples
+	}
+}
+
+// WithRollouts is a hand abstraction option to set the count of sampled
+// remaining boards when calculating hand strength.
+func WithRollouts(rollouts int) Option {
+	return func(a *Abstraction) {
+		a.rollouts = max(1, rollouts)
+	}
+}
+
+// WithOpponents is a hand abstraction option to set the count of sampled
+// opposing pockets when calculating hand strength.
+func WithOpponents(opponents int) Option {
+	return func(a *Abstraction) {
+		a.opponents = max(1, opponents)
+	}
+}
+
+// WithMetric is a hand abstraction option to set the hand strength metric.
+func WithMetric(metric Metric) Option {
+	return func(a *Abstraction) {
+		a.metric = metric
+	}
+}
+
+// Cluster clusters v into k clusters using k-means, with k-means++ seeding.
+// Returns the centroids, ordered smallest to largest. Clustering is
+// deterministic for the seed.
+func Cluster(v []float64, k int, seed int64) []float64 {
+	if len(v) == 0 || k < 1 {
+		return nil
+	}
+	r := rand.New(rand.NewSource(seed))
+	// k-means++ seeding
+	centroids := []float64{v[r.Intn(len(v))]}
+	d := make([]float64, len(v))
+	for len(centroids) < k {
+		var total float64
+		for i, x := range v {
+			d[i] = math.Pow(x-centroids[Nearest(centroids, x)], 2)
+			total += d[i]
+		}
+		if total == 0 {
+			break
+		}
+		t, i := r.Float64()*total, 0
+		for ; i < len(v)-1 && d[i] <= t; i++ {
+			t -= d[i]
+		}
+		centroids = append(centroids, v[i])
+	}
+	slices.Sort(centroids)
+	// lloyd iterations
+	sums, counts := make([]float64, len(centroids)), make([]int, len(centroids))
+	for range 100 {
+		clear(sums)
+		clear(counts)
+		for _, x := range v {
+			i := Nearest(centroids, x)
+			sums[i], counts[i] = sums[i]+x, counts[i]+1
+		}
+		changed := false
+		for i := range centroids {
+			if counts[i] == 0 {
+				continue
+			}
+			if c := sums[i] / float64(counts[i]); c != centroids[i] {
+				centroids[i], changed = c, true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+	slices.Sort(centroids)
+	return centroids
+}
+
+// Nearest returns the index of the centroid nearest to x.
+func Nearest(centroids []float64, x float64) int {
+	n, dist := 0, math.Inf(1)
+	for i, c := range centroids {
+		if d := math.Abs(x - c); d < dist {
+			n, dist = i, d
+		}
+	}
+	return n
+}
+
+// sample moves n randomly selected cards to the front of v.
+func sample(r *rand.Rand, v []cardrank.Card, n int) {
+	for i := range n {
+		j := i + r.Intn(len(v)-i)
+		v[i], v[j] = v[j], v[i]
+	}
+}
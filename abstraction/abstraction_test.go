@@ -0,0 +1,110 @@
+package abstraction
+
+import (
+	"context"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/cardrank/cardrank"
+)
+
+func TestCluster(t *testing.T) {
+	v := []float64{0.9, 0.1, 0.52, 0.11, 0.91, 0.5, 0.12, 0.92, 0.51}
+	exp := []float64{0.11, 0.51, 0.91}
+	for seed := range int64(10) {
+		centroids := Cluster(v, 3, seed)
+		if len(centroids) != len(exp) {
+			t.Fatalf("seed %d expected %d centroids, got: %d", seed, len(exp), len(centroids))
+		}
+		for i, c := range centroids {
+			if math.Abs(c-exp[i]) > 1e-9 {
+				t.Errorf("seed %d centroid %d expected %f, got: %f", seed, i, exp[i], c)
+			}
+		}
+	}
+	if centroids := Cluster([]float64{0.5, 0.5}, 3, 1); !slices.Equal(centroids, []float64{0.5}) {
+		t.Errorf("expected [0.5], got: %v", centroids)
+	}
+}
+
+func TestAbstraction(t *testing.T) {
+	opts := []Option{
+		WithSeed(7),
+		WithBuckets(3, 4),
+		WithSamples(40),
+		WithRollouts(8),
+		WithOpponents(16),
+	}
+	a, err := New(cardrank.Holdem, opts...)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if n := a.Streets(); n != 4 {
+		t.Fatalf("expected %d streets, got: %d", 4, n)
+	}
+	if err := a.Train(context.Background()); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	b, err := New(cardrank.Holdem, opts...)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if err := b.Train(context.Background()); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	for _, street := range []int{-1, a.Streets()} {
+		if n := a.Buckets(street); n != 0 {
+			t.Errorf("street %d expected 0 buckets, got: %d", street, n)
+		}
+	}
+	for street := range a.Streets() {
+		centroids := a.Centroids(street)
+		if len(centroids) == 0 || a.Buckets(street) < len(centroids) {
+			t.Errorf("street %d expected at most %d centroids, got: %v", street, a.Buckets(street), centroids)
+		}
+		if !slices.IsSorted(centroids) {
+			t.Errorf("street %d expected sorted centroids, got: %v", street, centroids)
+		}
+		if !slices.Equal(centroids, b.Centroids(street)) {
+			t.Errorf("street %d expected deterministic centroids %v, got: %v", street, centroids, b.Centroids(street))
+		}
+	}
+	tests := []struct {
+		strong string
+		weak   string
+		board  string
+	}{
+		{"Ah Ad", "7c 2d", ""},
+		{"Ah Ad", "7c 2d", "As Kc 9h"},
+		{"Ah Kh", "7c 2d", "Qh Jh 3c 4d"},
+		{"Ah Kh", "7c 2d", "Qh Jh Th 4d 3s"},
+	}
+	for i, test := range tests {
+		board := cardrank.Must(test.board)
+		strong, err := a.Bucket(cardrank.Must(test.strong), board)
+		if err != nil {
+			t.Fatalf("test %d expected no error, got: %v", i, err)
+		}
+		weak, err := a.Bucket(cardrank.Must(test.weak), board)
+		if err != nil {
+			t.Fatalf("test %d expected no error, got: %v", i, err)
+		}
+		if strong <= weak {
+			t.Errorf("test %d expected %d > %d", i, strong, weak)
+		}
+		if n, _ := a.Bucket(cardrank.Must(test.strong), board); n != strong {
+			t.Errorf("test %d expected deterministic bucket %d, got: %d", i, strong, n)
+		}
+	}
+	hs, ehs2 := a.Strength(cardrank.Must("Ah Kh"), cardrank.Must("Qh Jh Th 4d 3s"))
+	if hs != 1 || ehs2 != 1 {
+		t.Errorf("expected 1, 1, got: %f, %f", hs, ehs2)
+	}
+	if _, err := a.Bucket(cardrank.Must("Ah Kh"), cardrank.Must("Qh Jh")); err == nil {
+		t.Errorf("expected error")
+	}
+	if _, err := New(cardrank.Stud); err == nil {
+		t.Errorf("expected error")
+	}
+}
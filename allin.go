@@ -0,0 +1,99 @@
+package cardrank
+
+import (
+	"context"
+	"fmt"
+)
+
+// AllIn is the expected value (EV) adjusted result of an all-in, comparing
+// each position's expected share of the pot, as calculated from the odds at
+// the time of the all-in, to the actual amount won (ie, "Sklansky bucks").
+type AllIn struct {
+	// Pot is the pot.
+	Pot float64
+	// Equity is each position's equity at the time of the all-in.
+	Equity []float64
+	// Expected is each position's expected amount won.
+	Expected []float64
+	// Actual is each position's actual amount won.
+	Actual []float64
+}
+
+// NewAllIn creates a EV adjusted all-in result for the pot, from each
+// position's equity at the time of the all-in, as a fraction of the pot, and
+// the actual amount won by each position (see [Result.Split]).
+func NewAllIn(pot float64, equity, actual []float64) *AllIn {
+	a := &AllIn{
+		Pot:      pot,
+		Equity:   make([]float64, len(equity)),
+		Expected: make([]float64, len(equity)),
+		Actual:   make([]float64, len(equity)),
+	}
+	copy(a.Equity, equity)
+	copy(a.Actual, actual)
+	for i := range a.Equity {
+		a.Expected[i] = a.Equity[i] * pot
+	}
+	return a
+}
+
+// Diff returns the difference between the position's actual and expected
+// amount won, positive when the position won more than expected.
+func (a *AllIn) Diff(pos int) float64 {
+	return a.Actual[pos] - a.Expected[pos]
+}
+
+// Format satisfies the [fmt.Formatter] interface.
+func (a *AllIn) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 's', 'v':
+		for i := range a.Expected {
+			if i != 0 {
+				fmt.Fprint(f, ", ")
+			}
+			fmt.Fprintf(f, "%d: %0.1f%% %0.2f/%0.2f", i, 100*a.Equity[i], a.Expected[i], a.Actual[i])
+		}
+	default:
+		fmt.Fprintf(f, "%%!%c(ERROR=unknown verb, all-in)", verb)
+	}
+}
+
+// AllIn calculates the EV adjusted all-in result for the pot, using the
+// pockets and board at the time of the all-in, and the actual result. See
+// [NewAllIn].
+//
+// Each position's equity is its expected pot share, split between the Hi and
+// Lo winners of each board the same as [Result.Split], and for a type with
+// double boards, between the winners of each board (see [DoubleOdds.Equity]).
+//
+// Returns [ErrInvalidResult] when the result is nil. Returns
+// [ErrUnsupportedType] when enumerating the remaining pocket cards of a type
+// without a board (see [WithDeep]).
+func (typ Type) AllIn(ctx context.Context, pockets [][]Card, board []Card, pot float64, res *Result, opts ...CalcOption) (*AllIn, error) {
+	if res == nil {
+		return nil, ErrInvalidResult
+	}
+	calc := NewOddsCalc(typ, append(opts, WithPocketsBoard(pockets, board))...)
+	if calc.deep && typ.Up() && typ.Board() == 0 {
+		return nil, fmt.Errorf("%w: deep enumeration of %s", ErrUnsupportedType, typ)
+	}
+	equity := make([]float64, len(pockets))
+	if typ.Double() {
+		odds, err := calc.CalcDouble(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for i := range equity {
+			equity[i] = odds.Equity(i)
+		}
+	} else {
+		calc.shares = &potShares{shares: make([]float64, len(pockets))}
+		if _, _, err := calc.CalcErr(ctx); err != nil {
+			return nil, err
+		}
+		for i := range equity {
+			equity[i] = calc.shares.equity(i)
+		}
+	}
+	return NewAllIn(pot, equity, res.Split(pot)), nil
+}
@@ -0,0 +1,72 @@
+package cardrank
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestAllIn(t *testing.T) {
+	ctx := context.Background()
+	pockets, board := [][]Card{Must("Ah Ad"), Must("Kh Kd")}, Must("2c 7s 9d 3h")
+	res := NewResult(Holdem, &Run{Pockets: pockets, Hi: Must("2c 7s 9d 3h Ks")}, nil, false)
+	a, err := Holdem.AllIn(ctx, pockets, board, 200, res)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	exp := []float64{200 * 42.0 / 44, 200 * 2.0 / 44}
+	for i := range exp {
+		if math.Abs(a.Expected[i]-exp[i]) > 1e-9 {
+			t.Errorf("test %d expected %f, got: %f", i, exp[i], a.Expected[i])
+		}
+	}
+	if d := a.Diff(1); math.Abs(d-(200-exp[1])) > 1e-9 {
+		t.Errorf("expected %f, got: %f", 200-exp[1], d)
+	}
+	if d := a.Diff(0); math.Abs(d+exp[0]) > 1e-9 {
+		t.Errorf("expected %f, got: %f", -exp[0], d)
+	}
+	if s, exp := fmt.Sprintf("%s", a), "0: 95.5% 190.91/0.00, 1: 4.5% 9.09/200.00"; s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+	if _, err := Holdem.AllIn(ctx, pockets, board, 200, nil); !errors.Is(err, ErrInvalidResult) {
+		t.Errorf("expected error %v, got: %v", ErrInvalidResult, err)
+	}
+	studPockets := [][]Card{Must("Ah 2c 3d 4s"), Must("Kh Kd 9c 7s")}
+	studRes := NewResult(Razz, &Run{Pockets: studPockets}, nil, false)
+	if _, err := Razz.AllIn(ctx, studPockets, nil, 200, studRes, WithDeep(true)); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("expected error %v, got: %v", ErrUnsupportedType, err)
+	}
+}
+
+func TestAllInLow(t *testing.T) {
+	ctx := context.Background()
+	pockets, board := [][]Card{Must("Ah 2d Kd Ks"), Must("Qh Qs Jd Tc")}, Must("Kc Qd 3h 9s")
+	res := NewResult(OmahaHiLo, &Run{Pockets: pockets, Hi: Must("Kc 7d 3h 9s 5c")}, nil, false)
+	a, err := OmahaHiLo.AllIn(ctx, pockets, board, 1, res)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	exp, n := make([]float64, len(pockets)), 0
+	for _, c := range OmahaHiLo.DeckType().Exclude(board, pockets[0], pockets[1]) {
+		run := &Run{Pockets: pockets, Hi: append(slices.Clone(board), c)}
+		for i, f := range NewResult(OmahaHiLo, run, nil, false).Split(1) {
+			exp[i] += f
+		}
+		n++
+	}
+	for i := range exp {
+		if exp[i] /= float64(n); math.Abs(a.Equity[i]-exp[i]) > 1e-9 {
+			t.Errorf("test %d expected %f, got: %f", i, exp[i], a.Equity[i])
+		}
+	}
+	if s := a.Equity[0] + a.Equity[1]; math.Abs(s-1) > 1e-9 {
+		t.Errorf("expected equity sum 1, got: %f", s)
+	}
+	if a.Actual[0] != 1 {
+		t.Errorf("expected 1, got: %f", a.Actual[0])
+	}
+}
@@ -1,6 +1,7 @@
 package cardrank
 
 import (
+	"context"
 	"fmt"
 	"testing"
 )
@@ -133,6 +134,60 @@ func BenchmarkType(b *testing.B) {
 	}
 }
 
+func BenchmarkBackend(b *testing.B) {
+	active := ActiveBackend()
+	defer SetBackend(active)
+	b.Logf("active: %s", active.Name())
+	for _, backend := range Backends() {
+		if err := SetBackend(backend); err != nil {
+			b.Fatalf("expected no error, got: %v", err)
+		}
+		b.Run(backend.Name(), func(b *testing.B) {
+			benchType(b, Holdem, b.N, false)
+		})
+	}
+}
+
+func BenchmarkEvalPockets(b *testing.B) {
+	v := shuffled(DeckFrench)
+	board, pockets := v[:5], make([][]Card, 9)
+	for i := range pockets {
+		pockets[i] = v[5+2*i : 7+2*i]
+	}
+	b.Run("Eval", func(b *testing.B) {
+		for range b.N {
+			for _, pocket := range pockets {
+				benchE = Holdem.Eval(pocket, board).HiRank
+			}
+		}
+	})
+	b.Run("EvalPockets", func(b *testing.B) {
+		for range b.N {
+			benchE = Holdem.EvalPockets(pockets, board)[0].HiRank
+		}
+	})
+}
+
+func BenchmarkOddsReuse(b *testing.B) {
+	pockets := [][]Card{
+		Must("Ah 2h Kc Qd"),
+		Must("As 3d Js Ts"),
+		Must("7c 8c 9d 4h"),
+	}
+	board := Must("Qh 7h 2c")
+	for _, reuse := range []bool{false, true} {
+		b.Run(fmt.Sprintf("reuse=%t", reuse), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				hi, _, err := OmahaHiLo.OddsErr(context.Background(), pockets, board, WithReuse(reuse))
+				if err != nil || hi.Total == 0 {
+					b.Fatalf("expected no error, got: %v", err)
+				}
+			}
+		})
+	}
+}
+
 func benchType(b *testing.B, typ Type, n int, eval bool) {
 	b.Helper()
 	v, p, m, count, ev := shuffled(typ.DeckType()), typ.Pocket(), typ.Board(), 0, EvalOf(typ)
@@ -0,0 +1,485 @@
+// Package betting contains a no-limit betting round state machine for use
+// with a [cardrank.Dealer].
+package betting
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/cardrank/cardrank"
+)
+
+// Error is a error.
+type Error string
+
+// Error satisfies the [error] interface.
+func (err Error) Error() string {
+	return string(err)
+}
+
+// Error values.
+const (
+	// ErrInvalidAction is the invalid action error.
+	ErrInvalidAction Error = "invalid action"
+	// ErrInvalidAmount is the invalid amount error.
+	ErrInvalidAmount Error = "invalid amount"
+	// ErrOpenRound is the open betting round error.
+	ErrOpenRound Error = "open betting round"
+)
+
+// Action is a betting action.
+type Action uint8
+
+// Actions.
+const (
+	// Check checks.
+	Check Action = iota
+	// Call calls the current bet, or the remaining stack when less than the
+	// current bet.
+	Call
+	// Bet opens the betting on a street.
+	Bet
+	// Raise raises the current bet.
+	Raise
+	// Fold folds, deactivating the position on the dealer.
+	Fold
+)
+
+// String satisfies the [fmt.Stringer] interface.
+func (a Action) String() string {
+	switch a {
+	case Check:
+		return "check"
+	case Call:
+		return "call"
+	case Bet:
+		return "bet"
+	case Raise:
+		return "raise"
+	case Fold:
+		return "fold"
+	}
+	return fmt.Sprintf("Action(%d)", a)
+}
+
+// Act is a recorded betting action.
+type Act struct {
+	// Street is the dealer street index.
+	Street int
+	// Position is the acting position.
+	Position int
+	// Action is the action.
+	Action Action
+	// Amount is the amount added to the pot by the action.
+	Amount int64
+	// AllIn is true when the action committed the position's remaining
+	// stack.
+	AllIn bool
+}
+
+// Pot is a main or side pot.
+type Pot struct {
+	// Amount is the pot amount.
+	Amount int64
+	// Positions are the positions eligible to win the pot.
+	Positions []int
+}
+
+// Hand is a no-limit betting state machine for a hand dealt by a dealer,
+// tracking stacks, the current bet and minimum raise, the position to act,
+// and the pot for each street.
+//
+// Forced bets are posted prior to the first street (see [Hand.PostBlinds]
+// and [Hand.PostAntes]). Each call to [Hand.Next] deals the next street,
+// and starts its betting round. Folded positions are deactivated on the
+// dealer (see [cardrank.Dealer.Deactivate]). Streets dealt on runs after the
+// first are not bet.
+type Hand struct {
+	d         *cardrank.Dealer
+	bigBlind  int64
+	stacks    []int64
+	committed []int64
+	total     []int64
+	acted     map[int]bool
+	bet       int64
+	minRaise  int64
+	first     int
+	pos       int
+	acts      []Act
+}
+
+// New creates a new betting state machine for the dealer, using the big
+// blind as the minimum bet and the initial stacks for each of the dealer's
+// positions. The dealer must not have dealt any streets.
+func New(d *cardrank.Dealer, bigBlind int64, stacks ...int64) (*Hand, error) {
+	switch {
+	case d.Street() != -1:
+		return nil, fmt.Errorf("%w: already dealt", cardrank.ErrInvalidStreet)
+	case len(stacks) != d.Count:
+		return nil, fmt.Errorf("%w: expected %d stacks, got: %d", ErrInvalidAmount, d.Count, len(stacks))
+	case bigBlind <= 0:
+		return nil, fmt.Errorf("%w: big blind %d", ErrInvalidAmount, bigBlind)
+	}
+	for i, stack := range stacks {
+		if stack < 0 {
+			return nil, fmt.Errorf("%w: position %d stack %d", ErrInvalidAmount, i, stack)
+		}
+	}
+	return &Hand{
+		d:         d,
+		bigBlind:  bigBlind,
+		stacks:    slices.Clone(stacks),
+		committed: make([]int64, d.Count),
+		total:     make([]int64, d.Count),
+		acted:     make(map[int]bool),
+		minRaise:  bigBlind,
+		first:     d.Origin,
+		pos:       -1,
+	}, nil
+}
+
+// PostAntes posts an ante for each active position, in deal order. Antes
+// are dead, and do not count towards the first street's bet. See
+// [cardrank.Dealer.Post].
+func (h *Hand) PostAntes(amount int64) error {
+	for _, position := range h.d.DealOrder() {
+		if h.d.Active[position] {
+			if err := h.post(position, "Ante", amount, false); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// PostBlinds posts the blinds for the active positions in deal order, using
+// the type's blind names. Blinds are live, and the first position to act on
+// the first street is the active position following the last blind. When
+// heads-up, the button posts the first blind, and as such acts first on the
+// first street and last on later streets. See [cardrank.Dealer.PostBlinds].
+func (h *Hand) PostBlinds(amounts ...int64) error {
+	var positions []int
+	for _, position := range h.d.DealOrder() {
+		if h.d.Active[position] {
+			positions = append(positions, position)
+		}
+	}
+	if len(positions) == 2 {
+		positions[0], positions[1] = positions[1], positions[0]
+	}
+	for i, position := range positions {
+		if i == len(amounts) {
+			break
+		}
+		name := "Blind"
+		if i < len(h.d.Blinds) {
+			name = h.d.Blinds[i]
+		}
+		if err := h.post(position, name, amounts[i], true); err != nil {
+			return err
+		}
+		h.first = h.after(position)
+	}
+	return nil
+}
+
+// post posts a forced bet for the position, limited to the position's
+// stack.
+func (h *Hand) post(position int, name string, amount int64, live bool) error {
+	if amount < 0 {
+		return fmt.Errorf("%w: %d", ErrInvalidAmount, amount)
+	}
+	amount = min(amount, h.stacks[position])
+	if err := h.d.Post(position, name, amount); err != nil {
+		return err
+	}
+	h.stacks[position] -= amount
+	h.total[position] += amount
+	if live {
+		h.committed[position] += amount
+		h.bet = max(h.bet, h.committed[position])
+	}
+	return nil
+}
+
+// Next iterates the dealer (see [cardrank.Dealer.Next]), starting the
+// betting round for the dealt street. Returns false when there are no
+// additional streets or runs.
+//
+// Returns false without iterating the dealer while the current betting round
+// is not complete (see [Hand.Done]) or there are pending pocket discards
+// (see [cardrank.Dealer.Pending]). Use [Hand.NextErr] to distinguish these
+// from the end of the hand.
+func (h *Hand) Next() bool {
+	ok, _ := h.NextErr()
+	return ok
+}
+
+// NextErr iterates the dealer, starting the betting round for the dealt
+// street (see [Hand.Next]). Returns [ErrOpenRound] without iterating the
+// dealer while the current betting round is not complete, or the error
+// returned by [cardrank.Dealer.NextErr].
+func (h *Hand) NextErr() (bool, error) {
+	if h.pos != -1 {
+		return false, fmt.Errorf("%w: position %d to act", ErrOpenRound, h.pos)
+	}
+	ok, err := h.d.NextErr()
+	if !ok || err != nil {
+		return false, err
+	}
+	h.start()
+	return true, nil
+}
+
+// start starts the betting round for the dealer's current street.
+func (h *Hand) start() {
+	clear(h.acted)
+	h.minRaise, h.pos = h.bigBlind, -1
+	if r, _ := h.d.Run(); r != 0 {
+		return
+	}
+	first := h.first
+	if h.d.Street() != 0 {
+		h.bet, first = 0, h.d.Origin
+		clear(h.committed)
+	}
+	h.pos = h.from(first)
+}
+
+// SetFirst sets the first position to act on the current street, such as
+// for the bring-in on stud streets. Returns [cardrank.ErrInvalidPosition]
+// for a position that cannot act, or [cardrank.ErrInvalidStreet] when an
+// action has already occurred on the street.
+func (h *Hand) SetFirst(position int) error {
+	switch {
+	case position < 0 || h.d.Count <= position, !h.needs(position):
+		return fmt.Errorf("%w: %d", cardrank.ErrInvalidPosition, position)
+	case h.pos == -1 || len(h.acted) != 0:
+		return fmt.Errorf("%w: action already occurred", cardrank.ErrInvalidStreet)
+	}
+	h.pos = position
+	return nil
+}
+
+// Position returns the position to act, or -1 when the betting round is
+// complete.
+func (h *Hand) Position() int {
+	return h.pos
+}
+
+// Done returns true when the betting round is complete.
+func (h *Hand) Done() bool {
+	return h.pos == -1
+}
+
+// Stack returns the position's remaining stack.
+func (h *Hand) Stack(position int) int64 {
+	return h.stacks[position]
+}
+
+// Committed returns the amount committed by the position on the current
+// street.
+func (h *Hand) Committed(position int) int64 {
+	return h.committed[position]
+}
+
+// Bet returns the current street's bet.
+func (h *Hand) Bet() int64 {
+	return h.bet
+}
+
+// MinRaise returns the minimum raise increment, which is the size of the
+// last full bet or raise on the current street, or the big blind.
+func (h *Hand) MinRaise() int64 {
+	return h.minRaise
+}
+
+// ToCall returns the amount the position must add to call the current bet.
+func (h *Hand) ToCall(position int) int64 {
+	return min(h.bet-h.committed[position], h.stacks[position])
+}
+
+// Valid returns the valid actions for the position to act.
+func (h *Hand) Valid() []Action {
+	if h.pos == -1 {
+		return nil
+	}
+	var v []Action
+	if h.committed[h.pos] == h.bet {
+		v = append(v, Check)
+	} else {
+		v = append(v, Call)
+	}
+	switch {
+	case h.bet == 0:
+		v = append(v, Bet)
+	case h.canRaise(h.pos):
+		v = append(v, Raise)
+	}
+	return append(v, Fold)
+}
+
+// Act performs the action for the position to act. The amount is the
+// position's total committed on the street after a bet or raise (ie, "raise
+// to"), and is ignored for other actions. A bet or raise smaller than the
+// minimum is only valid when committing the position's remaining stack, and
+// does not reopen the betting for positions that have already acted.
+//
+// Returns [cardrank.ErrInvalidPosition] when the position is not the
+// position to act, [ErrInvalidAction] for an invalid action, or
+// [ErrInvalidAmount] for an invalid amount.
+func (h *Hand) Act(position int, action Action, amount int64) error {
+	if h.pos == -1 || position != h.pos {
+		return fmt.Errorf("%w: %d is not to act", cardrank.ErrInvalidPosition, position)
+	}
+	var add int64
+	switch action {
+	case Check:
+		if h.committed[position] != h.bet {
+			return fmt.Errorf("%w: cannot check facing %d", ErrInvalidAction, h.bet)
+		}
+	case Call:
+		if h.committed[position] == h.bet {
+			return fmt.Errorf("%w: nothing to call", ErrInvalidAction)
+		}
+		add = h.ToCall(position)
+	case Bet, Raise:
+		switch {
+		case action == Bet && h.bet != 0:
+			return fmt.Errorf("%w: cannot bet facing %d", ErrInvalidAction, h.bet)
+		case action == Raise && h.bet == 0:
+			return fmt.Errorf("%w: nothing to raise", ErrInvalidAction)
+		case action == Raise && !h.canRaise(position):
+			return fmt.Errorf("%w: betting not reopened", ErrInvalidAction)
+		}
+		add = amount - h.committed[position]
+		switch allIn := add == h.stacks[position]; {
+		case amount <= h.bet, h.stacks[position] < add:
+			return fmt.Errorf("%w: %s %d", ErrInvalidAmount, action, amount)
+		case amount-h.bet < h.minRaise && !allIn:
+			return fmt.Errorf("%w: %s %d less than minimum %d", ErrInvalidAmount, action, amount, h.bet+h.minRaise)
+		}
+		if size := amount - h.bet; h.minRaise <= size {
+			// full bet or raise reopens the betting
+			h.minRaise = size
+			clear(h.acted)
+		}
+		h.bet = amount
+	case Fold:
+		if err := h.d.DeactivateErr(position); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%w: %d", ErrInvalidAction, action)
+	}
+	h.stacks[position] -= add
+	h.committed[position] += add
+	h.total[position] += add
+	h.acted[position] = true
+	h.acts = append(h.acts, Act{
+		Street:   h.d.Street(),
+		Position: position,
+		Action:   action,
+		Amount:   add,
+		AllIn:    add != 0 && h.stacks[position] == 0,
+	})
+	h.pos = h.from(h.after(position))
+	return nil
+}
+
+// canRaise returns true when the position can raise the current bet.
+func (h *Hand) canRaise(position int) bool {
+	return !h.acted[position] && h.bet-h.committed[position] < h.stacks[position]
+}
+
+// needs returns true when the position needs to act.
+func (h *Hand) needs(position int) bool {
+	switch {
+	case !h.d.Active[position], h.stacks[position] == 0, h.acted[position] && h.committed[position] == h.bet:
+		return false
+	case h.committed[position] < h.bet:
+		return true
+	}
+	// not facing a bet, so only act when another position can respond
+	for i := range h.d.Count {
+		if i != position && h.d.Active[i] && h.stacks[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// from returns the first position needing to act, starting with the
+// position in deal order, or -1 when the betting round is complete.
+func (h *Hand) from(position int) int {
+	if len(h.d.Active) < 2 {
+		return -1
+	}
+	for i := range h.d.Count {
+		if p := h.step(position, i); h.needs(p) {
+			return p
+		}
+	}
+	return -1
+}
+
+// after returns the position following the position in deal order.
+func (h *Hand) after(position int) int {
+	return h.step(position, 1)
+}
+
+// step returns the position n steps from the position in deal order.
+func (h *Hand) step(position, n int) int {
+	if h.d.Reverse {
+		n = -n
+	}
+	return ((position+n)%h.d.Count + h.d.Count) % h.d.Count
+}
+
+// Acts returns the recorded actions, in the order acted.
+func (h *Hand) Acts() []Act {
+	return h.acts
+}
+
+// Pot returns the total amount in the pot, including forced bets.
+func (h *Hand) Pot() int64 {
+	var pot int64
+	for _, amount := range h.total {
+		pot += amount
+	}
+	return pot
+}
+
+// Pots returns the main pot followed by any side pots, split by the amounts
+// committed by the active positions. Amounts committed by inactive
+// positions are included in the pots, but inactive positions are not
+// eligible to win them. A pot with a single eligible position is an
+// uncalled amount to be returned to that position.
+func (h *Hand) Pots() []Pot {
+	var levels []int64
+	for i, amount := range h.total {
+		if h.d.Active[i] && amount != 0 {
+			levels = append(levels, amount)
+		}
+	}
+	slices.Sort(levels)
+	levels = slices.Compact(levels)
+	var pots []Pot
+	var prev int64
+	for n, level := range levels {
+		pot := Pot{}
+		for i, amount := range h.total {
+			if n == len(levels)-1 {
+				// last pot includes amounts beyond the largest active amount
+				pot.Amount += max(amount-prev, 0)
+			} else {
+				pot.Amount += min(max(amount-prev, 0), level-prev)
+			}
+			if h.d.Active[i] && level <= amount {
+				pot.Positions = append(pot.Positions, i)
+			}
+		}
+		pots, prev = append(pots, pot), level
+	}
+	return pots
+}
@@ -0,0 +1,280 @@
+package betting
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/cardrank/cardrank"
+)
+
+type testAct struct {
+	pos    int
+	action Action
+	amount int64
+	err    error
+}
+
+func TestHand(t *testing.T) {
+	d := cardrank.NewSeededDealer(cardrank.Holdem.Desc(), 1, 1, 3)
+	d.SetButton(0)
+	h, err := New(d, 2, 100, 100, 100)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if err := h.PostBlinds(1, 2); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !h.Next() {
+		t.Fatalf("expected next")
+	}
+	if pos := h.Position(); pos != 0 {
+		t.Fatalf("expected 0, got: %d", pos)
+	}
+	if v, exp := h.Valid(), []Action{Call, Raise, Fold}; !slices.Equal(v, exp) {
+		t.Errorf("expected %v, got: %v", exp, v)
+	}
+	actAll(t, h, []testAct{
+		{1, Call, 0, cardrank.ErrInvalidPosition},
+		{0, Check, 0, ErrInvalidAction},
+		{0, Bet, 6, ErrInvalidAction},
+		{0, Raise, 3, ErrInvalidAmount},
+		{0, Raise, 6, nil},
+		{1, Call, 0, nil},
+		{2, Raise, 20, nil},
+		{0, Fold, 0, nil},
+		{1, Raise, 30, ErrInvalidAmount},
+		{1, Call, 0, nil},
+	})
+	if !h.Done() {
+		t.Fatalf("expected done, got position: %d", h.Position())
+	}
+	if d.Active[0] {
+		t.Errorf("expected position 0 to be inactive")
+	}
+	if pot := h.Pot(); pot != 46 {
+		t.Errorf("expected 46, got: %d", pot)
+	}
+	if !h.Next() {
+		t.Fatalf("expected next")
+	}
+	if bet, pos := h.Bet(), h.Position(); bet != 0 || pos != 1 {
+		t.Fatalf("expected 0 and 1, got: %d and %d", bet, pos)
+	}
+	actAll(t, h, []testAct{
+		{1, Call, 0, ErrInvalidAction},
+		{1, Check, 0, nil},
+		{2, Bet, 1, ErrInvalidAmount},
+		{2, Bet, 30, nil},
+		{1, Raise, 80, nil},
+		{2, Call, 0, nil},
+	})
+	if stack := h.Stack(2); stack != 0 {
+		t.Errorf("expected 0, got: %d", stack)
+	}
+	// all-in, so remaining streets are not bet
+	var n int
+	for ; h.Next(); n++ {
+		if !h.Done() {
+			t.Errorf("expected done on street %d", d.Street())
+		}
+	}
+	if n != 2 {
+		t.Errorf("expected 2, got: %d", n)
+	}
+	pots := h.Pots()
+	if len(pots) != 1 || pots[0].Amount != 206 || !slices.Equal(pots[0].Positions, []int{1, 2}) {
+		t.Errorf("expected [{206 [1 2]}], got: %v", pots)
+	}
+	if n := len(h.Acts()); n != 9 {
+		t.Errorf("expected 9, got: %d", n)
+	}
+}
+
+func TestHandIncompleteRaise(t *testing.T) {
+	d := cardrank.NewSeededDealer(cardrank.Holdem.Desc(), 1, 1, 3)
+	d.SetButton(0)
+	h, err := New(d, 2, 100, 13, 100)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if err := h.PostBlinds(1, 2); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !h.Next() {
+		t.Fatalf("expected next")
+	}
+	actAll(t, h, []testAct{
+		{0, Raise, 10, nil},
+		{1, Raise, 13, nil},
+	})
+	if v, exp := h.Valid(), []Action{Call, Raise, Fold}; !slices.Equal(v, exp) {
+		t.Errorf("expected %v, got: %v", exp, v)
+	}
+	if minRaise := h.MinRaise(); minRaise != 8 {
+		t.Errorf("expected 8, got: %d", minRaise)
+	}
+	actAll(t, h, []testAct{
+		{2, Call, 0, nil},
+	})
+	// incomplete raise does not reopen the betting
+	if v, exp := h.Valid(), []Action{Call, Fold}; !slices.Equal(v, exp) {
+		t.Errorf("expected %v, got: %v", exp, v)
+	}
+	actAll(t, h, []testAct{
+		{0, Raise, 30, ErrInvalidAction},
+		{0, Call, 0, nil},
+	})
+	if !h.Done() {
+		t.Fatalf("expected done, got position: %d", h.Position())
+	}
+	if !h.Next() {
+		t.Fatalf("expected next")
+	}
+	if pos := h.Position(); pos != 2 {
+		t.Errorf("expected 2, got: %d", pos)
+	}
+}
+
+func TestHandFold(t *testing.T) {
+	d := cardrank.NewSeededDealer(cardrank.Holdem.Desc(), 1, 1, 2)
+	d.SetButton(0)
+	h, err := New(d, 2, 100, 100)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if err := h.PostBlinds(1, 2); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if h.Next(); h.Position() != 0 {
+		t.Fatalf("expected 0, got: %d", h.Position())
+	}
+	if ok, err := h.NextErr(); ok || !errors.Is(err, ErrOpenRound) {
+		t.Errorf("expected error %v, got: %t %v", ErrOpenRound, ok, err)
+	}
+	if h.Next() || h.Position() != 0 {
+		t.Errorf("expected no next without iterating while betting, got: %d", h.Position())
+	}
+	actAll(t, h, []testAct{
+		{0, Fold, 0, nil},
+	})
+	if !h.Done() {
+		t.Fatalf("expected done, got position: %d", h.Position())
+	}
+	if h.Next() {
+		t.Errorf("expected no next")
+	}
+	pots := h.Pots()
+	if len(pots) != 1 || pots[0].Amount != 3 || !slices.Equal(pots[0].Positions, []int{1}) {
+		t.Errorf("expected [{3 [1]}], got: %v", pots)
+	}
+}
+
+func TestHandHeadsUp(t *testing.T) {
+	d := cardrank.NewSeededDealer(cardrank.Holdem.Desc(), 1, 1, 3)
+	d.SetButton(2)
+	d.Deactivate(1)
+	h, err := New(d, 2, 100, 100, 100)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if err := h.PostBlinds(1, 2); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	exp := []cardrank.Post{
+		{Position: 2, Name: "Small Blind", Amount: 1},
+		{Position: 0, Name: "Big Blind", Amount: 2},
+	}
+	if posts := d.Posts(); !slices.Equal(posts, exp) {
+		t.Errorf("expected %v, got: %v", exp, posts)
+	}
+	if !h.Next() {
+		t.Fatalf("expected next")
+	}
+	// button acts first preflop
+	if pos := h.Position(); pos != 2 {
+		t.Fatalf("expected 2, got: %d", pos)
+	}
+	actAll(t, h, []testAct{
+		{0, Check, 0, cardrank.ErrInvalidPosition},
+		{2, Call, 0, nil},
+		{0, Check, 0, nil},
+	})
+	if !h.Done() {
+		t.Fatalf("expected done, got position: %d", h.Position())
+	}
+	if !h.Next() {
+		t.Fatalf("expected next")
+	}
+	// button acts last postflop
+	if pos := h.Position(); pos != 0 {
+		t.Fatalf("expected 0, got: %d", pos)
+	}
+	actAll(t, h, []testAct{
+		{0, Check, 0, nil},
+		{2, Bet, 2, nil},
+		{0, Call, 0, nil},
+	})
+	if pot := h.Pot(); pot != 8 {
+		t.Errorf("expected 8, got: %d", pot)
+	}
+}
+
+func TestPots(t *testing.T) {
+	d := cardrank.NewSeededDealer(cardrank.Holdem.Desc(), 1, 1, 4)
+	d.SetButton(0)
+	h, err := New(d, 2, 10, 50, 100, 100)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if err := h.PostAntes(1); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if err := h.PostBlinds(1, 2); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if n := len(d.Posts()); n != 6 {
+		t.Errorf("expected 6, got: %d", n)
+	}
+	h.Next()
+	actAll(t, h, []testAct{
+		{3, Raise, 60, nil},
+		{0, Call, 0, nil},
+		{1, Call, 0, nil},
+		{2, Fold, 0, nil},
+	})
+	if !h.Done() {
+		t.Fatalf("expected done, got position: %d", h.Position())
+	}
+	exp := []Pot{
+		{33, []int{0, 1, 3}},
+		{80, []int{1, 3}},
+		{11, []int{3}},
+	}
+	pots := h.Pots()
+	if len(pots) != len(exp) {
+		t.Fatalf("expected %d pots, got: %d", len(exp), len(pots))
+	}
+	var sum int64
+	for i, pot := range pots {
+		if pot.Amount != exp[i].Amount || !slices.Equal(pot.Positions, exp[i].Positions) {
+			t.Errorf("pot %d expected %v, got: %v", i, exp[i], pot)
+		}
+		sum += pot.Amount
+	}
+	if pot := h.Pot(); pot != sum {
+		t.Errorf("expected %d, got: %d", sum, pot)
+	}
+}
+
+func actAll(t *testing.T, h *Hand, acts []testAct) {
+	t.Helper()
+	for i, act := range acts {
+		switch err := h.Act(act.pos, act.action, act.amount); {
+		case act.err == nil && err != nil:
+			t.Fatalf("act %d expected no error, got: %v", i, err)
+		case act.err != nil && !errors.Is(err, act.err):
+			t.Fatalf("act %d expected error %v, got: %v", i, act.err, err)
+		}
+	}
+}
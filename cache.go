@@ -0,0 +1,151 @@
+package cardrank
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// OddsCache is a cache of calculated odds, keyed by the canonicalized hand
+// state of a [OddsCalc]. See [WithCache].
+type OddsCache interface {
+	// Get returns the cached Hi and Lo odds for the key.
+	Get(key string) (*Odds, *Odds, bool)
+	// Put caches the Hi and Lo odds for the key.
+	Put(key string, hi, lo *Odds)
+}
+
+// MemoryCache is a in-memory odds cache, safe for concurrent use. The cache
+// can be persisted to disk (see [MemoryCache.Save] and [MemoryCache.Load]).
+type MemoryCache struct {
+	mu     sync.RWMutex
+	m      map[string]cacheEntry
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// cacheEntry is a cached Hi and Lo odds.
+type cacheEntry struct {
+	Hi *Odds
+	Lo *Odds
+}
+
+// NewMemoryCache creates a new in-memory odds cache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		m: make(map[string]cacheEntry),
+	}
+}
+
+// Get satisfies the [OddsCache] interface.
+func (c *MemoryCache) Get(key string) (*Odds, *Odds, bool) {
+	c.mu.RLock()
+	e, ok := c.m[key]
+	c.mu.RUnlock()
+	if !ok {
+		c.misses.Add(1)
+		return nil, nil, false
+	}
+	c.hits.Add(1)
+	return e.Hi, e.Lo, true
+}
+
+// Put satisfies the [OddsCache] interface.
+func (c *MemoryCache) Put(key string, hi, lo *Odds) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.m[key] = cacheEntry{Hi: hi, Lo: lo}
+}
+
+// Len returns the number of cached entries.
+func (c *MemoryCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.m)
+}
+
+// Stats returns the cache hit and miss counts.
+func (c *MemoryCache) Stats() (int64, int64) {
+	return c.hits.Load(), c.misses.Load()
+}
+
+// Save writes the cached entries to w.
+func (c *MemoryCache) Save(w io.Writer) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if err := gob.NewEncoder(w).Encode(c.m); err != nil {
+		return fmt.Errorf("unable to save cache: %w", err)
+	}
+	return nil
+}
+
+// Load reads cached entries from r, previously written with
+// [MemoryCache.Save], adding them to the cache.
+func (c *MemoryCache) Load(r io.Reader) error {
+	var m map[string]cacheEntry
+	if err := gob.NewDecoder(r).Decode(&m); err != nil {
+		return fmt.Errorf("unable to load cache: %w", err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, e := range m {
+		c.m[k] = e
+	}
+	return nil
+}
+
+// key returns the canonicalized hand state of the calc, where the cards of
+// each pocket and board are ordered, and every option affecting the
+// calculation is included.
+func (c *OddsCalc) key() string {
+	var sb strings.Builder
+	run := c.runs[len(c.runs)-1]
+	fmt.Fprintf(&sb, "%s|%t|%t|%t|", c.typ.Id(), c.deep, c.folded, c.discard)
+	for i, pocket := range run.Pockets {
+		if c.active != nil && !c.active[i] {
+			sb.WriteString("-")
+		}
+		writeKeyCards(&sb, pocket)
+	}
+	sb.WriteString("|")
+	writeKeyCards(&sb, run.Hi)
+	writeKeyCards(&sb, run.Lo)
+	if c.discard {
+		for _, r := range c.runs {
+			writeKeyCards(&sb, r.Discard)
+		}
+	}
+	sb.WriteString("|")
+	writeKeyCards(&sb, c.dead)
+	sb.WriteString("|")
+	for _, pos := range slices.Sorted(maps.Keys(c.known)) {
+		sb.WriteString(strconv.Itoa(pos))
+		sb.WriteString(":")
+		writeKeyCards(&sb, c.known[pos])
+	}
+	// prior runs' boards and pockets are excluded from the unused cards
+	for _, r := range c.runs[:len(c.runs)-1] {
+		writeKeyCards(&sb, r.Hi)
+		writeKeyCards(&sb, r.Lo)
+	}
+	return sb.String()
+}
+
+// writeKeyCards writes the ordered cards to sb.
+func writeKeyCards(sb *strings.Builder, v []Card) {
+	v = slices.Clone(v)
+	slices.SortFunc(v, func(a, b Card) int {
+		return a.Index() - b.Index()
+	})
+	sb.WriteString("[")
+	for _, c := range v {
+		sb.WriteString(c.String())
+	}
+	sb.WriteString("]")
+}
@@ -0,0 +1,62 @@
+package cardrank
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestMemoryCache(t *testing.T) {
+	ctx := context.Background()
+	cache := NewMemoryCache()
+	board := Must("Qh 7h 2c")
+	exp, _, _ := Holdem.Odds(ctx, [][]Card{Must("Ah Kh"), Must("Qs Qd")}, board)
+	for i, pockets := range [][][]Card{
+		{Must("Ah Kh"), Must("Qs Qd")},
+		{Must("Kh Ah"), Must("Qd Qs")},
+		{Must("Ah Kh"), Must("Qs Qd")},
+	} {
+		odds, _, ok := Holdem.Odds(ctx, pockets, board, WithCache(cache))
+		switch {
+		case !ok:
+			t.Fatalf("test %d expected ok", i)
+		case odds.Total != exp.Total || !reflect.DeepEqual(odds.Counts, exp.Counts):
+			t.Errorf("test %d expected %v, got: %v", i, exp.Counts, odds.Counts)
+		}
+	}
+	if hits, misses := cache.Stats(); hits != 2 || misses != 1 {
+		t.Errorf("expected 2/1, got: %d/%d", hits, misses)
+	}
+	// different state
+	if _, _, ok := Holdem.Odds(ctx, [][]Card{Must("Qs Qd"), Must("Ah Kh")}, board, WithCache(cache)); !ok {
+		t.Fatalf("expected ok")
+	}
+	if _, _, ok := Holdem.Odds(ctx, [][]Card{Must("Ah Kh"), Must("Qs Qd")}, board, WithCache(cache), WithDeadCards(Must("2d"))); !ok {
+		t.Fatalf("expected ok")
+	}
+	if n := cache.Len(); n != 3 {
+		t.Errorf("expected 3, got: %d", n)
+	}
+	// persist
+	var buf bytes.Buffer
+	if err := cache.Save(&buf); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	loaded := NewMemoryCache()
+	if err := loaded.Load(&buf); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	odds, _, ok := Holdem.Odds(ctx, [][]Card{Must("Ah Kh"), Must("Qs Qd")}, board, WithCache(loaded))
+	switch {
+	case !ok:
+		t.Fatalf("expected ok")
+	case !reflect.DeepEqual(odds.Counts, exp.Counts):
+		t.Errorf("expected %v, got: %v", exp.Counts, odds.Counts)
+	case len(odds.Outs[0]) != len(exp.Outs[0]):
+		t.Errorf("expected %d outs, got: %d", len(exp.Outs[0]), len(odds.Outs[0]))
+	}
+	if hits, misses := loaded.Stats(); hits != 1 || misses != 0 {
+		t.Errorf("expected 1/0, got: %d/%d", hits, misses)
+	}
+}
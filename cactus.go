@@ -7,23 +7,43 @@ var (
 	unique5       map[uint32]EvalRank
 	sokoFlush4    map[uint32]EvalRank
 	sokoStraight4 map[uint32]EvalRank
+	partial4      map[uint32]EvalRank
+	partialHi     []EvalRank
 )
 
 func init() {
 	flush5, unique5 = cactusMaps()
 	sokoFlush4, sokoStraight4 = sokoMaps()
+	partial4, partialHi = partialMaps()
 	cactus = Cactus
 }
 
 // Cactus is a Cactus Kev rank eval func, using lookup maps generated on the
-// fly.
+// fly. Does not allocate, and is always available regardless of the build
+// tags or the [RankCactus] backend (see [CactusFlush] and [CactusUnique]).
 //
 // See: https://archive.is/G6GZg
 func Cactus(c0, c1, c2, c3, c4 Card) EvalRank {
 	if c0&c1&c2&c3&c4&0xf000 != 0 {
-		return flush5[primeProductBits(uint32(c0|c1|c2|c3|c4)>>16)]
+		return CactusFlush(uint16((c0 | c1 | c2 | c3 | c4) >> 16))
 	}
-	return unique5[primeProduct(c0, c1, c2, c3, c4)]
+	return CactusUnique(PrimeProduct(c0, c1, c2, c3, c4))
+}
+
+// CactusFlush returns the Cactus rank of a 5 card [Flush] or [StraightFlush]
+// from the precomputed flush table, where the rank mask has bit n set for
+// each card of [Rank] n (ie, bits 16-28 of a [Card]). Returns 0 when the mask
+// does not have exactly 5 bits set.
+func CactusFlush(mask uint16) EvalRank {
+	return flush5[primeProductBits(uint32(mask))]
+}
+
+// CactusUnique returns the Cactus rank of 5 cards that are not all of the
+// same suit from the precomputed unique table, where product is the prime
+// product of the card ranks (see [PrimeProduct]). Returns 0 for a product not
+// of 5 cards.
+func CactusUnique(product uint32) EvalRank {
+	return unique5[product]
 }
 
 // cactusMaps builds the cactus flush and unique5 maps.
@@ -133,8 +153,32 @@ func cactusMaps() (map[uint32]EvalRank, map[uint32]EvalRank) {
 //	Pair
 //	Nothing
 func RankSoko(c0, c1, c2, c3, c4 Card) EvalRank {
+	return rankSoko(TwoPair, c0, c1, c2, c3, c4)
+}
+
+// RankSokoUnder is a [Soko] rank eval func, ranking a Four Flush and Four
+// Straight under [Pair]'s, and over [Nothing]:
+//
+//	Straight Flush
+//	Four of a Kind
+//	Full House
+//	Flush
+//	Straight
+//	Three of a Kind
+//	Two Pair
+//	Pair
+//	Four Flush
+//	Four Straight
+//	Nothing
+func RankSokoUnder(c0, c1, c2, c3, c4 Card) EvalRank {
+	return rankSoko(Pair, c0, c1, c2, c3, c4)
+}
+
+// rankSoko is a [Soko] rank eval func, where over is the rank directly over a
+// Four Flush.
+func rankSoko(over EvalRank, c0, c1, c2, c3, c4 Card) EvalRank {
 	rank := RankCactus(c0, c1, c2, c3, c4)
-	if rank <= TwoPair {
+	if rank <= over {
 		return rank
 	}
 	r, v := Invalid, []Card{c0, c1, c2, c3, c4}
@@ -153,9 +197,9 @@ func RankSoko(c0, c1, c2, c3, c4 Card) EvalRank {
 		}
 	}
 	if r != Invalid {
-		return r
+		return r - TwoPair + over
 	}
-	return 1 + sokoStraight - TwoPair + rank
+	return toSoko(rank, over)
 }
 
 // sokoMaps generates [Soko] flush4 and straight4 maps.
@@ -181,6 +225,103 @@ func sokoMaps() (map[uint32]EvalRank, map[uint32]EvalRank) {
 	return flush4, straight4
 }
 
+// partialMaps generates the [RankPartial] map of 3 and 4 card rank prime
+// products, and the Cactus rank of each partial rank (see [Eval.HiPartial]).
+// Hands are ordered by their sets, then by their ranks, with a 3 card hand
+// ranking under the 4 card hands sharing its ranks.
+func partialMaps() (map[uint32]EvalRank, []EvalRank) {
+	type hand struct {
+		set int   // four of a kind (0) through nothing (4)
+		v   []int // ranks ordered by set, then high to low
+	}
+	var hands []hand
+	add := func(v ...int) {
+		counts := make(map[int]int)
+		for _, r := range v {
+			counts[r]++
+		}
+		slices.SortStableFunc(v, func(a, b int) int {
+			return counts[b] - counts[a]
+		})
+		set := 4
+		switch c0, c1 := counts[v[0]], counts[v[len(v)-1]]; {
+		case c0 == 4:
+			set = 0
+		case c0 == 3:
+			set = 1
+		case c0 == 2 && c1 == 2:
+			set = 2
+		case c0 == 2:
+			set = 3
+		}
+		hands = append(hands, hand{set, v})
+	}
+	for r0 := 12; r0 >= 0; r0-- {
+		for r1 := r0; r1 >= 0; r1-- {
+			for r2 := r1; r2 >= 0; r2-- {
+				add(r0, r1, r2)
+				for r3 := r2; r3 >= 0; r3-- {
+					add(r0, r1, r2, r3)
+				}
+			}
+		}
+	}
+	slices.SortStableFunc(hands, func(a, b hand) int {
+		if a.set != b.set {
+			return a.set - b.set
+		}
+		for i := range min(len(a.v), len(b.v)) {
+			if a.v[i] != b.v[i] {
+				return b.v[i] - a.v[i]
+			}
+		}
+		return len(b.v) - len(a.v)
+	})
+	m, hi := make(map[uint32]EvalRank, len(hands)), make([]EvalRank, len(hands)+1)
+	for i, h := range hands {
+		n := uint32(1)
+		for _, r := range h.v {
+			n *= primes[r]
+		}
+		// no better than the previous partial rank, as a better partial rank
+		// can have a worse completion (ie, 7-3-2 and 6-5-4-3)
+		m[n], hi[i+1] = 1+EvalRank(i), max(hi[i], partialCactus(h.v))
+	}
+	return m, hi
+}
+
+// partialCactus returns the worst Cactus rank of the 3 or 4 ranks completed
+// to 5 cards by ranks not in v. Completing cards are of a different suit than
+// the first card, so a flush is not possible.
+func partialCactus(v []int) EvalRank {
+	var c [5]Card
+	var counts [13]int
+	var mask uint16
+	for i, r := range v {
+		c[i] = New(Rank(r), SuitFromIndex(counts[r]))
+		counts[r]++
+		mask |= 1 << r
+	}
+	var rank EvalRank
+	for r0 := range 13 {
+		if mask&(1<<r0) != 0 {
+			continue
+		}
+		c[len(v)] = New(Rank(r0), Heart)
+		if len(v) == 4 {
+			rank = max(rank, Cactus(c[0], c[1], c[2], c[3], c[4]))
+			continue
+		}
+		for r1 := range r0 {
+			if mask&(1<<r1) == 0 {
+				c[4] = New(Rank(r1), Heart)
+				rank = max(rank, Cactus(c[0], c[1], c[2], c[3], c[4]))
+			}
+		}
+	}
+	return rank
+}
+
 /*
 // bestThreeMaps
 func bestThreeMaps() map[uint32]EvalRank {
@@ -197,8 +338,9 @@ func nextBitPermutation(bits uint32) uint32 {
 	return i | ((((i & -i) / (bits & -bits)) >> 1) - 1)
 }
 
-// primeProduct returns the prime product of the cards.
-func primeProduct(c0, c1, c2, c3, c4 Card) uint32 {
+// PrimeProduct returns the product of the primes of the card ranks (see
+// [Rank.Prime]), used as the key for [CactusUnique].
+func PrimeProduct(c0, c1, c2, c3, c4 Card) uint32 {
 	i := uint32(c0) & 0xff
 	i *= uint32(c1) & 0xff
 	i *= uint32(c2) & 0xff
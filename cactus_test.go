@@ -82,7 +82,7 @@ func TestPrimeProduct(t *testing.T) {
 		{[]Card{0x802713, 0x8004b25, 0x200291d, 0x00001, 0x00001}, 0x04fa3},
 	}
 	for i, test := range tests {
-		if n, exp := primeProduct(test.v[0], test.v[1], test.v[2], test.v[3], test.v[4]), test.exp; n != exp {
+		if n, exp := PrimeProduct(test.v[0], test.v[1], test.v[2], test.v[3], test.v[4]), test.exp; n != exp {
 			t.Errorf("test %d %v expected %d == %d", i, test.v, exp, n)
 		}
 	}
@@ -190,6 +190,49 @@ func TestCactusMaps(t *testing.T) {
 	}
 }
 
+func TestCactusTables(t *testing.T) {
+	tests := []struct {
+		v   string
+		exp EvalRank
+	}{
+		{"Ah Kh Qh Jh Th", 1},
+		{"5s 4s 3s 2s As", 10},
+		{"Ah Ac Ad As Kh", 11},
+		{"7h 5h 4h 3h 2h", Flush},
+		{"Ah Kc Qh Jh Th", 1600},
+		{"7c 5h 4h 3h 2h", Nothing},
+	}
+	for i, test := range tests {
+		v := Must(test.v)
+		var r EvalRank
+		if c := v[0] & v[1] & v[2] & v[3] & v[4]; c&0xf000 != 0 {
+			r = CactusFlush(uint16((v[0] | v[1] | v[2] | v[3] | v[4]) >> 16))
+		} else {
+			r = CactusUnique(PrimeProduct(v[0], v[1], v[2], v[3], v[4]))
+		}
+		if r != test.exp {
+			t.Errorf("test %d expected %d, got: %d", i, test.exp, r)
+		}
+		if r := Cactus(v[0], v[1], v[2], v[3], v[4]); r != test.exp {
+			t.Errorf("test %d expected %d, got: %d", i, test.exp, r)
+		}
+		if n := testing.AllocsPerRun(100, func() { Cactus(v[0], v[1], v[2], v[3], v[4]) }); n != 0 {
+			t.Errorf("test %d expected no allocs, got: %f", i, n)
+		}
+	}
+	if r := CactusFlush(0x0f); r != 0 {
+		t.Errorf("expected 0, got: %d", r)
+	}
+	if v := Must("Ah Ah Ah Ah Ah"); CactusUnique(PrimeProduct(v[0], v[1], v[2], v[3], v[4])) != 0 {
+		t.Errorf("expected 0 for 5 of a kind")
+	}
+	for rank := Two; rank <= Ace; rank++ {
+		if p := rank.Prime(); p != uint32(New(rank, Spade))&0xff {
+			t.Errorf("%s expected %d, got: %d", rank, uint32(New(rank, Spade))&0xff, p)
+		}
+	}
+}
+
 func TestSokoCards(t *testing.T) {
 	r := rand.New(rand.NewSource(time.Now().UnixNano()))
 	if s := os.Getenv("TESTS"); !strings.Contains(s, "soko") && !strings.Contains(s, "all") {
@@ -209,15 +252,15 @@ func TestSokoCards(t *testing.T) {
 							t.Fatalf("%v expected valid rank, got: %d", v, r)
 						case r <= TwoPair:
 						case hasFlush4(v):
-							if r <= TwoPair || sokoFlush < r {
-								t.Errorf("%v expected four flush %d < r <= %d, got: %d", v, TwoPair, sokoFlush, r)
+							if r <= TwoPair || SokoFourFlush < r {
+								t.Errorf("%v expected four flush %d < r <= %d, got: %d", v, TwoPair, SokoFourFlush, r)
 							}
 						case hasStraight4(v):
-							if r <= sokoFlush || sokoStraight < r {
-								t.Errorf("%v expected four straight %d < r <= %d, got: %d", v, sokoFlush, sokoStraight, r)
+							if r <= SokoFourFlush || SokoFourStraight < r {
+								t.Errorf("%v expected four straight %d < r <= %d, got: %d", v, SokoFourFlush, SokoFourStraight, r)
 							}
-						case sokoNothing < r:
-							t.Errorf("%v expected nothing r <= %d, got: %d", v, sokoNothing, r)
+						case SokoNothing < r:
+							t.Errorf("%v expected nothing r <= %d, got: %d", v, SokoNothing, r)
 						}
 						u := make([]Card, 5)
 						copy(u, v)
@@ -250,6 +293,7 @@ func TestRankSoko(t *testing.T) {
 		{"Ah Kh Ks Qh Jh", "Ad Kd Kh Qd Jd", 3327},
 		{"Ah Qd Ks Jh As", "Ad Qh Kh Jd Ac", 12621},
 		{"Ah Qd Jh Th 8c", "8d Ac Qh Jc Tc", 15777},
+		{"2c 2d 3h 4s 6d", "6c 4d 3c 2h 2s", 15610},
 	}
 	for i, test := range tests {
 		a, b := Must(test.a), Must(test.b)
@@ -262,6 +306,76 @@ func TestRankSoko(t *testing.T) {
 	}
 }
 
+func TestRankSokoUnder(t *testing.T) {
+	tests := []struct {
+		v   string
+		exp string
+	}{
+		{"Ah Kh Ks Qh Jh", "Pair, Kings, kickers Ace, Queen, Jack"},
+		{"Ah Qh 9h 3h 8c", "Four Flush, Ace-high, kickers Queen, Nine, Three, Eight"},
+		{"9c 8d 7h 6c Ks", "Four Straight, Nine-high, kicker King"},
+		{"Ah Qd Jh Th 8c", "Ace-high, kickers Queen, Jack, Ten, Eight"},
+		{"7h 5d 4h 3c 2s", "Four Straight, Five-high, kicker Seven"},
+		{"7h 5d 4h 3c 9s", "Nine-high, kickers Seven, Five, Four, Three"},
+	}
+	for i, test := range tests {
+		v := Must(test.v)
+		r := RankSokoUnder(v[0], v[1], v[2], v[3], v[4])
+		switch {
+		case strings.HasPrefix(test.exp, "Four Flush") && (r <= Pair || SokoUnderFourFlush < r),
+			strings.HasPrefix(test.exp, "Four Straight") && (r <= SokoUnderFourFlush || SokoUnderFourStraight < r),
+			strings.HasPrefix(test.exp, "Pair") && (r <= TwoPair || Pair < r),
+			strings.Contains(test.exp, "-high") && !strings.HasPrefix(test.exp, "Four") && (r <= SokoUnderFourStraight || SokoUnderNothing < r):
+			t.Errorf("test %d expected %q rank, got: %d", i, test.exp, r)
+		}
+		ev := EvalOf(Soko)
+		NewSokoUnderEval(true, false)(ev, v, nil)
+		if ev.HiRank != r {
+			t.Errorf("test %d expected %d, got: %d", i, r, ev.HiRank)
+		}
+		desc := &EvalDesc{Type: DescSokoUnder, Rank: ev.HiRank, Best: ev.HiBest, Unused: ev.HiUnused}
+		if s := fmt.Sprintf("%s", desc); s != test.exp {
+			t.Errorf("test %d expected %q, got: %q", i, test.exp, s)
+		}
+	}
+}
+
+func TestEvalRankToFromSoko(t *testing.T) {
+	tests := []struct {
+		r, soko, under EvalRank
+	}{
+		{StraightFlush, StraightFlush, StraightFlush},
+		{TwoPair, TwoPair, TwoPair},
+		{TwoPair + 1, SokoFourStraight + 2, TwoPair + 1},
+		{Pair, SokoPair, Pair},
+		{Pair + 1, SokoPair + 1, SokoUnderFourStraight + 1},
+		{Nothing, SokoNothing, SokoUnderNothing},
+	}
+	for i, test := range tests {
+		if r := test.r.ToSoko(); r != test.soko {
+			t.Errorf("test %d expected %d, got: %d", i, test.soko, r)
+		}
+		if r := test.soko.FromSoko(); r != test.r {
+			t.Errorf("test %d expected %d, got: %d", i, test.r, r)
+		}
+		if r := test.r.ToSokoUnder(); r != test.under {
+			t.Errorf("test %d expected %d, got: %d", i, test.under, r)
+		}
+		if r := test.under.FromSokoUnder(); r != test.r {
+			t.Errorf("test %d expected %d, got: %d", i, test.r, r)
+		}
+	}
+	if r := SokoFourFlush.FromSoko(); r != Invalid {
+		t.Errorf("expected %d, got: %d", Invalid, r)
+	}
+	if r := (SokoFourStraight + 1).FromSoko(); r != Invalid {
+		t.Errorf("expected %d, got: %d", Invalid, r)
+	}
+	if r := (SokoUnderFourStraight - 1).FromSokoUnder(); r != Invalid {
+		t.Errorf("expected %d, got: %d", Invalid, r)
+	}
+}
+
 func hasFlush4(v []Card) bool {
 	for i := range 5 {
 		c0, c1, c2, c3 := v[i%5], v[(i+1)%5], v[(i+2)%5], v[(i+3)%5]
@@ -1,4 +1,4 @@
-//go:build !embedded
+//go:build !embedded && !tiny
 
 package cardrank
 
@@ -7,7 +7,7 @@ func init() {
 }
 
 // CactusFast is a fast Cactus Kev rank eval func, implementing Paul Senzee's
-// perfect hash lookup.
+// perfect hash lookup. Does not allocate.
 //
 // See: http://senzee.blogspot.com/2006/06/some-perfect-hash.html
 func CactusFast(c0, c1, c2, c3, c4 Card) EvalRank {
@@ -1,13 +1,16 @@
 package cardrank
 
 import (
-	"bytes"
 	"context"
-	_ "embed"
-	"encoding/csv"
 	"fmt"
-	"regexp"
-	"strconv"
+	"iter"
+	"maps"
+	"math"
+	"math/bits"
+	"math/rand"
+	"runtime"
+	"slices"
+	"sync"
 	"sync/atomic"
 	"time"
 )
@@ -20,6 +23,22 @@ type OddsCalc struct {
 	active  map[int]bool
 	folded  bool
 	discard bool
+	dead    []Card
+	known   map[int][]Card
+	reuse   bool
+	// deadline is the time to stop enumerating.
+	deadline time.Time
+	cache    OddsCache
+	samples  int
+	shuffler Shuffler
+	strategy SamplingStrategy
+	// folds are the fold probabilities of each position.
+	folds  []float64
+	source FoldSource
+	// double are the double board odds being calculated.
+	double *DoubleOdds
+	// shares are the pot shares being calculated.
+	shares *potShares
 }
 
 // NewOddsCalc creates a new run odds calc.
@@ -39,6 +58,9 @@ func (c *OddsCalc) u() []Card {
 	for _, run := range c.runs {
 		if c.discard {
 			ex = append(ex, run.Discard)
+			for _, v := range run.pockets {
+				ex = append(ex, v)
+			}
 		}
 		ex = append(ex, run.Hi, run.Lo)
 		if c.active == nil || c.folded {
@@ -51,28 +73,80 @@ func (c *OddsCalc) u() []Card {
 			}
 		}
 	}
+	for _, v := range c.known {
+		ex = append(ex, v)
+	}
+	ex = append(ex, c.dead)
 	return c.typ.DeckType().Exclude(ex...)
 }
 
-// Calc calculates odds.
+// Calc calculates odds. See [OddsCalc.CalcErr].
 func (c *OddsCalc) Calc(ctx context.Context) (*Odds, *Odds, bool) {
+	hi, lo, err := c.CalcErr(ctx)
+	return hi, lo, err == nil
+}
+
+// CalcErr calculates odds. Returns [ErrUnsupportedType] when the type is not
+// registered, [ErrInsufficientCards] when no pockets have been dealt,
+// [ErrInvalidCard] when known cards are not held by a position (see
+// [WithKnownCards]), or [ErrContextCancelled] with the partial odds when the
+// context is done prior to the calculation completing.
+//
+// For a type with double boards, the odds of each board are calculated
+// independently of the other board. Use [OddsCalc.CalcDouble] for the joint
+// double board odds.
+//
+// When a deadline is set (see [WithDeadline]), the board combinations are
+// enumerated in a stride order spread across all combinations, and the
+// partial odds are returned without error when the deadline is reached,
+// marked as approximate (see [Odds.Approximate]).
+func (c *OddsCalc) CalcErr(ctx context.Context) (*Odds, *Odds, error) {
+	if _, ok := descs[c.typ]; !ok {
+		return nil, nil, ErrUnsupportedType
+	}
+	if c.cache == nil || !c.deadline.IsZero() || c.folds != nil || c.shares != nil || len(c.runs) == 0 {
+		return c.calc(ctx)
+	}
+	key := c.key()
+	if hi, lo, ok := c.cache.Get(key); ok {
+		return hi.Clone(), lo.Clone(), nil
+	}
+	hi, lo, err := c.calc(ctx)
+	if err == nil && !hi.Approximate {
+		c.cache.Put(key, hi.Clone(), lo.Clone())
+	}
+	return hi, lo, err
+}
+
+// calc calculates odds.
+func (c *OddsCalc) calc(ctx context.Context) (*Odds, *Odds, error) {
 	// check runs and pocket count
 	n := len(c.runs)
 	if n == 0 {
-		return nil, nil, false
+		return nil, nil, ErrInsufficientCards
 	}
 	// ensure at least 1 pocket pair has been dealt
 	count := len(c.runs[n-1].Pockets)
 	if count == 0 {
-		return nil, nil, false
+		return nil, nil, ErrInsufficientCards
+	}
+	// enumerate remaining pocket cards for stud
+	if c.deep && c.typ.Up() && c.typ.Board() == 0 {
+		return c.calcStud(ctx, c.runs[n-1].Pockets)
+	}
+	if err := checkKnown(c.known, c.runs[n-1].Pockets); err != nil {
+		return nil, nil, err
 	}
 	b, low, double := c.typ.Board(), c.typ.Low(), c.typ.Double()
 	run := c.runs[n-1].Dupe()
 	k, u := b-len(run.Hi), c.u()
 	// if pocket == 2, board == 0, use lookup
-	if !c.deep && b == k {
-		hi, lo := run.CalcStart(low || double)
-		return hi, lo, true
+	if !c.deep && c.samples == 0 && c.folds == nil && c.shares == nil && b == k {
+		if hi, lo := run.calcStart(c.typ, low || double); hi != nil {
+			return hi, lo, nil
+		}
+		// starting data unavailable (ie, built with the tiny tag), so
+		// enumerate the boards
 	}
 	// expand hi + lo boards
 	run.Hi = append(run.Hi, make([]Card, k)...)
@@ -81,37 +155,486 @@ func (c *OddsCalc) Calc(ctx context.Context) (*Odds, *Odds, bool) {
 	}
 	// setup odds
 	hi := NewOdds(count, u)
+	hi.Combinations = binom(len(u), k)
+	// when calculating double odds, the boards are dealt jointly, with the lo
+	// board dealt from the cards remaining after the hi board
+	joint := double && c.double != nil
+	if joint {
+		hi.Combinations *= binom(len(u)-k, k)
+	}
 	var lo *Odds
 	if low || double {
 		lo = NewOdds(count, u)
+		lo.Combinations = hi.Combinations
 	}
-	hiSuits, loSuits := countRunSuits(run, double)
 	// iterate combinations
 	offset := b - k
-	for g, v := NewCombinGen(u, k); g.Next(); {
+	var evs, sim []*Eval
+	if c.reuse {
+		p := evalsPool.Get().(*[]*Eval)
+		evs = reuseEvals(*p, count)
+		defer func() {
+			*p = evs
+			evalsPool.Put(p)
+		}()
+	}
+	g, v := NewCombinGen(u, k)
+	next := g.Next
+	sampled := 0 < c.samples && c.samples < hi.Combinations && c.shuffler != nil
+	switch {
+	case sampled && joint:
+		var sg *sampleGen
+		sg, v = newSampleGen(u, 2*k, c.samples, c.shuffler, c.strategy)
+		next = sg.Next
+	case sampled:
+		var sg *sampleGen
+		sg, v = newSampleGen(u, k, c.samples, c.shuffler, c.strategy)
+		next = sg.Next
+	case !c.deadline.IsZero():
+		var sg *strideGen[Card]
+		sg, v = newStrideGen(u, k)
+		next = sg.Next
+	}
+	if joint && !sampled {
+		var dg *doubleGen
+		dg, v = newDoubleGen(next, v, u, k)
+		next = dg.Next
+	}
+	for ; next(); hi.Evaluated++ {
 		// check context
 		select {
 		case <-ctx.Done():
-			return hi, lo, false
+			if lo != nil {
+				lo.Evaluated = hi.Evaluated
+			}
+			return hi, lo, cancelled(ctx)
 		default:
 		}
+		// check deadline
+		if hi.Evaluated&0x3f == 0x3f && !c.deadline.IsZero() && !time.Now().Before(c.deadline) {
+			hi.Approximate = true
+			if lo != nil {
+				lo.Approximate, lo.Evaluated = true, hi.Evaluated
+			}
+			return hi, lo, nil
+		}
 		// populate hi + lo boards
-		copy(run.Hi[offset:], v)
-		if double {
-			copy(run.Lo[offset:], v)
+		copy(run.Hi[offset:], v[:k])
+		switch {
+		case joint:
+			copy(run.Lo[offset:], v[k:])
+		case double:
+			// each board's odds are independent of the other board
+			copy(run.Lo[offset:], v[:k])
 		}
 		// eval
-		evs := run.Eval(c.typ, c.active, true)
+		if !c.reuse {
+			evs = nil
+		}
+		evs = run.eval(c.typ, c.active, true, evs)
+		res := evs
+		if c.folds != nil {
+			sim = c.fold(append(sim[:0], evs...))
+			res = sim
+		}
 		// add to odds
-		hi.Add(evs, hiSuits, run.Hi[offset:], false)
+		hi.Add(res, nil, run.Hi[offset:], false)
 		switch {
 		case low:
-			lo.Add(evs, loSuits, run.Hi[offset:], true)
+			lo.Add(res, nil, run.Hi[offset:], true)
 		case double:
-			lo.Add(evs, loSuits, run.Lo[offset:], true)
+			lo.Add(res, nil, run.Lo[offset:], true)
+		}
+		if c.double != nil {
+			c.double.add(res)
+		}
+		if c.shares != nil {
+			c.shares.add(res, low || double)
+		}
+	}
+	hi.Approximate = sampled || c.folds != nil
+	if lo != nil {
+		lo.Approximate, lo.Evaluated = hi.Approximate, hi.Evaluated
+	}
+	return hi, lo, nil
+}
+
+// evalsPool is a pool of evals reused by odds calcs (see [WithReuse]).
+var evalsPool = sync.Pool{
+	New: func() any {
+		return new([]*Eval)
+	},
+}
+
+// reuseEvals returns n evals reusing the evals in evs, clearing any previous
+// eval, or nil when evs has insufficient capacity.
+func reuseEvals(evs []*Eval, n int) []*Eval {
+	if cap(evs) < n {
+		return nil
+	}
+	evs = evs[:n]
+	for _, ev := range evs {
+		if ev != nil {
+			*ev = Eval{}
+		}
+	}
+	return evs
+}
+
+// fold simulates folds for the evals, in position order, removing the evals
+// of folded positions. A position is not folded when it is the only remaining
+// position.
+func (c *OddsCalc) fold(evs []*Eval) []*Eval {
+	var n int
+	for _, ev := range evs {
+		if ev != nil {
+			n++
+		}
+	}
+	for i := 0; i < len(evs) && i < len(c.folds) && 1 < n; i++ {
+		if evs[i] != nil && 0 < c.folds[i] && c.source.Float64() < c.folds[i] {
+			evs[i] = nil
+			n--
+		}
+	}
+	return evs
+}
+
+// CalcDouble calculates the double board odds for a type with double boards
+// (see [TypeDesc.Double]), where each Lo board is dealt from the cards
+// remaining after the Hi board. Returns [ErrUnsupportedType] when the type
+// does not have double boards, [ErrInsufficientCards] when no board cards
+// have been dealt and no samples have been set (see [WithSamples]), or the
+// errors returned by [OddsCalc.CalcErr].
+func (c *OddsCalc) CalcDouble(ctx context.Context) (*DoubleOdds, error) {
+	switch n := len(c.runs); {
+	case !c.typ.Double():
+		return nil, ErrUnsupportedType
+	case n == 0 || len(c.runs[n-1].Pockets) == 0:
+		return nil, ErrInsufficientCards
+	case len(c.runs[n-1].Hi) == 0 && (c.samples == 0 || c.shuffler == nil):
+		return nil, fmt.Errorf("%w: no board cards", ErrInsufficientCards)
+	}
+	c.double = NewDoubleOdds(len(c.runs[len(c.runs)-1].Pockets))
+	defer func() {
+		c.double = nil
+	}()
+	odds := c.double
+	var err error
+	odds.Hi, odds.Lo, err = c.calc(ctx)
+	return odds, err
+}
+
+// DoubleOdds are calculated double board odds (see [OddsCalc.CalcDouble]),
+// where half the pot is awarded to each board's winners.
+type DoubleOdds struct {
+	// Hi are the Hi (first) board odds.
+	Hi *Odds
+	// Lo are the Lo (second) board odds.
+	Lo *Odds
+	// Total is the total number of outcomes.
+	Total int
+	// HiShares is each position's sum of the Hi board pot shares.
+	HiShares []float64
+	// LoShares is each position's sum of the Lo board pot shares.
+	LoShares []float64
+	// Scoops is each position's count of outcomes winning both boards
+	// outright.
+	Scoops []int
+}
+
+// NewDoubleOdds creates a new double board odds.
+func NewDoubleOdds(count int) *DoubleOdds {
+	return &DoubleOdds{
+		HiShares: make([]float64, count),
+		LoShares: make([]float64, count),
+		Scoops:   make([]int, count),
+	}
+}
+
+// add adds the outcome of the evals to the odds.
+func (odds *DoubleOdds) add(evs []*Eval) {
+	hi, hiPivot := Order(evs, false)
+	lo, loPivot := Order(evs, true)
+	for _, i := range hi[:hiPivot] {
+		odds.HiShares[i] += 1 / float64(hiPivot)
+	}
+	for _, i := range lo[:loPivot] {
+		odds.LoShares[i] += 1 / float64(loPivot)
+	}
+	if hiPivot == 1 && loPivot == 1 && hi[0] == lo[0] {
+		odds.Scoops[hi[0]]++
+	}
+	odds.Total++
+}
+
+// potShares are the summed pot shares of each position, where the pot is split
+// between the Hi and Lo winners the same as [Result.Split].
+type potShares struct {
+	total  int
+	shares []float64
+}
+
+// add adds the pot shares of the evals.
+func (s *potShares) add(evs []*Eval, low bool) {
+	hi, hiPivot := Order(evs, false)
+	if hiPivot == 0 {
+		return
+	}
+	pot := 1.0
+	if low {
+		if lo, loPivot := Order(evs, true); loPivot != 0 {
+			pot = 0.5
+			for _, i := range lo[:loPivot] {
+				s.shares[i] += pot / float64(loPivot)
+			}
+		}
+	}
+	for _, i := range hi[:hiPivot] {
+		s.shares[i] += pot / float64(hiPivot)
+	}
+	s.total++
+}
+
+// merge merges the pot shares of b.
+func (s *potShares) merge(b *potShares) {
+	if s == nil || b == nil {
+		return
+	}
+	s.total += b.total
+	for i := range min(len(s.shares), len(b.shares)) {
+		s.shares[i] += b.shares[i]
+	}
+}
+
+// equity returns the position's share of the pot.
+func (s *potShares) equity(pos int) float64 {
+	return s.shares[pos] / float64(max(s.total, 1))
+}
+
+// HiEquity returns the position's share of the Hi board's half of the pot.
+func (odds *DoubleOdds) HiEquity(pos int) float64 {
+	return odds.HiShares[pos] / float64(max(odds.Total, 1))
+}
+
+// LoEquity returns the position's share of the Lo board's half of the pot.
+func (odds *DoubleOdds) LoEquity(pos int) float64 {
+	return odds.LoShares[pos] / float64(max(odds.Total, 1))
+}
+
+// Equity returns the position's share of the pot.
+func (odds *DoubleOdds) Equity(pos int) float64 {
+	return (odds.HiEquity(pos) + odds.LoEquity(pos)) / 2
+}
+
+// Scoop returns the probability of the position winning both boards
+// outright.
+func (odds *DoubleOdds) Scoop(pos int) float64 {
+	return float64(odds.Scoops[pos]) / float64(max(odds.Total, 1))
+}
+
+// Format satisfies the [fmt.Formatter] interface, writing a line for each
+// position with equity.
+func (odds *DoubleOdds) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's':
+		for i := range odds.Scoops {
+			if odds.HiShares[i] == 0 && odds.LoShares[i] == 0 {
+				continue
+			}
+			fmt.Fprintf(f, "%d: hi %.1f%% lo %.1f%% equity %.1f%% scoop %.1f%%\n",
+				i, 100*odds.HiEquity(i), 100*odds.LoEquity(i), 100*odds.Equity(i), 100*odds.Scoop(i))
+		}
+	default:
+		fmt.Fprintf(f, "%%!%c(ERROR=unknown verb, double odds)", verb)
+	}
+}
+
+// calcStud calculates [Stud] odds, including [Razz], by enumerating the
+// remaining cards of each active pocket, up to the type's pocket count, and
+// evaluating each completed pocket. [Razz] pockets are ranked by their best
+// Ace-to-Five low directly (see [razzLow]). A position's outs are the
+// remaining cards dealt to the position when it wins or splits.
+//
+// The known cards of an active position (see [WithKnownCards]) are added to
+// the position's pocket, while the known cards of a folded position must be
+// in its pocket. The upcards of folded positions are excluded from the
+// remaining cards when passed as dead cards (see [WithDeadCards]).
+//
+// The enumeration grows quickly with each pocket's remaining cards, and is
+// best suited for calculating odds on or after the 5th street.
+func (c *OddsCalc) calcStud(ctx context.Context, pockets [][]Card) (*Odds, *Odds, error) {
+	count, total := len(pockets), c.typ.Pocket()
+	// only the remaining cards of active positions are enumerated
+	held := make([][]Card, count)
+	for i, pocket := range pockets {
+		if c.active != nil && !c.active[i] {
+			held[i] = pocket
+		}
+	}
+	if err := checkKnown(c.known, held); err != nil {
+		return nil, nil, err
+	}
+	u := c.u()
+	s := &studCalc{
+		ctx:   ctx,
+		hi:    NewOdds(count, u),
+		hands: make([][]Card, count),
+		known: make([]int, count),
+		his:   make([]EvalRank, count),
+		los:   make([]EvalRank, count),
+	}
+	if c.typ.Low() {
+		s.lo = NewOdds(count, u)
+	}
+	ev := EvalOf(c.typ)
+	if c.typ.Desc().Eval == EvalRazz {
+		s.rank = func(v []Card) (EvalRank, EvalRank) {
+			return razzLow(ev, v), Invalid
+		}
+	} else {
+		s.rank = func(v []Card) (EvalRank, EvalRank) {
+			ev.HiRank, ev.LoRank = Invalid, Invalid
+			calcs[ev.Type](ev, v, nil)
+			return ev.HiRank, ev.LoRank
+		}
+	}
+	var pos []int
+	for i, pocket := range pockets {
+		s.his[i], s.los[i] = Invalid, Invalid
+		if c.active != nil && !c.active[i] {
+			continue
+		}
+		// add the position's known cards not in its pocket
+		for _, card := range c.known[i] {
+			if !slices.Contains(pocket, card) {
+				pocket = append(slices.Clip(pocket), card)
+			}
+		}
+		if total < len(pocket) {
+			return nil, nil, fmt.Errorf("position %d has %d pocket cards: %w", i, len(pocket), ErrInvalidCard)
+		}
+		s.hands[i], s.known[i] = make([]Card, total), len(pocket)
+		copy(s.hands[i], pocket)
+		pos = append(pos, i)
+	}
+	if len(pos) == 0 {
+		return nil, nil, ErrInsufficientCards
+	}
+	if !s.enum(u, pos) {
+		return s.hi, s.lo, cancelled(ctx)
+	}
+	return s.hi, s.lo, nil
+}
+
+// checkKnown checks that the known cards (see [WithKnownCards]) are of the
+// positions of the pockets, and are in the position's pocket, where a nil
+// pocket is a position whose remaining cards are enumerated.
+func checkKnown(known map[int][]Card, pockets [][]Card) error {
+	for _, pos := range slices.Sorted(maps.Keys(known)) {
+		if pos < 0 || len(pockets) <= pos {
+			return fmt.Errorf("position %d has known cards but no pocket: %w", pos, ErrInvalidCard)
+		}
+		for _, card := range known[pos] {
+			if pockets[pos] != nil && !slices.Contains(pockets[pos], card) {
+				return fmt.Errorf("position %d does not hold known card %s: %w", pos, card, ErrInvalidCard)
+			}
+		}
+	}
+	return nil
+}
+
+// studCalc enumerates the remaining cards of [Stud] pockets.
+type studCalc struct {
+	ctx   context.Context
+	hi    *Odds
+	lo    *Odds
+	hands [][]Card
+	known []int
+	his   []EvalRank
+	los   []EvalRank
+	rank  func([]Card) (EvalRank, EvalRank)
+}
+
+// enum enumerates the remaining cards of the pocket for the first position,
+// recursing for each remaining position, and adds the results to the odds.
+func (s *studCalc) enum(u []Card, pos []int) bool {
+	if len(pos) == 0 {
+		s.add(s.hi, s.his)
+		if s.lo != nil {
+			s.add(s.lo, s.los)
+		}
+		return true
+	}
+	i := pos[0]
+	k := len(s.hands[i]) - s.known[i]
+	if k == 0 {
+		s.his[i], s.los[i] = s.rank(s.hands[i])
+		return s.enum(u, pos[1:])
+	}
+	g, v := NewCombinUnusedGen(u, k)
+	if len(pos) == 1 {
+		g, v = NewCombinGen(u, k)
+	}
+	for g.Next() {
+		select {
+		case <-s.ctx.Done():
+			return false
+		default:
+		}
+		copy(s.hands[i][s.known[i]:], v[:k])
+		s.his[i], s.los[i] = s.rank(s.hands[i])
+		if !s.enum(v[k:], pos[1:]) {
+			return false
+		}
+	}
+	return true
+}
+
+// add adds the outcome of the ranks to the odds.
+func (s *studCalc) add(odds *Odds, ranks []EvalRank) {
+	best, pivot := Invalid, 0
+	for _, r := range ranks {
+		switch {
+		case r < best:
+			best, pivot = r, 1
+		case r == best && r != Invalid:
+			pivot++
 		}
 	}
-	return hi, lo, true
+	for i, r := range ranks {
+		switch {
+		case s.hands[i] == nil:
+			continue
+		case r != best || r == Invalid:
+			odds.Losses[i]++
+			continue
+		}
+		odds.Counts[i]++
+		for _, c := range s.hands[i][s.known[i]:] {
+			odds.Outs[i][c] = true
+		}
+	}
+	odds.Total += pivot
+}
+
+// razzLow returns the best Ace-to-Five low of v, by taking the 5 lowest
+// distinct ranks of v (see [RankAceFiveLow]). Uses the type's eval, reusing
+// ev, when v does not have 5 distinct ranks.
+func razzLow(ev *Eval, v []Card) EvalRank {
+	var mask uint
+	for _, c := range v {
+		mask |= 1 << c.AceRank()
+	}
+	if bits.OnesCount(mask) < 5 {
+		ev.HiRank, ev.LoRank = Invalid, Invalid
+		calcs[ev.Type](ev, v, nil)
+		return ev.HiRank
+	}
+	for 5 < bits.OnesCount(mask) {
+		mask &^= 1 << (bits.Len(mask) - 1)
+	}
+	return EvalRank(mask)
 }
 
 // Odds are calculated run odds.
@@ -120,16 +643,34 @@ type Odds struct {
 	Total int
 	// Counts is each position's outcome count for wins and splits.
 	Counts []int
+	// Losses is each position's count of evaluated outcomes where the
+	// position neither wins nor splits.
+	Losses []int
 	// Outs are map of the available outs for a position.
 	Outs []map[Card]bool
+	// Estimate is true when the odds were estimated from starting pocket data
+	// instead of being enumerated (see [Run.CalcStart]).
+	Estimate bool
+	// Approximate is true when the odds were calculated from a partial
+	// enumeration, such as when the deadline was reached (see
+	// [WithDeadline]).
+	Approximate bool
+	// Evaluated is the number of board combinations evaluated.
+	Evaluated int
+	// Combinations is the total number of board combinations.
+	Combinations int
 	// Suits [][]Suit
 	// Dead  bool
+
+	// indices is reused when ordering evals.
+	indices []int
 }
 
 // NewOdds creates a new odds.
 func NewOdds(count int, u []Card) *Odds {
 	odds := &Odds{
 		Counts: make([]int, count),
+		Losses: make([]int, count),
 		Outs:   make([]map[Card]bool, count),
 		// Suits: make([][]Suit, count),
 	}
@@ -139,17 +680,24 @@ func NewOdds(count int, u []Card) *Odds {
 	return odds
 }
 
-// Add adds the eval results to the odds.
+// Add adds the eval results to the odds, where v are the enumerated board
+// cards. The cards in v are not copied or modified. The suit counts are
+// unused, and may be nil.
 func (odds *Odds) Add(evs []*Eval, suits [][4]int, v []Card, low bool) {
-	indices, pivot := Order(evs, low)
-	s := make([][4]int, len(suits))
-	copy(s, suits)
+	var pivot int
+	odds.indices, pivot = order(evs, low, odds.indices)
+	indices := odds.indices
 	for i := range pivot {
 		odds.Counts[indices[i]]++
 		for _, c := range v {
 			odds.Outs[indices[i]][c] = true
 		}
 	}
+	for _, i := range indices[pivot:] {
+		if evs[i] != nil {
+			odds.Losses[i]++
+		}
+	}
 	odds.Total += pivot
 }
 
@@ -168,6 +716,89 @@ func (odds *Odds) Percent(pos int) float32 {
 	return float32(odds.Counts[pos]) / float32(max(odds.Total, 1)) * 100
 }
 
+// Merge merges the counts, totals, and outs of b into the odds.
+func (odds *Odds) Merge(b *Odds) {
+	if odds == nil || b == nil {
+		return
+	}
+	odds.Total += b.Total
+	odds.Estimate = odds.Estimate || b.Estimate
+	odds.Approximate = odds.Approximate || b.Approximate
+	odds.Evaluated += b.Evaluated
+	odds.Combinations += b.Combinations
+	for i := range min(len(odds.Counts), len(b.Counts)) {
+		odds.Counts[i] += b.Counts[i]
+		odds.Losses[i] += b.Losses[i]
+		for c := range b.Outs[i] {
+			odds.Outs[i][c] = true
+		}
+	}
+}
+
+// Clone returns a copy of the odds.
+func (odds *Odds) Clone() *Odds {
+	if odds == nil {
+		return nil
+	}
+	b := &Odds{
+		Total:        odds.Total,
+		Counts:       slices.Clone(odds.Counts),
+		Losses:       slices.Clone(odds.Losses),
+		Outs:         make([]map[Card]bool, len(odds.Outs)),
+		Estimate:     odds.Estimate,
+		Approximate:  odds.Approximate,
+		Evaluated:    odds.Evaluated,
+		Combinations: odds.Combinations,
+	}
+	for i, m := range odds.Outs {
+		b.Outs[i] = make(map[Card]bool, len(m))
+		maps.Copy(b.Outs[i], m)
+	}
+	return b
+}
+
+// Exact returns true when the odds were calculated by enumerating every
+// outcome, and were not estimated, sampled, or partially enumerated.
+func (odds *Odds) Exact() bool {
+	return !odds.Estimate && !odds.Approximate
+}
+
+// ConfidenceInterval returns the margin of the confidence interval, as a
+// percent, of the position's percent (see [Odds.Percent]) at the confidence
+// level (for example, 0.95), using the normal approximation of the binomial
+// distribution over the evaluated board combinations. Returns 0 when the odds
+// are exact (see [Odds.Exact]).
+//
+// Example:
+//
+//	fmt.Printf("%.1f%% ± %.1f%%", odds.Percent(0), odds.ConfidenceInterval(0, 0.95))
+func (odds *Odds) ConfidenceInterval(pos int, level float64) float64 {
+	n := odds.Evaluated
+	if n == 0 {
+		n = odds.Total
+	}
+	if odds.Exact() || n == 0 {
+		return 0
+	}
+	p := float64(odds.Counts[pos]) / float64(max(odds.Total, 1))
+	return 100 * zScore(level) * math.Sqrt(p*(1-p)/float64(n))
+}
+
+// Ratio returns the odds against pos as a ratio to 1 (ex: 2.1 for 2.1 : 1).
+// Returns +Inf when pos has no winning or split outcomes.
+func (odds *Odds) Ratio(pos int) float32 {
+	if odds.Counts[pos] == 0 {
+		return float32(math.Inf(1))
+	}
+	return float32(odds.Total-odds.Counts[pos]) / float32(odds.Counts[pos])
+}
+
+// Profitable returns true when the equity for pos meets or exceeds the
+// break-even equity needed to call into the pot (see [NeedEquity]).
+func (odds *Odds) Profitable(pos int, pot, call float64) bool {
+	return NeedEquity(pot, call) <= float64(odds.Counts[pos])/float64(max(odds.Total, 1))
+}
+
 /*
 // Outs returns the out cards and suits for pos.
 func (odds *Odds) Outs(pos int, distinct bool) ([]Card, []Suit) {
@@ -207,6 +838,14 @@ func (odds *Odds) Format(f fmt.State, verb rune) {
 		if i, ok := f.Width(); ok {
 			fmt.Fprintf(f, "%0.1f%% (%d/%d)", odds.Percent(i), odds.Counts[i], odds.Total)
 		}
+	case 'r':
+		if i, ok := f.Width(); ok {
+			p, ok := f.Precision()
+			if !ok {
+				p = 1
+			}
+			fmt.Fprintf(f, "%0.*f : 1", p, odds.Ratio(i))
+		}
 	/*
 		case 'o', 'O':
 			odds.formatOuts(f, 's', verb == 'O')
@@ -257,6 +896,334 @@ func (odds *Odds) formatOuts(f fmt.State, verb rune, distinct bool) {
 }
 */
 
+// RangeCalc calculates the odds of multiple ranges of pockets, with card
+// removal, where a range is a set of pockets. Each assignment of a pocket from
+// each range not sharing any cards with the other pockets or the board is
+// calculated, and the results are combined.
+type RangeCalc struct {
+	typ     Type
+	ranges  [][][]Card
+	board   []Card
+	workers int
+	dead    []Card
+	opts    []CalcOption
+}
+
+// NewRangeCalc creates a new range odds calc. The passed options are also
+// used for each assignment's [OddsCalc].
+func NewRangeCalc(typ Type, ranges [][][]Card, board []Card, opts ...CalcOption) *RangeCalc {
+	c := &RangeCalc{
+		typ:     typ,
+		ranges:  ranges,
+		board:   board,
+		workers: runtime.NumCPU(),
+	}
+	for _, o := range opts {
+		o(c)
+	}
+	c.opts = opts
+	return c
+}
+
+// Calc calculates the odds for the ranges. See [RangeCalc.CalcErr].
+func (c *RangeCalc) Calc(ctx context.Context) (*Odds, *Odds, bool) {
+	hi, lo, err := c.CalcErr(ctx)
+	return hi, lo, err == nil
+}
+
+// CalcErr calculates the odds for the ranges. Partitions the first range's
+// pockets across the workers (see [WithWorkers]). Returns the same errors as
+// [OddsCalc.CalcErr].
+func (c *RangeCalc) CalcErr(ctx context.Context) (*Odds, *Odds, error) {
+	return c.calc(ctx, nil)
+}
+
+// CalcEquity calculates the odds for the ranges, and each position's equity
+// as its expected share of the pot, with a split pot divided between the tied
+// winners the same as [Result.Split]. Returns the same errors as
+// [RangeCalc.CalcErr], with the partial equities when the context is done.
+func (c *RangeCalc) CalcEquity(ctx context.Context) (*Odds, *Odds, []float64, error) {
+	shares := &potShares{shares: make([]float64, len(c.ranges))}
+	hi, lo, err := c.calc(ctx, shares)
+	if hi == nil {
+		return nil, nil, nil, err
+	}
+	equity := make([]float64, len(c.ranges))
+	for i := range equity {
+		equity[i] = shares.equity(i)
+	}
+	return hi, lo, equity, err
+}
+
+// calc calculates the odds for the ranges, summing the pot shares to shares
+// when not nil.
+func (c *RangeCalc) calc(ctx context.Context, shares *potShares) (*Odds, *Odds, error) {
+	if _, ok := descs[c.typ]; !ok {
+		return nil, nil, ErrUnsupportedType
+	}
+	count := len(c.ranges)
+	if count == 0 {
+		return nil, nil, ErrInsufficientCards
+	}
+	hi := NewOdds(count, nil)
+	var lo *Odds
+	if c.typ.Low() || c.typ.Double() {
+		lo = NewOdds(count, nil)
+	}
+	ch := make(chan []Card)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var err error
+	for _, opts := range c.sources(max(c.workers, 1)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h, l := NewOdds(count, nil), NewOdds(count, nil)
+			var s *potShares
+			if shares != nil {
+				s = &potShares{shares: make([]float64, count)}
+			}
+			var e error
+			for pocket := range ch {
+				if e == nil {
+					e = c.do(ctx, h, l, s, opts, [][]Card{pocket}, exclude(c.board, c.dead, pocket))
+				}
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			hi.Merge(h)
+			lo.Merge(l)
+			shares.merge(s)
+			if err == nil {
+				err = e
+			}
+		}()
+	}
+	board := exclude(c.board, c.dead)
+	for _, pocket := range c.ranges[0] {
+		if excluded(board, pocket) {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+		case ch <- pocket:
+			continue
+		}
+		break
+	}
+	close(ch)
+	wg.Wait()
+	select {
+	case <-ctx.Done():
+		return hi, lo, cancelled(ctx)
+	default:
+	}
+	return hi, lo, err
+}
+
+// sources returns the options for each worker, replacing the sampling
+// shuffler (see [WithSamples]) and fold source (see [WithFolds]) with a
+// separate source for each worker, as the passed shuffler and fold source
+// are not safe for concurrent use. Each worker's source is seeded from the
+// passed shuffler or fold source.
+func (c *RangeCalc) sources(workers int) [][]CalcOption {
+	v := make([][]CalcOption, workers)
+	oc := NewOddsCalc(c.typ, c.opts...)
+	for i := range workers {
+		v[i] = slices.Clip(c.opts)
+		if oc.shuffler == nil && oc.source == nil {
+			continue
+		}
+		r := rand.New(rand.NewSource(seedOf(oc.shuffler, oc.source)))
+		if oc.shuffler != nil {
+			v[i] = append(v[i], WithSamples(oc.samples, r))
+		}
+		if oc.source != nil {
+			v[i] = append(v[i], WithFolds(oc.folds, r))
+		}
+		v[i] = slices.Clip(v[i])
+	}
+	return v
+}
+
+// seedOf returns a seed drawn from the fold source, or from the shuffler
+// when the fold source is nil, using the source's Int63 or Uint64 when
+// available (ie, a [rand.Rand]). Otherwise the seed is drawn from the fold
+// source's Float64, or from the 16 nibbles of the shuffler's shuffled
+// permutation.
+func seedOf(shuffler Shuffler, source FoldSource) int64 {
+	var r interface{} = shuffler
+	if source != nil {
+		r = source
+	}
+	switch r := r.(type) {
+	case interface{ Int63() int64 }:
+		return r.Int63()
+	case interface{ Uint64() uint64 }:
+		return int64(r.Uint64() >> 1)
+	case FoldSource:
+		// exact, as a Float64 has 53 bits of precision
+		return int64(r.Float64() * (1 << 53))
+	}
+	var v [16]uint64
+	for i := range v {
+		v[i] = uint64(i)
+	}
+	shuffler.Shuffle(len(v), func(i, j int) {
+		v[i], v[j] = v[j], v[i]
+	})
+	var seed uint64
+	for _, n := range v {
+		seed = seed<<4 | n
+	}
+	return int64(seed)
+}
+
+// do recursively assigns pockets from the remaining ranges, calculating the
+// odds and pot shares for each complete assignment.
+func (c *RangeCalc) do(ctx context.Context, hi, lo *Odds, shares *potShares, opts []CalcOption, pockets [][]Card, ex map[Card]bool) error {
+	if len(pockets) == len(c.ranges) {
+		calc := NewOddsCalc(c.typ, append(opts, WithPocketsBoard(pockets, c.board))...)
+		calc.shares = shares
+		h, l, err := calc.CalcErr(ctx)
+		hi.Merge(h)
+		lo.Merge(l)
+		return err
+	}
+	for _, pocket := range c.ranges[len(pockets)] {
+		if excluded(ex, pocket) {
+			continue
+		}
+		for _, card := range pocket {
+			ex[card] = true
+		}
+		err := c.do(ctx, hi, lo, shares, opts, append(pockets, pocket), ex)
+		for _, card := range pocket {
+			delete(ex, card)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cancelled returns a [ErrContextCancelled] error wrapping the context's
+// error.
+func cancelled(ctx context.Context) error {
+	return fmt.Errorf("%w: %w", ErrContextCancelled, ctx.Err())
+}
+
+// exclude returns a map of the cards in v.
+func exclude(v ...[]Card) map[Card]bool {
+	m := make(map[Card]bool)
+	for _, u := range v {
+		for _, c := range u {
+			m[c] = true
+		}
+	}
+	return m
+}
+
+// unusedCards returns the cards in the type's deck not in v.
+func unusedCards(typ Type, v ...[]Card) []Card {
+	ex := exclude(v...)
+	var unused []Card
+	for _, c := range typ.Deck().All() {
+		if !ex[c] {
+			unused = append(unused, c)
+		}
+	}
+	return unused
+}
+
+// excluded returns true when any card in v is in ex.
+func excluded(ex map[Card]bool, v []Card) bool {
+	for _, c := range v {
+		if ex[c] {
+			return true
+		}
+	}
+	return false
+}
+
+// StreetOdds are the calculated odds for a street of a specific board runout.
+type StreetOdds struct {
+	// Street is the street.
+	Street StreetDesc
+	// Board is the board as of the street.
+	Board []Card
+	// Hi are the Hi odds.
+	Hi *Odds
+	// Lo are the Lo odds, when the type is Hi/Lo or has double boards.
+	Lo *Odds
+}
+
+// CalcStreetOdds calculates the odds of the pockets for each street of a
+// specific board runout, as a time series suitable for equity graphs. The
+// first street and each street dealing board cards are calculated, stopping
+// when the board has insufficient cards for a street.
+//
+// Calculates deeply (see [WithDeep]), unless overridden by opts.
+func CalcStreetOdds(ctx context.Context, typ Type, pockets [][]Card, board []Card, opts ...CalcOption) ([]StreetOdds, bool) {
+	opts = append([]CalcOption{WithDeep(true)}, opts...)
+	var v []StreetOdds
+	var n int
+	for i, st := range typ.Streets() {
+		n += st.Board
+		switch {
+		case len(board) < n:
+			return v, true
+		case i != 0 && st.Board == 0:
+			continue
+		}
+		hi, lo, ok := typ.Odds(ctx, pockets, board[:n:n], opts...)
+		if !ok {
+			return v, false
+		}
+		v = append(v, StreetOdds{
+			Street: st,
+			Board:  board[:n:n],
+			Hi:     hi,
+			Lo:     lo,
+		})
+	}
+	return v, true
+}
+
+// NeedEquity returns the break-even equity (0-1) needed to profitably call
+// the call amount, where pot includes all prior bets (see [BreakEven]).
+func NeedEquity(pot, call float64) float64 {
+	if pot+call <= 0 {
+		return 0
+	}
+	return call / (pot + call)
+}
+
+// PotOdds returns the pot odds as a ratio to 1 for the call amount (ex: 3.0
+// for 3 : 1), where pot includes all prior bets. Returns +Inf when call is 0.
+func PotOdds(pot, call float64) float64 {
+	if call <= 0 {
+		return math.Inf(1)
+	}
+	return pot / call
+}
+
+// ImpliedOdds returns the implied pot odds as a ratio to 1 for the call
+// amount, including the amount expected to be won on later streets.
+func ImpliedOdds(pot, call, implied float64) float64 {
+	return PotOdds(pot+implied, call)
+}
+
+// BreakEven returns the break-even equity (0-1) for odds as a ratio to 1
+// (ex: 0.25 for 3 : 1).
+func BreakEven(ratio float64) float64 {
+	if math.IsInf(ratio, 1) {
+		return 0
+	}
+	return 1 / (ratio + 1)
+}
+
 // ExpValueCalc is a expected value calculator.
 type ExpValueCalc struct {
 	typ       Type
@@ -264,6 +1231,8 @@ type ExpValueCalc struct {
 	pocket    []Card
 	board     []Card
 	opponents int
+	dead      []Card
+	known     map[int][]Card
 }
 
 // NewExpValueCalc creates a new expected value calculator.
@@ -281,17 +1250,40 @@ func NewExpValueCalc(typ Type, pocket []Card, opts ...CalcOption) *ExpValueCalc
 
 // u builds the set of unused cards.
 func (c *ExpValueCalc) u() []Card {
-	return c.typ.DeckType().Exclude(c.pocket, c.board)
+	ex := [][]Card{c.pocket, c.board, c.dead}
+	for _, v := range c.known {
+		ex = append(ex, v)
+	}
+	return c.typ.DeckType().Exclude(ex...)
 }
 
-// Calc calculates the expected value.
+// Calc calculates the expected value. See [ExpValueCalc.CalcErr].
 func (c *ExpValueCalc) Calc(ctx context.Context) (*ExpValue, bool) {
+	expv, err := c.CalcErr(ctx)
+	return expv, err == nil
+}
+
+// CalcErr calculates the expected value. Returns [ErrUnsupportedType] when
+// the type is not registered, [ErrInvalidCard] when known cards are not of
+// the pocket or the opponent (see [WithKnownCards]), [ErrInsufficientCards]
+// when there is no board and no starting pocket data for the pocket, or
+// [ErrContextCancelled] with the partial expected value when the context is
+// done prior to the calculation completing.
+func (c *ExpValueCalc) CalcErr(ctx context.Context) (*ExpValue, error) {
+	if _, ok := descs[c.typ]; !ok {
+		return nil, ErrUnsupportedType
+	}
+	if err := checkKnown(c.known, [][]Card{c.pocket, nil}); err != nil {
+		return nil, err
+	}
 	u, b, nb := c.u(), c.typ.Board(), len(c.board)
-	switch np := len(c.pocket); {
-	case !c.deep && 1 < np && np < 7 && nb == 0:
-		return StartingExpValue(c.pocket), true
-	case nb == 0:
-		return NewExpValue(1), false
+	if np := len(c.pocket); !c.deep && 1 < np && np < 7 && nb == 0 && len(c.dead) == 0 && len(c.known) == 0 {
+		if expv := c.typ.StartingExpValue(c.pocket); expv != nil {
+			return expv, nil
+		}
+	}
+	if nb == 0 {
+		return NewExpValue(1), ErrInsufficientCards
 	}
 	v := make([]Card, b)
 	copy(v, c.board)
@@ -299,7 +1291,7 @@ func (c *ExpValueCalc) Calc(ctx context.Context) (*ExpValue, bool) {
 	for g.Next() {
 		select {
 		case <-ctx.Done():
-			return expv, false
+			return expv, cancelled(ctx)
 		default:
 		}
 		avail, board := Exclude(u, v[b-(b-nb):]), make([]Card, b)
@@ -313,11 +1305,11 @@ func (c *ExpValueCalc) Calc(ctx context.Context) (*ExpValue, bool) {
 		}
 		select {
 		case <-ctx.Done():
-			return expv, false
+			return expv, cancelled(ctx)
 		case <-time.After(50 * time.Millisecond):
 		}
 	}
-	return expv, true
+	return expv, nil
 }
 
 func (c *ExpValueCalc) do(_ context.Context, expv *ExpValue, board, avail []Card, wait *int64) {
@@ -333,10 +1325,14 @@ func (c *ExpValueCalc) do(_ context.Context, expv *ExpValue, board, avail []Card
 	var i, pivot int
 	var indices []int
 	var win bool
-	for g, v := NewCombinGen(avail, c.typ.Pocket()); g.Next(); {
+	// known opponent cards
+	known := c.known[1]
+	pocket := make([]Card, 0, max(c.typ.Pocket(), len(known)))
+	for g, v := NewCombinGen(avail, max(c.typ.Pocket()-len(known), 0)); g.Next(); {
+		pocket = append(append(pocket[:0], known...), v...)
 		// eval and order
 		evs[1].HiRank = Invalid
-		f(evs[1], v, board)
+		f(evs[1], pocket, board)
 		indices, pivot = Order(evs, false)
 		// determine if win
 		for i, win = 0, false; i < pivot; i++ {
@@ -368,6 +1364,9 @@ type ExpValue struct {
 	Splits    uint64
 	Losses    uint64
 	Total     uint64
+	// Sampled is true when the expected value was calculated from sampled
+	// outcomes (see [StartingEquity]).
+	Sampled bool
 }
 
 // NewExpValue creates a new expected value.
@@ -383,6 +1382,59 @@ func (expv *ExpValue) Add(v *ExpValue) {
 	expv.Splits += v.Splits
 	expv.Losses += v.Losses
 	expv.Total += v.Total
+	expv.Sampled = expv.Sampled || v.Sampled
+}
+
+// Exact returns true when the expected value was calculated by enumerating
+// every outcome.
+func (expv *ExpValue) Exact() bool {
+	return !expv.Sampled
+}
+
+// ConfidenceInterval returns the margin of the confidence interval, as a
+// percent, of the expected value's percent (see [ExpValue.Percent]) at the
+// confidence level (for example, 0.95), using the sample variance of the win,
+// split, and loss outcomes. Returns 0 when the expected value is exact (see
+// [ExpValue.Exact]).
+func (expv *ExpValue) ConfidenceInterval(level float64) float64 {
+	if expv.Exact() || expv.Total == 0 {
+		return 0
+	}
+	n, share := float64(expv.Total), 1/float64(expv.Opponents+1)
+	m := expv.Float64()
+	sq := (float64(expv.Wins) + float64(expv.Splits)*share*share) / n
+	return 100 * zScore(level) * math.Sqrt(max(sq-m*m, 0)/n)
+}
+
+// zScore returns the two-sided standard normal score for the confidence
+// level.
+func zScore(level float64) float64 {
+	return math.Sqrt2 * math.Erfinv(level)
+}
+
+// addComp adds the outcome of the hero's eval against the opponent's eval. For
+// a Hi/Lo, the Hi and Lo halves of the pot are each added as an outcome, with
+// the Hi winning both halves when neither Lo qualifies, the same as
+// [Result.Split].
+func (expv *ExpValue) addComp(hero, opp *Eval, low bool) {
+	v, n := [2]int{hero.Comp(opp, false)}, 1
+	if low {
+		v[1], n = v[0], 2
+		if qualified(hero.LoRank) || qualified(opp.LoRank) {
+			v[1] = hero.Comp(opp, true)
+		}
+	}
+	for _, c := range v[:n] {
+		switch c {
+		case -1:
+			expv.Wins++
+		case 0:
+			expv.Splits++
+		default:
+			expv.Losses++
+		}
+		expv.Total++
+	}
 }
 
 // Float32 returns the expected value as a float32.
@@ -419,7 +1471,8 @@ func (expv *ExpValue) Format(f fmt.State, verb rune) {
 type CalcOption func(interface{})
 
 // WithDeep is a calc option to set whether the run should run deep
-// calculations.
+// calculations, such as enumerating the remaining pocket cards of [Stud]
+// types.
 func WithDeep(deep bool) CalcOption {
 	return func(v interface{}) {
 		switch c := v.(type) {
@@ -431,6 +1484,28 @@ func WithDeep(deep bool) CalcOption {
 	}
 }
 
+// WithReuse is a calc option to set whether an odds calc reuses the evals,
+// and the evals' cards, for each enumerated board, drawing the evals from a
+// pool shared by odds calcs. Reduces allocations when enumerating many boards,
+// such as with [Omaha]. Defaults to false.
+func WithReuse(reuse bool) CalcOption {
+	return func(v interface{}) {
+		if c, ok := v.(*OddsCalc); ok {
+			c.reuse = reuse
+		}
+	}
+}
+
+// WithWorkers is a calc option to set the number of parallel workers used by
+// a [RangeCalc]. Defaults to the number of CPUs.
+func WithWorkers(workers int) CalcOption {
+	return func(v interface{}) {
+		if c, ok := v.(*RangeCalc); ok {
+			c.workers = workers
+		}
+	}
+}
+
 // WithRuns is a calc option to set the runs.
 func WithRuns(runs []*Run) CalcOption {
 	return func(v interface{}) {
@@ -451,40 +1526,169 @@ func WithPocketsBoard(pockets [][]Card, board []Card) CalcOption {
 	}
 }
 
-// WithActive is a calc option to run with the active map and whether or not
-// folded positions should be included.
-func WithActive(active map[int]bool, folded bool) CalcOption {
+// WithActive is a calc option to run with the active map and whether or not
+// folded positions should be included.
+func WithActive(active map[int]bool, folded bool) CalcOption {
+	return func(v interface{}) {
+		if c, ok := v.(*OddsCalc); ok {
+			c.active, c.folded = active, folded
+		}
+	}
+}
+
+// WithDiscard is a calc option to set whether the run's discarded cards,
+// including the positions' pocket discards, should be excluded.
+func WithDiscard(discard bool) CalcOption {
+	return func(v interface{}) {
+		if c, ok := v.(*OddsCalc); ok {
+			c.discard = discard
+		}
+	}
+}
+
+// WithBoard is a calc option to set the board.
+func WithBoard(board []Card) CalcOption {
+	return func(v interface{}) {
+		if c, ok := v.(*ExpValueCalc); ok {
+			c.board = board
+		}
+	}
+}
+
+// WithOpponents is a calc option to set the opponents.
+func WithOpponents(opponents int) CalcOption {
+	return func(v interface{}) {
+		if c, ok := v.(*ExpValueCalc); ok {
+			c.opponents = opponents
+		}
+	}
+}
+
+// WithDeadCards is a calc option to add dead cards, such as the exposed cards
+// of a mucked or misdealt pocket, the upcards of a folded [Stud] position
+// (see [Dealer.CalcErr]), or the cards missing from a stripped deck (see
+// [DeckType.Strip]). Dead cards are excluded from the unused cards, and are
+// distinct from the active pockets. Pockets of a [RangeCalc] containing a
+// dead card are skipped.
+func WithDeadCards(cards []Card) CalcOption {
+	return func(v interface{}) {
+		switch c := v.(type) {
+		case *OddsCalc:
+			c.dead = append(c.dead, cards...)
+		case *RangeCalc:
+			c.dead = append(c.dead, cards...)
+		case *ExpValueCalc:
+			c.dead = append(c.dead, cards...)
+		}
+	}
+}
+
+// WithKnownCards is a calc option to set the known cards of a position, such
+// as the exposed card of a opponent's pocket, or the upcards of a [Stud]
+// position. Known cards are held by the position: when the position's cards
+// are enumerated, only the position's cards containing the known cards are
+// enumerated, otherwise the known cards must be in the position's pocket.
+// Calcs return [ErrInvalidCard] for known cards not held by the position, or
+// of a position without a pocket.
+//
+// When used with a [OddsCalc] for a [Stud] type (see [WithDeep]), the
+// remaining cards of each position are enumerated. When used with a
+// [ExpValueCalc], position 0 is the pocket, and position 1 is the enumerated
+// opponent. Use [WithDeadCards] for cards not held by any position, such as
+// the exposed card of a mucked pocket, or the upcards of a folded position.
+func WithKnownCards(pos int, cards []Card) CalcOption {
+	return func(v interface{}) {
+		switch c := v.(type) {
+		case *OddsCalc:
+			if c.known == nil {
+				c.known = make(map[int][]Card)
+			}
+			c.known[pos] = cards
+		case *ExpValueCalc:
+			if c.known == nil {
+				c.known = make(map[int][]Card)
+			}
+			c.known[pos] = cards
+		}
+	}
+}
+
+// WithDeadline is a calc option to set a deadline for a [OddsCalc], after
+// which the partially enumerated odds are returned. See [OddsCalc.CalcErr].
+func WithDeadline(deadline time.Time) CalcOption {
+	return func(v interface{}) {
+		if c, ok := v.(*OddsCalc); ok {
+			c.deadline = deadline
+		}
+	}
+}
+
+// WithCache is a calc option to set a cache for a [OddsCalc]'s calculated
+// odds (see [OddsCache]). Odds calculated with a deadline (see
+// [WithDeadline]) are not cached.
+func WithCache(cache OddsCache) CalcOption {
 	return func(v interface{}) {
 		if c, ok := v.(*OddsCalc); ok {
-			c.active, c.folded = active, folded
+			c.cache = cache
 		}
 	}
 }
 
-// WithDiscard is a calc option to set whether the run's discarded cards should
-// be excluded.
-func WithDiscard(discard bool) CalcOption {
+// WithSamples is a calc option to set a [OddsCalc] to sample the specified
+// number of random board runouts using the shuffler, instead of enumerating
+// every runout. Sampled odds are marked as approximate (see
+// [Odds.Approximate]). Every runout is enumerated when there are fewer
+// runouts than samples. See [WithSamplingStrategy].
+//
+// The shuffler is not used concurrently: a [RangeCalc] uses a separate
+// source for each worker, seeded from the shuffler.
+func WithSamples(samples int, shuffler Shuffler) CalcOption {
 	return func(v interface{}) {
 		if c, ok := v.(*OddsCalc); ok {
-			c.discard = discard
+			c.samples, c.shuffler = samples, shuffler
 		}
 	}
 }
 
-// WithBoard is a calc option to set the board.
-func WithBoard(board []Card) CalcOption {
+// WithSamplingStrategy is a calc option to set the sampling strategy used to
+// reduce the variance of sampled odds (see [WithSamples]).
+func WithSamplingStrategy(strategy SamplingStrategy) CalcOption {
 	return func(v interface{}) {
-		if c, ok := v.(*ExpValueCalc); ok {
-			c.board = board
+		if c, ok := v.(*OddsCalc); ok {
+			c.strategy = strategy
 		}
 	}
 }
 
-// WithOpponents is a calc option to set the opponents.
-func WithOpponents(opponents int) CalcOption {
+// FoldSource is a source of random numbers used to simulate folds (see
+// [WithFolds]). Compatible with math/rand.Rand's Float64 method.
+type FoldSource interface {
+	Float64() float64
+}
+
+// WithFolds is a calc option to simulate future folds when calculating odds,
+// where each position folds before the showdown with its probability in
+// folds, independently for each board enumerated (or sampled), using the
+// source. A folded position does not win, and the last remaining position is
+// never folded. Odds calculated with simulated folds are marked as
+// approximate (see [Odds.Approximate]).
+//
+// All-in equities assume every position reaches the showdown. Simulating
+// folds, such as with each position's expected fold frequency, adjusts the
+// equities for the positions expected to continue. The fold probabilities do
+// not depend on the cards dealt. Simulating folds requires enumerating the
+// boards, as such preflop odds should be calculated with [WithSamples].
+//
+// The source is not used concurrently: a [RangeCalc] uses a separate source
+// for each worker, seeded from the source. When the source is nil, a source
+// seeded from the current time is used.
+func WithFolds(folds []float64, source FoldSource) CalcOption {
+	if source == nil {
+		source = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
 	return func(v interface{}) {
-		if c, ok := v.(*ExpValueCalc); ok {
-			c.opponents = opponents
+		if c, ok := v.(*OddsCalc); ok {
+			c.folds, c.source = folds, source
 		}
 	}
 }
@@ -582,6 +1786,19 @@ func (g *BinGen[T]) Next() bool {
 	return true
 }
 
+// All returns an iterator over the generator's remaining combinations. The
+// yielded slice is reused for each combination, and should be copied when
+// retained.
+func (g *BinGen[T]) All() iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		for g.Next() {
+			if !yield(g.d) {
+				return
+			}
+		}
+	}
+}
+
 // cpy copies the next combination to d.
 func (g *BinGen[T]) cpy() {
 	for i := range g.k {
@@ -604,6 +1821,221 @@ func (g *BinGen[T]) unused() {
 	}
 }
 
+// strideGen is a binomial combination generator that generates each
+// combination exactly once, stepping through the combinations by a stride
+// coprime with the total, such that any prefix of the generated combinations
+// is spread across all combinations.
+type strideGen[T any] struct {
+	s    []T
+	k    int
+	n    int
+	step int
+	i    int
+	j    int
+	d    []T
+}
+
+// newStrideGen creates a stride combination generator for k elements in s.
+// Returns the generator and a slice where the values will be copied after
+// each call to Next.
+func newStrideGen[T any](s []T, k int) (*strideGen[T], []T) {
+	n := binom(len(s), k)
+	// golden ratio stride
+	step := max(int(float64(n)*0.6180339887), 1)
+	for ; 1 < n && gcd(step, n) != 1; step++ {
+	}
+	d := make([]T, k)
+	return &strideGen[T]{
+		s:    s,
+		k:    k,
+		n:    n,
+		step: step,
+		d:    d,
+	}, d
+}
+
+// Next generates the next combination.
+func (g *strideGen[T]) Next() bool {
+	if g.n <= g.i {
+		return false
+	}
+	// unrank the lexicographic combination at j
+	x, c := g.j, 0
+	for p := range g.k {
+		for ; ; c++ {
+			m := binom(len(g.s)-c-1, g.k-p-1)
+			if x < m {
+				break
+			}
+			x -= m
+		}
+		g.d[p] = g.s[c]
+		c++
+	}
+	g.i, g.j = g.i+1, (g.j+g.step)%g.n
+	return true
+}
+
+// doubleGen is a generator for the Hi and Lo boards of double boards, where
+// the Lo board's cards are combinations of the cards remaining after each
+// generated Hi board.
+type doubleGen struct {
+	next func() bool
+	hi   []Card
+	u    []Card
+	k    int
+	g    *BinGen[Card]
+	lo   []Card
+	d    []Card
+}
+
+// newDoubleGen creates a double board generator for the Hi board generator's
+// next func and values, returning the generator and a slice where the Hi and
+// Lo board values will be copied after each call to [doubleGen.Next].
+func newDoubleGen(next func() bool, hi, u []Card, k int) (*doubleGen, []Card) {
+	d := make([]Card, 2*k)
+	return &doubleGen{
+		next: next,
+		hi:   hi,
+		u:    u,
+		k:    k,
+		d:    d,
+	}, d
+}
+
+// Next generates the next Hi and Lo boards.
+func (g *doubleGen) Next() bool {
+	for {
+		if g.g != nil && g.g.Next() {
+			copy(g.d[g.k:], g.lo)
+			return true
+		}
+		if !g.next() {
+			return false
+		}
+		copy(g.d, g.hi)
+		g.g, g.lo = NewCombinGen(Exclude(g.u, g.hi), g.k)
+	}
+}
+
+// SamplingStrategy is a set of sampling strategies for sampled odds (see
+// [WithSamplingStrategy]).
+type SamplingStrategy uint8
+
+// Sampling strategies.
+const (
+	// SampleRandom samples each runout at random.
+	SampleRandom SamplingStrategy = 0
+	// SampleStratified stratifies the samples by the first runout card, such
+	// that each unused card is the first runout card for an equal share of
+	// the samples.
+	SampleStratified SamplingStrategy = 1
+	// SampleAntithetic pairs each sampled runout with the runout having each
+	// card's suit rotated among the unused cards of the same rank, reducing
+	// the variance of flush draws.
+	SampleAntithetic SamplingStrategy = 2
+)
+
+// sampleGen is a sampled combination generator.
+type sampleGen struct {
+	s        []Card
+	w        []Card
+	k        int
+	n        int
+	i        int
+	strata   int
+	shuffler Shuffler
+	strategy SamplingStrategy
+	partner  map[Card]Card
+	pending  bool
+	d        []Card
+}
+
+// newSampleGen creates a sampled combination generator for n samples of k
+// cards in s, using the shuffler and sampling strategy. Returns the generator
+// and a slice where the values will be copied after each call to Next.
+func newSampleGen(s []Card, k, n int, shuffler Shuffler, strategy SamplingStrategy) (*sampleGen, []Card) {
+	g := &sampleGen{
+		s:        s,
+		w:        make([]Card, len(s)),
+		k:        k,
+		n:        n,
+		shuffler: shuffler,
+		strategy: strategy,
+		d:        make([]Card, k),
+	}
+	if strategy&SampleAntithetic != 0 {
+		// rotate suits among the cards of each rank
+		g.partner = make(map[Card]Card, len(s))
+		ranks := make(map[Rank][]Card)
+		for _, c := range s {
+			ranks[c.Rank()] = append(ranks[c.Rank()], c)
+		}
+		for _, v := range ranks {
+			for i, c := range v {
+				g.partner[c] = v[(i+1)%len(v)]
+			}
+		}
+	}
+	return g, g.d
+}
+
+// Next generates the next sampled combination.
+func (g *sampleGen) Next() bool {
+	if g.n <= g.i || len(g.s) < g.k {
+		return false
+	}
+	g.i++
+	if g.pending {
+		for i, c := range g.d {
+			g.d[i] = g.partner[c]
+		}
+		g.pending = false
+		return true
+	}
+	copy(g.w, g.s)
+	w := g.w
+	if g.strategy&SampleStratified != 0 && 0 < g.k {
+		// fix the first card, and shuffle the remaining
+		f := g.strata % len(w)
+		w[0], w[f] = w[f], w[0]
+		g.strata++
+		g.d[0], w = w[0], w[1:]
+		g.shuffler.Shuffle(len(w), func(i, j int) {
+			w[i], w[j] = w[j], w[i]
+		})
+		copy(g.d[1:], w)
+	} else {
+		g.shuffler.Shuffle(len(w), func(i, j int) {
+			w[i], w[j] = w[j], w[i]
+		})
+		copy(g.d, w)
+	}
+	g.pending = g.strategy&SampleAntithetic != 0
+	return true
+}
+
+// binom returns the binomial coefficient of n and k.
+func binom(n, k int) int {
+	if k < 0 || n < k {
+		return 0
+	}
+	k = min(k, n-k)
+	v := 1
+	for i := 1; i <= k; i++ {
+		v = v * (n - k + i) / i
+	}
+	return v
+}
+
+// gcd returns the greatest common divisor of a and b.
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
 // startingExpValue is the preloaded map of starting expected value
 // calculations.
 var startingExpValue map[string]ExpValue
@@ -611,16 +2043,59 @@ var startingExpValue map[string]ExpValue
 // startingCactus is the preloaded map of starting cactus values.
 var startingCactus map[string]EvalRank
 
-func init() {
-	var err error
-	if startingExpValue, startingCactus, err = holdemStarting(); err != nil {
-		panic(err)
+// startings are the registered starting pocket data.
+var startings = make(map[Type]Starting)
+
+// Starting is a type's precalculated starting pocket data.
+type Starting struct {
+	// ExpValue returns the starting expected value for the pocket, or nil
+	// when not available.
+	ExpValue func([]Card) *ExpValue
+	// EvalRank returns the worst (highest) possible resulting 5-card rank for
+	// the pocket, or [Invalid] when not available.
+	EvalRank func([]Card) EvalRank
+}
+
+// RegisterStarting registers the type's starting pocket data, used instead of
+// the [Holdem] starting pocket data by the type's evals and calcs prior to the
+// board being dealt.
+//
+// Types without registered data use the [Holdem] starting pocket data for
+// Cactus and [Omaha] evals and calcs (see [StartingExpValue] and
+// [StartingEvalRank]). Types with other evals (such as [Short] and [Manila])
+// only use registered data.
+func RegisterStarting(typ Type, starting Starting) error {
+	if _, ok := descs[typ]; !ok {
+		return fmt.Errorf("type %s is not registered", typ)
+	}
+	if _, ok := startings[typ]; ok {
+		return fmt.Errorf("type %s starting data already registered", typ)
 	}
+	startings[typ] = starting
+	return nil
 }
 
-// StartingExpValue returns the starting pocket expected value.
+// startingEvalRank returns the type's registered starting eval rank for the
+// pocket, or the [Holdem] starting eval rank when fallback is true.
+func startingEvalRank(typ Type, pocket []Card, fallback bool) EvalRank {
+	if starting, ok := startings[typ]; ok {
+		if starting.EvalRank == nil {
+			return Invalid
+		}
+		return starting.EvalRank(pocket)
+	}
+	if fallback {
+		return StartingEvalRank(pocket)
+	}
+	return Invalid
+}
+
+// StartingExpValue returns the starting pocket expected value. Returns nil
+// when built with the [tiny] build tag.
+//
+// [tiny]: https://pkg.go.dev/github.com/cardrank/cardrank#readme-tiny
 func StartingExpValue(pocket []Card) *ExpValue {
-	var f func([]Card) ([][]Card, int)
+	var f func(*cardBuf, []Card) ([][]Card, int)
 	switch len(pocket) {
 	case 2:
 		f = take2c2
@@ -635,7 +2110,10 @@ func StartingExpValue(pocket []Card) *ExpValue {
 	default:
 		return nil
 	}
-	pockets, n := f(pocket)
+	if startingExpValue == nil {
+		return nil
+	}
+	pockets, n := f(nil, pocket)
 	expv := NewExpValue(1)
 	for i := range n {
 		v := startingExpValue[HashKey(pockets[i][0], pockets[i][1])]
@@ -650,7 +2128,7 @@ func StartingExpValue(pocket []Card) *ExpValue {
 // For example, a single [King] would be the highest non-[Straight] and
 // non-[Flush] value between [Pair] and [HighCard].
 func StartingEvalRank(pocket []Card) EvalRank {
-	var f func([]Card) ([][]Card, int)
+	var f func(*cardBuf, []Card) ([][]Card, int)
 	switch n := len(pocket); n {
 	case 0:
 		return Nothing
@@ -684,7 +2162,7 @@ func StartingEvalRank(pocket []Card) EvalRank {
 			return cactusTwo
 		}
 	case 2:
-		return startingCactus[HashKey(pocket[0], pocket[1])]
+		return startingCactusRank(pocket[0], pocket[1])
 	case 3:
 		f = take3c2
 	case 4:
@@ -696,10 +2174,32 @@ func StartingEvalRank(pocket []Card) EvalRank {
 	default:
 		return Invalid
 	}
-	pockets, n := f(pocket)
+	pockets, n := f(nil, pocket)
 	r := Invalid
 	for i := range n {
-		r = min(r, startingCactus[HashKey(pockets[i][0], pockets[i][1])])
+		r = min(r, startingCactusRank(pockets[i][0], pockets[i][1]))
+	}
+	return r
+}
+
+// calcStartingCactus calculates the worst (highest) possible resulting
+// 5-card rank for the 2 card pocket, by completing the pocket with each 3
+// distinct ranks not in the pocket, of a suit different than the first card.
+func calcStartingCactus(c0, c1 Card) EvalRank {
+	suit := Spade
+	if c0.Suit() == Spade {
+		suit = Heart
+	}
+	mask := uint16(1)<<c0.Rank() | uint16(1)<<c1.Rank()
+	var r EvalRank
+	for r2 := Two; r2 <= Ace; r2++ {
+		for r3 := r2 + 1; r3 <= Ace; r3++ {
+			for r4 := r3 + 1; r4 <= Ace; r4++ {
+				if mask&(1<<r2|1<<r3|1<<r4) == 0 {
+					r = max(r, Cactus(c0, c1, New(r2, suit), New(r3, suit), New(r4, suit)))
+				}
+			}
+		}
 	}
 	return r
 }
@@ -719,94 +2219,571 @@ func HashKey(c0, c1 Card) string {
 	return string([]byte{r0.Byte(), r1.Byte(), 's'})
 }
 
-// HoldemStarting returns the starting Holdem pockets.
-func HoldemStarting() (map[string]ExpValue, map[string]EvalRank) {
-	m, v, err := holdemStarting()
-	if err != nil {
-		panic(fmt.Sprintf("unable to load starting pockets: %v", err))
+// GridCoords returns the 13x13 starting pocket grid coordinates for the hash
+// key (see [HashKey]). Rows and columns are ordered [Ace]-[Two], with suited
+// pockets above the diagonal (row < col), offsuit pockets below the diagonal
+// (col < row), and pairs on the diagonal.
+func GridCoords(key string) (int, int, bool, error) {
+	if len(key) != 2 && len(key) != 3 {
+		return 0, 0, false, fmt.Errorf("%w: %q", ErrInvalidHashKey, key)
+	}
+	r0, r1 := RankFromRune(rune(key[0])), RankFromRune(rune(key[1]))
+	switch {
+	case r0 == InvalidRank, r1 == InvalidRank,
+		len(key) == 2 && r0 != r1,
+		len(key) == 3 && (r0 <= r1 || key[2] != 's' && key[2] != 'o'):
+		return 0, 0, false, fmt.Errorf("%w: %q", ErrInvalidHashKey, key)
+	}
+	i, j := int(Ace-r0), int(Ace-r1)
+	if len(key) == 3 && key[2] == 's' {
+		return i, j, true, nil
 	}
-	return m, v
+	return j, i, false, nil
 }
 
-// holdemStarting returns the starting Holdem pockets.
-func holdemStarting() (map[string]ExpValue, map[string]EvalRank, error) {
-	r := csv.NewReader(bytes.NewReader(starting))
-	r.FieldsPerRecord = 6
-	lines, err := r.ReadAll()
+// GridKey returns the hash key (see [HashKey]) for the 13x13 starting pocket
+// grid coordinates. See [GridCoords] for the grid layout.
+func GridKey(row, col int) string {
+	if row < 0 || 12 < row || col < 0 || 12 < col {
+		return ""
+	}
+	r0, r1 := Ace-Rank(row), Ace-Rank(col)
 	switch {
-	case err != nil:
-		return nil, nil, fmt.Errorf("unable to read starting pockets: %w", err)
-	case len(lines) != 170:
-		return nil, nil, fmt.Errorf("invalid starting pocket length %d", len(lines))
+	case row == col:
+		return string([]byte{r0.Byte(), r1.Byte()})
+	case row < col:
+		return string([]byte{r0.Byte(), r1.Byte(), 's'})
+	}
+	return string([]byte{r1.Byte(), r0.Byte(), 'o'})
+}
+
+// HashKeyPockets returns the pockets for the hash key (see [HashKey]), being
+// the 6 pockets of a pair, 4 suited pockets, or 12 offsuit pockets.
+func HashKeyPockets(key string) ([][]Card, error) {
+	row, col, suited, err := GridCoords(key)
+	if err != nil {
+		return nil, err
+	}
+	r0, r1 := Ace-Rank(min(row, col)), Ace-Rank(max(row, col))
+	suits := Suits()
+	var v [][]Card
+	for i, s0 := range suits {
+		for j, s1 := range suits {
+			switch {
+			case r0 == r1 && i < j,
+				r0 != r1 && suited && i == j,
+				r0 != r1 && !suited && i != j:
+				v = append(v, []Card{New(r0, s0), New(r1, s1)})
+			}
+		}
+	}
+	return v, nil
+}
+
+// GridIterator iterates the 169 starting pockets of the 13x13 starting
+// pocket grid, row by row. See [GridCoords] for the grid layout.
+type GridIterator struct {
+	i int
+	// Row is the current row.
+	Row int
+	// Col is the current column.
+	Col int
+	// Suited is true when the current pocket is suited.
+	Suited bool
+	// Key is the hash key of the current pocket (see [HashKey]).
+	Key string
+}
+
+// NewGridIterator creates a new starting pocket grid iterator.
+func NewGridIterator() *GridIterator {
+	return &GridIterator{i: -1}
+}
+
+// Next moves to the next pocket in the grid.
+func (it *GridIterator) Next() bool {
+	if 169 <= it.i+1 {
+		it.i = 169
+		return false
+	}
+	it.i++
+	it.Row, it.Col = it.i/13, it.i%13
+	it.Suited, it.Key = it.Row < it.Col, GridKey(it.Row, it.Col)
+	return true
+}
+
+// Hutchison returns the Hutchison point count for a 4, 5, or 6 card Omaha
+// pocket, scoring the pocket's flush, pair, and straight potential. Higher
+// counts are stronger starting pockets. Returns 0 for other pocket lengths.
+//
+// The point count only scores Hi potential, and is an approximation for Hi/Lo
+// types (such as [OmahaHiLo]), where a pocket's Lo potential is not counted.
+// Use [StartingEquity] for Hi/Lo types.
+//
+// Points are counted as follows:
+//
+//	Flush:    for each suit with 2 or more cards, 4 (Ace-high), 3 (King-high),
+//	          2.5 (Queen-high), 2 (Jack-high), or 1.5 (other), less 2 for
+//	          each card of the suit beyond the second
+//	Pair:     for each rank with exactly 2 cards, 18 (Aces), 16 (Kings),
+//	          14 (Queens), 13 (Jacks), 12 (Tens), or 10 (Nines) through 3 (Twos)
+//	Straight: for each 2 distinct ranks able to make a straight together, 4
+//	          (no gap), 3 (1 gap), 2 (2 gaps), or 1 (3 gaps)
+func Hutchison(pocket []Card) float64 {
+	if n := len(pocket); n < 4 || 6 < n {
+		return 0
+	}
+	var ranks [13]int
+	var suits [4]Rank
+	var counts [4]int
+	for _, c := range pocket {
+		r, s := c.Rank(), c.SuitIndex()
+		ranks[r]++
+		if counts[s]++; counts[s] == 1 || suits[s] < r {
+			suits[s] = r
+		}
+	}
+	var points float64
+	// flush
+	for i, n := range counts {
+		if n < 2 {
+			continue
+		}
+		switch suits[i] {
+		case Ace:
+			points += 4
+		case King:
+			points += 3
+		case Queen:
+			points += 2.5
+		case Jack:
+			points += 2
+		default:
+			points += 1.5
+		}
+		points -= 2 * float64(n-2)
 	}
-	re := regexp.MustCompile(`^[2-9AKQJT]{2}[os]?$`)
-	m, v := make(map[string]ExpValue), make(map[string]EvalRank)
-	for i, line := range lines[1:] {
-		if !re.MatchString(line[0]) {
-			return nil, nil, fmt.Errorf("line %d: invalid key %q", i+1, line[0])
+	// pairs
+	for r, n := range ranks {
+		if n != 2 {
+			continue
 		}
-		w, _ := strconv.ParseUint(line[1], 10, 64)
-		s, _ := strconv.ParseUint(line[2], 10, 64)
-		l, _ := strconv.ParseUint(line[3], 10, 64)
-		if w+s+l != startingTotal {
-			return nil, nil, fmt.Errorf("line %d: wins, splits, losses do not total %d: %d + %d + %d", i+1, startingTotal, w, s, l)
+		switch Rank(r) {
+		case Ace:
+			points += 18
+		case King:
+			points += 16
+		case Queen:
+			points += 14
+		case Jack:
+			points += 13
+		case Ten:
+			points += 12
+		default:
+			points += float64(r + 3)
 		}
-		expv := ExpValue{
-			Opponents: 1,
-			Wins:      w,
-			Splits:    s,
-			Losses:    l,
-			Total:     startingTotal,
+	}
+	// straights
+	for r0 := Ace; Two < r0; r0-- {
+		if ranks[r0] == 0 {
+			continue
 		}
-		if fmt.Sprintf("%f", expv.Float64()) != line[4] {
-			return nil, nil, fmt.Errorf("line %d: calculated %f does not equal %s", i+1, expv.Float64(), line[4])
+		for r1 := r0 - 1; ; r1-- {
+			if ranks[r1] != 0 {
+				points += straightPoints(int(r0 - r1 - 1))
+			}
+			if r1 == Two {
+				break
+			}
 		}
-		// parse cactus
-		r, _ := strconv.ParseUint(line[5], 10, 64)
-		if r == 0 || uint64(Nothing) < r {
-			return nil, nil, fmt.Errorf("line %d: invalid cactus rank parsed from %q", i+1, line[5])
+	}
+	// wheel straights with a low ace
+	if ranks[Ace] != 0 {
+		for r := Two; r <= Five; r++ {
+			if ranks[r] != 0 {
+				points += straightPoints(int(r - Two))
+			}
 		}
-		m[line[0]], v[line[0]] = expv, EvalRank(r)
 	}
-	return m, v, nil
+	return points
 }
 
-// starting is the embedded starting pocket data.
-//
-//go:embed starting.csv
-var starting []byte
-
-// startingTotal is the total for each starting pocket pair.
-const startingTotal = 2097572400
+// straightPoints returns the Hutchison straight points for the gap between 2
+// ranks.
+func straightPoints(gap int) float64 {
+	if gap < 4 {
+		return float64(4 - gap)
+	}
+	return 0
+}
 
-// countRunSuits returns the suit counts for each of the run's pockets.
-func countRunSuits(run *Run, double bool) ([][4]int, [][4]int) {
-	hi := countCardSuits(run.Pockets, run.Hi)
-	var lo [][4]int
+// StartingEquity returns the estimated expected value of the pocket against
+// a single random opponent pocket, by sampling random boards and opposing
+// pockets using the shuffler. Useful as a baseline for types without
+// precalculated starting values (such as [Omaha], [OmahaFive], and
+// [OmahaSix]). The estimate is deterministic for a deterministic shuffler.
+//
+// For Hi/Lo types (such as [OmahaHiLo]), the Hi and Lo halves of the pot are
+// each counted as an outcome, the same as [ExpValueCalc]. For types with
+// double boards, a separate Lo board is sampled, with each board's half of
+// the pot counted as an outcome.
+func StartingEquity(typ Type, pocket []Card, shuffler Shuffler, samples int) *ExpValue {
+	expv := NewExpValue(1)
+	b, n, low, double := typ.Board(), typ.Pocket(), typ.Low(), typ.Double()
+	u, m := unusedCards(typ, pocket), b+n
 	if double {
-		lo = countCardSuits(run.Pockets, run.Lo)
+		m += b
+	}
+	if b == 0 || len(u) < m {
+		return expv
+	}
+	expv.Sampled = true
+	f, evs, lo := calcs[typ], []*Eval{EvalOf(typ), EvalOf(typ)}, EvalOf(typ)
+	for range samples {
+		shuffler.Shuffle(len(u), func(i, j int) {
+			u[i], u[j] = u[j], u[i]
+		})
+		board, opp := u[:b], u[b:b+n]
+		for i, v := range [][]Card{pocket, opp} {
+			*evs[i] = Eval{
+				Type:   typ,
+				HiRank: Invalid,
+				LoRank: Invalid,
+			}
+			f(evs[i], v, board)
+			if double {
+				*lo = Eval{
+					Type:   typ,
+					HiRank: Invalid,
+					LoRank: Invalid,
+				}
+				f(lo, v, u[b+n:m])
+				evs[i].SetLoFrom(lo)
+			}
+		}
+		expv.addComp(evs[0], evs[1], low || double)
 	}
-	return hi, lo
+	return expv
 }
 
-// countCardSuits returns suit counts.
-func countCardSuits(pockets [][]Card, board []Card) [][4]int {
-	count := len(pockets)
-	if count == 0 {
+// CompareEquity calculates the expected value of the pocket and board against
+// a single opponent pocket for each of the types, such as when choosing the
+// type to play in a dealer's choice game. Types having the same deck, pocket,
+// and board counts share each enumerated board and opponent pocket, with
+// each type's eval applied in the same pass.
+//
+// When samples is 0, every board and opponent pocket is enumerated.
+// Otherwise, the boards and opponent pockets are sampled using the shuffler,
+// and the expected values are marked as sampled (see [ExpValue.Sampled]).
+//
+// For a Hi/Lo type, the pot is split between the Hi and Lo the same as
+// [Result.Split], with each half of the pot counted as an outcome. For a type
+// with double boards, the board is the Hi (first) board, and the Lo (second)
+// board is dealt in full from the cards remaining after the Hi board, with
+// each board's half of the pot counted as an outcome. As enumerating both
+// boards is expensive, use samples for types with double boards.
+//
+// Returns [ErrUnsupportedType] when a type is not registered,
+// [ErrInvalidType] when a type's pocket count does not match the pocket, or
+// [ErrInvalidCard] when a card is not in a type's deck.
+func CompareEquity(ctx context.Context, pocket, board []Card, types []Type, shuffler Shuffler, samples int) (map[Type]*ExpValue, error) {
+	type group struct {
+		deck   DeckType
+		pocket int
+		board  int
+		double bool
+	}
+	var keys []group
+	groups := make(map[group][]Type)
+	for _, typ := range types {
+		if _, ok := descs[typ]; !ok {
+			return nil, ErrUnsupportedType
+		}
+		switch {
+		case typ.Pocket() != len(pocket), typ.Board() < len(board):
+			return nil, fmt.Errorf("%w: %s has %d pocket and %d board cards", ErrInvalidType, typ, typ.Pocket(), typ.Board())
+		}
+		deck := typ.DeckType()
+		for _, c := range slices.Concat(pocket, board) {
+			if !slices.Contains(deck.v(), c) {
+				return nil, fmt.Errorf("%w: %s not in %s deck", ErrInvalidCard, c, typ)
+			}
+		}
+		key := group{deck, typ.Pocket(), typ.Board(), typ.Double()}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], typ)
+	}
+	m := make(map[Type]*ExpValue, len(types))
+	for _, key := range keys {
+		v := groups[key]
+		expvs := make([]*ExpValue, len(v))
+		for i, typ := range v {
+			expvs[i] = NewExpValue(1)
+			expvs[i].Sampled = 0 < samples
+			m[typ] = expvs[i]
+		}
+		u := key.deck.Exclude(pocket, board)
+		var lo int
+		if key.double {
+			lo = key.board
+		}
+		if err := compareEquity(ctx, v, expvs, pocket, board, u, key.board-len(board), lo, shuffler, samples); err != nil {
+			return m, err
+		}
+	}
+	return m, nil
+}
+
+// compareEquity enumerates or samples the boards and opponent pockets from the
+// unused cards, adding the results to each type's expected value. When lo is
+// not 0, a Lo board of lo cards is dealt after the Hi board for types with
+// double boards.
+func compareEquity(ctx context.Context, types []Type, expvs []*ExpValue, pocket, board, u []Card, k, lo int, shuffler Shuffler, samples int) error {
+	n := len(pocket)
+	if len(u) < k+lo+n {
+		return ErrInsufficientCards
+	}
+	hero, opp, ev := make([]*Eval, len(types)), EvalOf(types[0]), EvalOf(types[0])
+	full, second := make([]Card, len(board)+k), make([]Card, lo)
+	copy(full, board)
+	// eval evaluates the pocket for the Hi board, and the Lo board when
+	// there are double boards
+	eval := func(dst *Eval, typ Type, v []Card) {
+		*dst = Eval{Type: typ, HiRank: Invalid, LoRank: Invalid}
+		calcs[typ](dst, v, full)
+		if lo != 0 {
+			*ev = Eval{Type: typ, HiRank: Invalid, LoRank: Invalid}
+			calcs[typ](ev, v, second)
+			dst.SetLoFrom(ev)
+		}
+	}
+	// evalHero evaluates the hero's pocket for the boards for each type
+	evalHero := func() {
+		for i, typ := range types {
+			hero[i] = EvalOf(typ)
+			eval(hero[i], typ, pocket)
+		}
+	}
+	// add adds the opponent pocket results for each type
+	add := func(v []Card) {
+		for i, typ := range types {
+			eval(opp, typ, v)
+			expvs[i].addComp(hero[i], opp, typ.Low() || typ.Double())
+		}
+	}
+	if 0 < samples {
+		w := slices.Clone(u)
+		for range samples {
+			select {
+			case <-ctx.Done():
+				return cancelled(ctx)
+			default:
+			}
+			shuffler.Shuffle(len(w), func(i, j int) {
+				w[i], w[j] = w[j], w[i]
+			})
+			copy(full[len(board):], w[:k])
+			copy(second, w[k:k+lo])
+			evalHero()
+			add(w[k+lo : k+lo+n])
+		}
 		return nil
 	}
-	base := make([]int, 4)
-	countSuits(base, board)
-	v := make([][4]int, count)
-	for i := range count {
-		copy(v[i][:], base)
+	for g, v := NewCombinGen(u, k); g.Next(); {
+		select {
+		case <-ctx.Done():
+			return cancelled(ctx)
+		default:
+		}
+		copy(full[len(board):], v)
+		r := Exclude(u, v)
+		for h, b := NewCombinGen(r, lo); h.Next(); {
+			copy(second, b)
+			evalHero()
+			for j, p := NewCombinGen(Exclude(r, b), n); j.Next(); {
+				add(p)
+			}
+		}
 	}
-	return v
+	return nil
+}
+
+// TransitionMatrix is a hand category transition matrix, counting the
+// transitions from the hand category after a street to the final hand
+// category after the last street. Categories are indexed in the order of
+// [IterCategories].
+type TransitionMatrix struct {
+	// Type is the type.
+	Type Type
+	// Street is the street index.
+	Street int
+	// Counts are the transition counts, indexed by the category after the
+	// street and the final category.
+	Counts [9][9]int
+	// Totals are the total counts of the category after the street.
+	Totals [9]int
+	// Total is the total count.
+	Total int
+}
+
+// Transitions calculates the type's hand category transition matrix from the
+// street (index) to the last street, sampling random deals from the type's
+// deck.
+//
+// The hand category after the street is the Hi eval's category when enough
+// pocket and board cards have been dealt for the eval (see [EvalType.Combos]),
+// otherwise the best set (pair, two pair, three or four of a kind) of the
+// dealt cards. For [Omaha] evals, the best set uses at most 2 pocket cards,
+// the same as the eval. Returns
+// [ErrUnsupportedType] for types without a Cactus eval or with draw streets,
+// or [ErrInvalidStreet] for an invalid street.
+func (typ Type) Transitions(ctx context.Context, street int, shuffler Shuffler, samples int) (*TransitionMatrix, error) {
+	desc, ok := descs[typ]
+	switch {
+	case !ok, !desc.Eval.Cactus(), typ.Draw():
+		return nil, ErrUnsupportedType
+	case street < 0 || len(desc.Streets) <= street:
+		return nil, fmt.Errorf("%w: %d", ErrInvalidStreet, street)
+	}
+	var pn, bn int
+	var partial bool
+	for i, s := range desc.Streets {
+		pn, bn = pn+s.Pocket, bn+s.Board
+		if i == street {
+			partial = desc.Eval.Combos(pn, bn) == 0
+		}
+	}
+	var omaha bool
+	switch desc.Eval {
+	case EvalManila, EvalSpanish, EvalOmaha:
+		omaha = true
+	}
+	v := desc.Deck.Unshuffled()
+	if len(v) < pn+bn {
+		return nil, ErrInsufficientCards
+	}
+	m := &TransitionMatrix{
+		Type:   typ,
+		Street: street,
+	}
+	ev := EvalOf(typ)
+	for range samples {
+		select {
+		case <-ctx.Done():
+			return m, cancelled(ctx)
+		default:
+		}
+		shuffler.Shuffle(len(v), func(i, j int) {
+			v[i], v[j] = v[j], v[i]
+		})
+		var pocket, board []Card
+		var from EvalRank
+		for i, s := range desc.Streets {
+			pocket, board = v[:len(pocket)+s.Pocket], v[pn:pn+len(board)+s.Board]
+			if i == street {
+				if partial {
+					from = partialCategory(pocket, board, omaha)
+				} else {
+					from = transitionCategory(ev, pocket, board)
+				}
+			}
+		}
+		i := slices.Index(categories, from)
+		j := slices.Index(categories, transitionCategory(ev, pocket, board))
+		if i == -1 || j == -1 {
+			continue
+		}
+		m.Counts[i][j]++
+		m.Totals[i]++
+		m.Total++
+	}
+	return m, nil
+}
+
+// transitionCategory returns the Cactus category of the type's Hi eval.
+func transitionCategory(ev *Eval, pocket, board []Card) EvalRank {
+	*ev = Eval{Type: ev.Type, HiRank: Invalid, LoRank: Invalid}
+	calcs[ev.Type](ev, pocket, board)
+	return cactusRank(ev.Type, ev.HiRank).Fixed()
+}
+
+// partialCategory returns the Cactus category of the best set of the pocket
+// and board, using at most 2 pocket cards when omaha is true.
+func partialCategory(pocket, board []Card, omaha bool) EvalRank {
+	if !omaha || len(pocket) <= 2 {
+		return setCategory(slices.Concat(pocket, board))
+	}
+	r, v := Nothing, make([]Card, 2+len(board))
+	copy(v[2:], board)
+	for i := range len(pocket) - 1 {
+		for j := i + 1; j < len(pocket); j++ {
+			v[0], v[1] = pocket[i], pocket[j]
+			r = min(r, setCategory(v))
+		}
+	}
+	return r
 }
 
-// countSuits counts the suits in v, adding to d.
-func countSuits(d []int, v []Card) {
+// setCategory returns the Cactus category of the best set of the cards in v.
+func setCategory(v []Card) EvalRank {
+	var counts [13]int
 	for _, c := range v {
-		d[c.SuitIndex()]++
+		counts[c.RankIndex()]++
+	}
+	var pairs, trips int
+	for _, n := range counts {
+		switch {
+		case 4 <= n:
+			return FourOfAKind
+		case n == 3:
+			trips++
+		case n == 2:
+			pairs++
+		}
+	}
+	switch {
+	case 0 < trips:
+		return ThreeOfAKind
+	case 1 < pairs:
+		return TwoPair
+	case pairs == 1:
+		return Pair
 	}
+	return Nothing
 }
+
+// Prob returns the probability of transitioning from the category to the
+// final category.
+func (m *TransitionMatrix) Prob(from, to EvalRank) float64 {
+	i, j := slices.Index(categories, from.Fixed()), slices.Index(categories, to.Fixed())
+	if i == -1 || j == -1 || m.Totals[i] == 0 {
+		return 0
+	}
+	return float64(m.Counts[i][j]) / float64(m.Totals[i])
+}
+
+// Format satisfies the [fmt.Formatter] interface, writing a line for each
+// category after the street with its transition probabilities.
+func (m *TransitionMatrix) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's':
+		for i, from := range categories {
+			if m.Totals[i] == 0 {
+				continue
+			}
+			fmt.Fprintf(f, "%s (%d):", from.Title(), m.Totals[i])
+			for j, to := range categories {
+				if m.Counts[i][j] != 0 {
+					fmt.Fprintf(f, " %s %.1f%%", to.Title(), 100*m.Prob(from, to))
+				}
+			}
+			fmt.Fprintln(f)
+		}
+	default:
+		fmt.Fprintf(f, "%%!%c(ERROR=unknown verb, transition matrix)", verb)
+	}
+}
+
+// startingTotal is the total for each starting pocket pair.
+const startingTotal = 2097572400
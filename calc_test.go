@@ -3,9 +3,13 @@ package cardrank
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"math/rand"
 	"os"
 	"reflect"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -68,12 +72,140 @@ func TestStartingEvalRank(t *testing.T) {
 	}
 }
 
-func TestStartingExpValueOmaha(t *testing.T) {
-	expv, ok := Omaha.ExpValue(context.Background(), Must("Ah Kh 9s Jd"))
-	if !ok {
-		t.Fatalf("expected ok")
+func TestCalcErr(t *testing.T) {
+	pockets, board := [][]Card{Must("Ah Kh"), Must("7c 7d")}, Must("2c 8h Td")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		typ     Type
+		ctx     context.Context
+		pockets [][]Card
+		exp     error
+	}{
+		{Holdem, context.Background(), pockets, nil},
+		{Holdem, context.Background(), nil, ErrInsufficientCards},
+		{Holdem, ctx, pockets, ErrContextCancelled},
+		{Type(0xffff), context.Background(), pockets, ErrUnsupportedType},
+	}
+	for i, test := range tests {
+		_, _, err := test.typ.OddsErr(test.ctx, test.pockets, board)
+		if !errors.Is(err, test.exp) {
+			t.Errorf("test %d expected %v, got: %v", i, test.exp, err)
+		}
+	}
+	if _, _, err := Holdem.OddsErr(ctx, pockets, board); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected %v, got: %v", context.Canceled, err)
+	}
+	if _, err := Holdem.ExpValueErr(context.Background(), Must("Ah")); !errors.Is(err, ErrInsufficientCards) {
+		t.Errorf("expected %v, got: %v", ErrInsufficientCards, err)
+	}
+	if _, err := Holdem.EvalErr(Must("Ah Kh"), board); err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+	if _, err := Razz.EvalErr(Must("Ah 2c"), nil); !errors.Is(err, ErrInsufficientCards) {
+		t.Errorf("expected %v, got: %v", ErrInsufficientCards, err)
+	}
+	if _, err := Type(0xffff).EvalErr(Must("Ah Kh"), board); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("expected %v, got: %v", ErrUnsupportedType, err)
+	}
+}
+
+func TestHutchison(t *testing.T) {
+	tests := []struct {
+		v   string
+		exp float64
+	}{
+		{"Ac Ad Kc Kd", 46},
+		{"Jh Th 9s 8s", 23.5},
+		{"Ah 2h 3c Kd", 19},
+		{"Ah As Ad 2c", 4},
+		{"9h 9s 5d 5c", 17},
+		{"Ah Kh Qh Jh", 20},
+		{"Ah Kh Qh Jh Th", 28},
+		{"Ah Kh", 0},
+	}
+	for i, test := range tests {
+		if p := Hutchison(Must(test.v)); p != test.exp {
+			t.Errorf("test %d %s expected %f, got: %f", i, test.v, test.exp, p)
+		}
+	}
+}
+
+func TestStartingEquity(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	expv := StartingEquity(Holdem, Must("Ah Ad"), r, 4000)
+	if f := expv.Float64(); expv.Total != 4000 || f < 0.82 || 0.88 < f {
+		t.Errorf("expected approximately 0.85, got: %f (%d)", f, expv.Total)
+	}
+	a := StartingEquity(Omaha, Must("Ac Ad Kc Kd"), r, 2000)
+	b := StartingEquity(Omaha, Must("2c 3d 7h 8s"), r, 2000)
+	if a.Float64() <= b.Float64() {
+		t.Errorf("expected %f > %f", a.Float64(), b.Float64())
+	}
+	if expv := StartingEquity(Stud, Must("Ah Ad"), r, 100); expv.Total != 0 {
+		t.Errorf("expected %d, got: %d", 0, expv.Total)
+	}
+	// the hi and lo halves are each counted for hi/lo types
+	hi := StartingEquity(Omaha, Must("Ah 2h 3s 4s"), r, 2000)
+	lo := StartingEquity(OmahaHiLo, Must("Ah 2h 3s 4s"), r, 2000)
+	if lo.Total != 4000 {
+		t.Errorf("expected %d, got: %d", 4000, lo.Total)
+	}
+	if lo.Float64() <= hi.Float64() {
+		t.Errorf("expected %f > %f", lo.Float64(), hi.Float64())
+	}
+	if expv := StartingEquity(Double, Must("Ah Ad"), r, 1000); expv.Total != 2000 {
+		t.Errorf("expected %d, got: %d", 2000, expv.Total)
+	}
+}
+
+func TestRegisterStarting(t *testing.T) {
+	defer delete(startings, Short)
+	pocket := Must("Ah Ad")
+	if ev := Short.Eval(pocket, nil); ev.HiRank != Invalid {
+		t.Errorf("expected %d, got: %d", Invalid, ev.HiRank)
+	}
+	starting := Starting{
+		ExpValue: func(v []Card) *ExpValue {
+			if len(v) != 2 {
+				return nil
+			}
+			return &ExpValue{Opponents: 1, Wins: 3, Losses: 1, Total: 4}
+		},
+		EvalRank: func(v []Card) EvalRank {
+			return 1234
+		},
+	}
+	if err := RegisterStarting(Short, starting); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if err := RegisterStarting(Short, starting); err == nil {
+		t.Errorf("expected error")
+	}
+	if err := RegisterStarting(Type(0), starting); err == nil {
+		t.Errorf("expected error")
+	}
+	ev := Short.Eval(Must("Ad Ah"), nil)
+	if ev.HiRank != 1234 {
+		t.Errorf("expected %d, got: %d", 1234, ev.HiRank)
+	}
+	if exp := Must("Ad Ah"); !reflect.DeepEqual(ev.HiBest, exp) {
+		t.Errorf("expected %v, got: %v", exp, ev.HiBest)
+	}
+	if r := Short.StartingEvalRank(pocket); r != 1234 {
+		t.Errorf("expected %d, got: %d", 1234, r)
+	}
+	expv, ok := Short.ExpValue(context.Background(), pocket)
+	if !ok || expv.Wins != 3 || expv.Total != 4 {
+		t.Errorf("expected registered expected value, got: %v %t", expv, ok)
+	}
+	odds, _, ok := Short.Odds(context.Background(), [][]Card{pocket, Must("Kh Kd")}, nil)
+	if !ok || odds.Total != 4 || odds.Counts[0] != 4 {
+		t.Errorf("expected registered starting odds, got: %v %t", odds, ok)
+	}
+	if r := Holdem.StartingEvalRank(pocket); r != StartingEvalRank(pocket) {
+		t.Errorf("expected %d, got: %d", StartingEvalRank(pocket), r)
 	}
-	t.Logf("%v", expv)
 }
 
 func TestOddsCalc(t *testing.T) {
@@ -420,6 +552,641 @@ func testOddsCalc(t *testing.T, ctx context.Context, typ Type, pockets [][]Card,
 	}
 }
 
+func TestOddsRatio(t *testing.T) {
+	odds := &Odds{
+		Total:  1000,
+		Counts: []int{320, 680, 0},
+	}
+	tests := []struct {
+		pos int
+		s   string
+		exp string
+	}{
+		{0, "%*r", "2.1 : 1"},
+		{1, "%*r", "0.5 : 1"},
+		{0, "%*.2r", "2.12 : 1"},
+		{2, "%*r", "+Inf : 1"},
+	}
+	for i, test := range tests {
+		if s := fmt.Sprintf(test.s, test.pos, odds); s != test.exp {
+			t.Errorf("test %d expected %q, got: %q", i, test.exp, s)
+		}
+	}
+	if !odds.Profitable(0, 300, 100) {
+		t.Errorf("expected profitable")
+	}
+	if odds.Profitable(0, 100, 100) {
+		t.Errorf("expected not profitable")
+	}
+}
+
+func TestCalcStreetOdds(t *testing.T) {
+	ctx := context.Background()
+	pockets := [][]Card{Must("Ah Kh"), Must("Qs Qd")}
+	board := Must("Qh 7h 2c Jh 3s")
+	v, ok := Holdem.StreetOdds(ctx, pockets, board, WithDeep(false))
+	switch {
+	case !ok:
+		t.Fatalf("expected ok")
+	case len(v) != 4:
+		t.Fatalf("expected 4 streets, got: %d", len(v))
+	}
+	for i, exp := range []string{"Pre-Flop", "Flop", "Turn", "River"} {
+		if v[i].Street.Name != exp {
+			t.Errorf("test %d expected %q, got: %q", i, exp, v[i].Street.Name)
+		}
+		if n, exp := len(v[i].Board), []int{0, 3, 4, 5}[i]; n != exp {
+			t.Errorf("test %d expected board %d, got: %d", i, exp, n)
+		}
+	}
+	flop, _, _ := Holdem.Odds(ctx, pockets, board[:3])
+	if !reflect.DeepEqual(v[1].Hi.Counts, flop.Counts) || v[1].Hi.Total != flop.Total {
+		t.Errorf("expected %v, got: %v", flop.Counts, v[1].Hi.Counts)
+	}
+	if river := v[3].Hi; river.Total != 1 || river.Counts[0] != 1 || river.Counts[1] != 0 {
+		t.Errorf("expected river win for 0, got: %v", river.Counts)
+	}
+	// partial runout
+	if v, _ := Holdem.StreetOdds(ctx, pockets, board[:4], WithDeep(false)); len(v) != 3 {
+		t.Errorf("expected 3 streets, got: %d", len(v))
+	}
+}
+
+func TestRazzOdds(t *testing.T) {
+	ctx := context.Background()
+	// 6th street, compare to the razz eval
+	pockets := [][]Card{Must("Ah 3c 5d Ks Qh 8c"), Must("2s 4d 6h 9c 9d Tc")}
+	odds, lo, ok := Razz.Odds(ctx, pockets, nil, WithDeep(true))
+	switch {
+	case !ok:
+		t.Fatalf("expected ok")
+	case lo != nil:
+		t.Fatalf("expected nil lo odds")
+	}
+	u := DeckFrench.Exclude(pockets...)
+	exp := NewOdds(2, u)
+	for _, c0 := range u {
+		for _, c1 := range u {
+			if c0 == c1 {
+				continue
+			}
+			a, b := Razz.Eval(append(pockets[0][:6:6], c0), nil), Razz.Eval(append(pockets[1][:6:6], c1), nil)
+			switch {
+			case a.HiRank < b.HiRank:
+				exp.Counts[0]++
+				exp.Total++
+			case b.HiRank < a.HiRank:
+				exp.Counts[1]++
+				exp.Total++
+			default:
+				exp.Counts[0]++
+				exp.Counts[1]++
+				exp.Total += 2
+			}
+		}
+	}
+	if odds.Total != exp.Total || !reflect.DeepEqual(odds.Counts, exp.Counts) {
+		t.Errorf("expected %d %v, got: %d %v", exp.Total, exp.Counts, odds.Total, odds.Counts)
+	}
+	if !odds.Outs[1][Must("3s")[0]] {
+		t.Errorf("expected out 3s, got: %v", odds.Outs[1])
+	}
+	// 5th street, a made wheel cannot lose
+	pockets = [][]Card{Must("Ah 2c 3d 4s 5h"), Must("2s 3h 4d 6c 7d")}
+	odds, _, ok = Razz.Odds(ctx, pockets, nil, WithDeep(true))
+	switch {
+	case !ok:
+		t.Fatalf("expected ok")
+	case odds.Counts[0] != 861*780:
+		t.Errorf("expected %d, got: %d", 861*780, odds.Counts[0])
+	case odds.Counts[1] == 0 || odds.Total != odds.Counts[0]+odds.Counts[1]:
+		t.Errorf("expected splits, got: %d %v", odds.Total, odds.Counts)
+	}
+	// folded positions are skipped
+	pockets = append(pockets, Must("Kc Kd Ks"))
+	if odds, _, ok = Razz.Odds(ctx, pockets, nil, WithDeep(true), WithActive(map[int]bool{0: true, 1: true}, true)); !ok || odds.Counts[2] != 0 {
+		t.Errorf("expected no counts for folded position, got: %v", odds.Counts)
+	}
+}
+
+func TestStudOdds(t *testing.T) {
+	ctx := context.Background()
+	// 6th street, compare to the stud eval
+	pockets := [][]Card{Must("Ah Ac 5d Ks Qh 8c"), Must("2s 4d 6h 9c 9d Tc")}
+	known := Must("As Kc 9h")
+	for _, dead := range []bool{false, true} {
+		var opts []CalcOption
+		u := DeckFrench.Exclude(pockets...)
+		if dead {
+			opts = append(opts, WithDeadCards(known))
+			u = DeckFrench.Exclude(append(pockets, known)...)
+		}
+		odds, lo, ok := Stud.Odds(ctx, pockets, nil, append(opts, WithDeep(true))...)
+		switch {
+		case !ok:
+			t.Fatalf("expected ok")
+		case lo != nil:
+			t.Fatalf("expected nil lo odds")
+		}
+		exp := NewOdds(2, u)
+		for _, c0 := range u {
+			for _, c1 := range u {
+				if c0 == c1 {
+					continue
+				}
+				a, b := Stud.Eval(append(pockets[0][:6:6], c0), nil), Stud.Eval(append(pockets[1][:6:6], c1), nil)
+				switch {
+				case a.HiRank < b.HiRank:
+					exp.Counts[0]++
+					exp.Total++
+				case b.HiRank < a.HiRank:
+					exp.Counts[1]++
+					exp.Total++
+				default:
+					exp.Counts[0]++
+					exp.Counts[1]++
+					exp.Total += 2
+				}
+			}
+		}
+		if odds.Total != exp.Total || !reflect.DeepEqual(odds.Counts, exp.Counts) {
+			t.Errorf("dead %t expected %d %v, got: %d %v", dead, exp.Total, exp.Counts, odds.Total, odds.Counts)
+		}
+		if dead && odds.Outs[1][Must("9h")[0]] {
+			t.Errorf("expected dead 9h to not be an out")
+		}
+	}
+	// hi/lo
+	pockets = [][]Card{Must("Ah 2c 3d 4s Kh 8c"), Must("Ks Kd 6h 9c 9d Tc")}
+	hi, lo, ok := StudHiLo.Odds(ctx, pockets, nil, WithDeep(true))
+	switch {
+	case !ok:
+		t.Fatalf("expected ok")
+	case lo == nil:
+		t.Fatalf("expected lo odds")
+	case hi.Counts[1] <= hi.Counts[0]:
+		t.Errorf("expected position 1 to be the hi favorite, got: %v", hi.Counts)
+	case lo.Counts[1] != 0 || lo.Counts[0] == 0:
+		t.Errorf("expected only position 0 to make a lo, got: %v", lo.Counts)
+	}
+}
+
+func TestWithDeadCards(t *testing.T) {
+	ctx := context.Background()
+	pockets, board := [][]Card{Must("Ah Kh"), Must("Qs Qd")}, Must("Qh 7h 2c")
+	tests := []struct {
+		known []Card
+		total int
+	}{
+		{nil, 990},
+		{Must("Jh Th"), 903},
+		{Must("Kc"), 946},
+	}
+	for i, test := range tests {
+		odds, _, ok := Holdem.Odds(ctx, pockets, board, WithDeadCards(test.known))
+		switch {
+		case !ok:
+			t.Fatalf("test %d expected ok", i)
+		case odds.Total != test.total:
+			t.Errorf("test %d expected %d, got: %d", i, test.total, odds.Total)
+		}
+	}
+	// stripped deck, 1035 boards of 46 unused cards, by 946 opponent pockets
+	expv, ok := Holdem.ExpValue(ctx, Must("Ah As"), WithBoard(board), WithDeadCards(DeckFrench.Strip(Must("Kc")...).Missing))
+	switch {
+	case !ok:
+		t.Fatalf("expected ok")
+	case expv.Total != 979110:
+		t.Errorf("expected %d, got: %d", 979110, expv.Total)
+	}
+	// pockets containing a dead card are skipped
+	ranges := [][][]Card{{Must("Ah Kh"), Must("Ac Kc")}, {Must("Qs Qd")}}
+	odds, _, ok := Holdem.RangeOdds(ctx, ranges, board, WithDeadCards(Must("Kc")))
+	switch {
+	case !ok:
+		t.Fatalf("expected ok")
+	case odds.Total != 946:
+		t.Errorf("expected %d, got: %d", 946, odds.Total)
+	}
+}
+
+func TestWithKnownCards(t *testing.T) {
+	ctx := context.Background()
+	// known cards held by a position
+	pockets, board := [][]Card{Must("Ah Kh"), Must("Qs Qd")}, Must("Qh 7h 2c")
+	odds, _, ok := Holdem.Odds(ctx, pockets, board, WithKnownCards(1, Must("Qs")))
+	switch {
+	case !ok:
+		t.Fatalf("expected ok")
+	case odds.Total != 990:
+		t.Errorf("expected %d, got: %d", 990, odds.Total)
+	}
+	// known cards not held by a position, or of a position without a pocket
+	for i, opt := range []CalcOption{WithKnownCards(1, Must("Kc")), WithKnownCards(2, Must("Kc"))} {
+		if _, _, err := Holdem.OddsErr(ctx, pockets, board, opt); !errors.Is(err, ErrInvalidCard) {
+			t.Errorf("test %d expected error %v, got: %v", i, ErrInvalidCard, err)
+		}
+	}
+	for i, opt := range []CalcOption{WithKnownCards(0, Must("Kc")), WithKnownCards(2, Must("Kc"))} {
+		if _, err := NewExpValueCalc(Holdem, Must("Ah As"), WithBoard(board), opt).CalcErr(ctx); !errors.Is(err, ErrInvalidCard) {
+			t.Errorf("test %d expected error %v, got: %v", i, ErrInvalidCard, err)
+		}
+	}
+	// opponent with 1 known card
+	expv, ok := Holdem.ExpValue(ctx, Must("Ah As"), WithBoard(board), WithKnownCards(1, Must("Kc")))
+	switch {
+	case !ok:
+		t.Fatalf("expected ok")
+	case expv.Total != 1035*44:
+		t.Errorf("expected %d, got: %d", 1035*44, expv.Total)
+	}
+	// opponent fully known
+	expv, ok = Holdem.ExpValue(ctx, Must("Ah As"), WithBoard(board), WithKnownCards(1, Must("Qs Qd")))
+	switch {
+	case !ok:
+		t.Fatalf("expected ok")
+	case expv.Total != 990:
+		t.Errorf("expected %d, got: %d", 990, expv.Total)
+	case expv.Losses == 0 || expv.Wins == 0:
+		t.Errorf("expected wins and losses, got: %v", expv)
+	}
+	// stud position with 1 known card, enumerating only the remaining cards
+	pockets = [][]Card{Must("Ah Ac 5d Ks Qh 8c"), Must("6h 9c 9d Tc")}
+	hi, _, ok := Stud.Odds(ctx, pockets, nil, WithDeep(true), WithKnownCards(1, Must("2s")))
+	if !ok {
+		t.Fatalf("expected ok")
+	}
+	exp, _, _ := Stud.Odds(ctx, [][]Card{pockets[0], Must("6h 9c 9d Tc 2s")}, nil, WithDeep(true))
+	if hi.Total != exp.Total || !slices.Equal(hi.Counts, exp.Counts) {
+		t.Errorf("expected %d %v, got: %d %v", exp.Total, exp.Counts, hi.Total, hi.Counts)
+	}
+	// the upcards of a folded position are dead cards, not known cards
+	folded := WithActive(map[int]bool{0: true, 2: true}, false)
+	pockets = append(pockets, Must("7c 8d 2h Jc"))
+	for i, opts := range [][]CalcOption{
+		{WithKnownCards(1, Must("2s 3s 4s 5s"))},
+		{WithKnownCards(3, Must("2s"))},
+		{folded, WithKnownCards(1, Must("2s"))},
+	} {
+		if _, _, err := Stud.OddsErr(ctx, pockets, nil, append(opts, WithDeep(true))...); !errors.Is(err, ErrInvalidCard) {
+			t.Errorf("test %d expected error %v, got: %v", i, ErrInvalidCard, err)
+		}
+	}
+}
+
+func TestWithDeadline(t *testing.T) {
+	ctx := context.Background()
+	pockets, board := [][]Card{Must("Ah Kh"), Must("Qs Qd")}, Must("Qh 7h 2c")
+	exp, _, _ := Holdem.Odds(ctx, pockets, board)
+	odds, _, ok := Holdem.Odds(ctx, pockets, board, WithDeadline(time.Now().Add(time.Hour)))
+	switch {
+	case !ok:
+		t.Fatalf("expected ok")
+	case odds.Approximate:
+		t.Errorf("expected not approximate")
+	case odds.Evaluated != 990 || odds.Combinations != 990:
+		t.Errorf("expected 990/990, got: %d/%d", odds.Evaluated, odds.Combinations)
+	case !reflect.DeepEqual(odds.Counts, exp.Counts) || odds.Total != exp.Total:
+		t.Errorf("expected %v, got: %v", exp.Counts, odds.Counts)
+	}
+	odds, _, ok = Holdem.Odds(ctx, pockets, board, WithDeadline(time.Now().Add(-time.Hour)))
+	switch {
+	case !ok:
+		t.Fatalf("expected ok")
+	case !odds.Approximate:
+		t.Errorf("expected approximate")
+	case odds.Evaluated == 0 || odds.Combinations <= odds.Evaluated:
+		t.Errorf("expected partial evaluation, got: %d/%d", odds.Evaluated, odds.Combinations)
+	case odds.Total == 0:
+		t.Errorf("expected outcomes")
+	}
+}
+
+func TestWithSamples(t *testing.T) {
+	ctx := context.Background()
+	pockets, board := [][]Card{Must("Ah Kh"), Must("Qs Qd")}, Must("Qh 7h")
+	exp, _, _ := Holdem.Odds(ctx, pockets, board)
+	for _, strategy := range []SamplingStrategy{
+		SampleRandom,
+		SampleStratified,
+		SampleAntithetic,
+		SampleStratified | SampleAntithetic,
+	} {
+		r := rand.New(rand.NewSource(0))
+		odds, _, ok := Holdem.Odds(ctx, pockets, board, WithSamples(4000, r), WithSamplingStrategy(strategy))
+		switch {
+		case !ok:
+			t.Fatalf("strategy %d expected ok", strategy)
+		case !odds.Approximate:
+			t.Errorf("strategy %d expected approximate", strategy)
+		case odds.Evaluated != 4000 || odds.Combinations != 15180:
+			t.Errorf("strategy %d expected 4000/15180, got: %d/%d", strategy, odds.Evaluated, odds.Combinations)
+		}
+		for i := range pockets {
+			if p, e := odds.Percent(i), exp.Percent(i); math.Abs(float64(p-e)) > 3 {
+				t.Errorf("strategy %d position %d expected %f, got: %f", strategy, i, e, p)
+			}
+		}
+	}
+	// enumerates when fewer runouts than samples
+	odds, _, _ := Holdem.Odds(ctx, pockets, Must("Qh 7h 2c"), WithSamples(4000, rand.New(rand.NewSource(0))))
+	if odds.Approximate || odds.Evaluated != 990 {
+		t.Errorf("expected exact enumeration, got: %t %d", odds.Approximate, odds.Evaluated)
+	}
+	// each range worker samples with a separate source (run with -race)
+	ranges := [][][]Card{{Must("Ah Kh"), Must("As Ks"), Must("Ad Kd")}, {Must("Qs Qd"), Must("Jc Jd")}}
+	odds, _, err := NewRangeCalc(Holdem, ranges, board, WithSamples(500, rand.New(rand.NewSource(0))), WithWorkers(3)).CalcErr(ctx)
+	if err != nil || !odds.Approximate || odds.Evaluated != 3000 {
+		t.Errorf("expected 3000 sampled outcomes, got: %d %v", odds.Evaluated, err)
+	}
+}
+
+func TestConfidenceInterval(t *testing.T) {
+	if z := zScore(0.95); math.Abs(z-1.959964) > 1e-6 {
+		t.Errorf("expected 1.959964, got: %f", z)
+	}
+	ctx := context.Background()
+	pockets, board := [][]Card{Must("Ah Kh"), Must("Qs Qd")}, Must("Qh 7h")
+	exp, _, _ := Holdem.Odds(ctx, pockets, board)
+	if !exp.Exact() || exp.ConfidenceInterval(0, 0.95) != 0 {
+		t.Errorf("expected exact odds")
+	}
+	odds, _, _ := Holdem.Odds(ctx, pockets, board, WithSamples(2000, rand.New(rand.NewSource(0))))
+	if odds.Exact() {
+		t.Errorf("expected inexact odds")
+	}
+	for i := range pockets {
+		ci := odds.ConfidenceInterval(i, 0.99)
+		if ci <= 0 || 5 < ci {
+			t.Errorf("position %d expected margin between 0 and 5, got: %f", i, ci)
+		}
+		if d := math.Abs(float64(odds.Percent(i) - exp.Percent(i))); ci < d {
+			t.Errorf("position %d expected %f within %f, got: %f", i, exp.Percent(i), ci, odds.Percent(i))
+		}
+	}
+	expv := StartingEquity(Holdem, Must("Ah Ad"), rand.New(rand.NewSource(0)), 2000)
+	if expv.Exact() {
+		t.Errorf("expected inexact expected value")
+	}
+	if ci := expv.ConfidenceInterval(0.95); ci <= 0 || 5 < ci {
+		t.Errorf("expected margin between 0 and 5, got: %f", ci)
+	}
+	expv = &ExpValue{Opponents: 1, Wins: 50, Splits: 10, Losses: 40, Total: 100}
+	if ci := expv.ConfidenceInterval(0.95); ci != 0 {
+		t.Errorf("expected 0, got: %f", ci)
+	}
+	// mean 0.55, mean of squares 0.525, variance 0.2225
+	expv.Sampled = true
+	if ci, exp := expv.ConfidenceInterval(0.95), 100*zScore(0.95)*math.Sqrt(0.2225/100); math.Abs(ci-exp) > 1e-9 {
+		t.Errorf("expected %f, got: %f", exp, ci)
+	}
+}
+
+func TestSampleGen(t *testing.T) {
+	u := Must("Ah Ad Kh 2c")
+	g, v := newSampleGen(u, 2, 8, rand.New(rand.NewSource(0)), SampleStratified|SampleAntithetic)
+	var prev []Card
+	first := make(map[Card]int)
+	for i := 0; g.Next(); i++ {
+		if v[0] == v[1] {
+			t.Fatalf("test %d expected distinct cards, got: %v", i, v)
+		}
+		if i%2 == 0 {
+			first[v[0]]++
+			prev = slices.Clone(v)
+			continue
+		}
+		// antithetic partner
+		for j, c := range v {
+			if c.Rank() != prev[j].Rank() {
+				t.Errorf("test %d expected rank %s, got: %s", i, prev[j].Rank(), c.Rank())
+			}
+		}
+	}
+	for _, c := range u {
+		if first[c] != 1 {
+			t.Errorf("expected %s to be the first card once, got: %d", c, first[c])
+		}
+	}
+}
+
+func TestStrideGen(t *testing.T) {
+	for _, k := range []int{0, 1, 2, 3, 5} {
+		s := DeckFrench.Unshuffled()[:10]
+		exp := make(map[string]bool)
+		for g, v := NewCombinGen(s, k); g.Next(); {
+			exp[fmt.Sprintf("%v", v)] = true
+		}
+		m := make(map[string]bool)
+		for g, v := newStrideGen(s, k); g.Next(); {
+			if key := fmt.Sprintf("%v", v); m[key] {
+				t.Fatalf("k %d expected %s to be generated once", k, key)
+			} else {
+				m[key] = true
+			}
+		}
+		if !reflect.DeepEqual(m, exp) {
+			t.Errorf("k %d expected %d combinations, got: %d", k, len(exp), len(m))
+		}
+	}
+}
+
+func TestOddsAdd(t *testing.T) {
+	pockets := [][]Card{Must("Ah Kh"), Must("Qs Qd"), Must("7c 8c")}
+	odds := NewOdds(len(pockets), nil)
+	evs := Holdem.EvalPockets(pockets, Must("2h 3d 9s 4c 5c"))
+	v := Must("4c 5c")
+	for range 3 {
+		odds.Add(evs, nil, v, false)
+	}
+	if odds.Total != 3 || odds.Counts[0] != 3 {
+		t.Errorf("expected 3 wins for 0, got: %d/%v", odds.Total, odds.Counts)
+	}
+	if exp := []int{0, 3, 3}; !reflect.DeepEqual(odds.Losses, exp) {
+		t.Errorf("expected losses %v, got: %v", exp, odds.Losses)
+	}
+	if exp := Must("4c 5c"); !reflect.DeepEqual(v, exp) {
+		t.Errorf("expected cards to be unmodified %v, got: %v", exp, v)
+	}
+}
+
+func TestWithReuse(t *testing.T) {
+	tests := []struct {
+		typ     Type
+		pockets string
+		board   string
+	}{
+		{Holdem, "Ah Kh, Qs Qd, 7c 8c", "2h 3d 9s"},
+		{OmahaHiLo, "Ah 2h Kc Qd, As 3d Js Ts, 7c 8c 9d 4h", "Qh 7h 2c"},
+		{Omaha, "Ah Kh Qd Jd, Qs Qc 9d 8d", "2h 3d 9s 4s"},
+		{Omaha, "Ah Kh Qd Jd, Qs Qc 9d 8d, 7c 6c 5h 4h", "2h 3d 9s"},
+	}
+	for i, test := range tests {
+		var pockets [][]Card
+		for _, s := range strings.Split(test.pockets, ",") {
+			pockets = append(pockets, Must(s))
+		}
+		board := Must(test.board)
+		hi, lo, _ := test.typ.Odds(context.Background(), pockets, board)
+		rhi, rlo, _ := test.typ.Odds(context.Background(), pockets, board, WithReuse(true))
+		if !reflect.DeepEqual(hi, rhi) || !reflect.DeepEqual(lo, rlo) {
+			t.Errorf("test %d expected %v/%v, got: %v/%v", i, hi, lo, rhi, rlo)
+		}
+	}
+	// the cards of an eval not reused by a odds calc are not overwritten
+	v := Must("2c 3c 4c 5c 6c")
+	ev := EvalOf(Omaha)
+	ev.HiBest, ev.HiUnused = v[:0], v[:0]
+	ev.Eval(Must("Ah Kh Qd Jd"), Must("Qh Jh Th"))
+	if exp := Must("2c 3c 4c 5c 6c"); !slices.Equal(v, exp) {
+		t.Errorf("expected %v, got: %v", exp, v)
+	}
+	// double boards
+	for _, typ := range []Type{Double, OmahaDouble} {
+		run := NewRun(2)
+		run.Pockets[0], run.Pockets[1] = Must("Ah Ad Kc Qd")[:typ.Pocket()], Must("Kh Qh 9d 8d")[:typ.Pocket()]
+		run.Hi, run.Lo = Must("2h 7h 9c Js"), Must("Ks 3c 4d 8s")
+		odds, err := NewOddsCalc(typ, WithRuns([]*Run{run})).CalcDouble(context.Background())
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		rodds, err := NewOddsCalc(typ, WithRuns([]*Run{run}), WithReuse(true)).CalcDouble(context.Background())
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !reflect.DeepEqual(odds, rodds) {
+			t.Errorf("%s expected %v, got: %v", typ, odds, rodds)
+		}
+	}
+}
+
+func TestRangeCalc(t *testing.T) {
+	ctx := context.Background()
+	board := Must("Qh 7h 2c")
+	ranges := [][][]Card{
+		{Must("Ah Kh"), Must("As Ks")},
+		{Must("Ah Ac"), Must("Qd Qc")},
+		{Must("Jh Th"), Must("As Ac"), Must("9s 8s")},
+	}
+	// manually enumerate assignments without shared cards
+	exp := NewOdds(3, nil)
+	var n int
+	for _, p0 := range ranges[0] {
+		for _, p1 := range ranges[1] {
+			for _, p2 := range ranges[2] {
+				if excluded(exclude(p0, p1), p2) || excluded(exclude(p0), p1) {
+					continue
+				}
+				odds, _, _ := Holdem.Odds(ctx, [][]Card{p0, p1, p2}, board)
+				exp.Merge(odds)
+				n++
+			}
+		}
+	}
+	if n != 7 {
+		t.Fatalf("expected 7 assignments, got: %d", n)
+	}
+	for _, workers := range []int{1, 4} {
+		odds, lo, ok := Holdem.RangeOdds(ctx, ranges, board, WithWorkers(workers))
+		switch {
+		case !ok:
+			t.Fatalf("expected ok")
+		case lo != nil:
+			t.Errorf("expected nil lo")
+		case odds.Total != exp.Total || !reflect.DeepEqual(odds.Counts, exp.Counts):
+			t.Errorf("workers %d expected %d %v, got: %d %v", workers, exp.Total, exp.Counts, odds.Total, odds.Counts)
+		}
+	}
+	// single pocket ranges match odds
+	hi, lo, _ := OmahaHiLo.Odds(ctx, [][]Card{Must("Ah 2h Kc Qd"), Must("As 3d Js Ts")}, board)
+	rhi, rlo, ok := OmahaHiLo.RangeOdds(ctx, [][][]Card{{Must("Ah 2h Kc Qd")}, {Must("As 3d Js Ts")}}, board)
+	switch {
+	case !ok:
+		t.Fatalf("expected ok")
+	case !reflect.DeepEqual(hi.Counts, rhi.Counts), !reflect.DeepEqual(lo.Counts, rlo.Counts):
+		t.Errorf("expected %v/%v, got: %v/%v", hi.Counts, lo.Counts, rhi.Counts, rlo.Counts)
+	}
+	// workers use separate sources
+	r := rand.New(rand.NewSource(0))
+	odds, _, ok := Holdem.RangeOdds(ctx, ranges, nil, WithWorkers(4), WithSamples(200, r), WithFolds([]float64{0, 0.5, 0.5}, r))
+	switch {
+	case !ok:
+		t.Fatalf("expected ok")
+	case !odds.Approximate || odds.Total == 0:
+		t.Errorf("expected approximate odds, got: %t %d", odds.Approximate, odds.Total)
+	}
+}
+
+func TestRangeCalcEquity(t *testing.T) {
+	ctx := context.Background()
+	// split pots are divided between the tied winners
+	pockets, board := [][]Card{Must("Qc Jc"), Must("Qd Jd"), Must("9s 9h")}, Must("Ah Kh 7c 2d")
+	evs := make([]Describer, len(pockets))
+	for i, pocket := range pockets {
+		evs[i] = Holdem.Eval(pocket, board)
+	}
+	a, err := Holdem.AllIn(ctx, pockets, board, 1, ResultOf(false, evs...))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	exp, _, _ := Holdem.Odds(ctx, pockets, board)
+	ranges := [][][]Card{{pockets[0]}, {pockets[1]}, {pockets[2]}}
+	for _, workers := range []int{1, 4} {
+		hi, _, equity, err := NewRangeCalc(Holdem, ranges, board, WithWorkers(workers)).CalcEquity(ctx)
+		switch {
+		case err != nil:
+			t.Fatalf("expected no error, got: %v", err)
+		case hi.Total != exp.Total:
+			t.Errorf("expected %d, got: %d", exp.Total, hi.Total)
+		}
+		for i := range equity {
+			if math.Abs(equity[i]-a.Equity[i]) > 1e-9 {
+				t.Errorf("workers %d position %d expected %f, got: %f", workers, i, a.Equity[i], equity[i])
+			}
+		}
+	}
+	// hi/lo pots are split between the hi and lo winners
+	_, _, equity, err := NewRangeCalc(OmahaHiLo, [][][]Card{{Must("Ah 2h Kc Qd")}, {Must("As 3d Js Ts")}}, Must("Qh 7h 4c")).CalcEquity(ctx)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if sum := equity[0] + equity[1]; math.Abs(sum-1) > 1e-9 {
+		t.Errorf("expected equities summing to 1, got: %f", sum)
+	}
+	if _, _, equity, err := NewRangeCalc(Type(0xffff), ranges, board).CalcEquity(ctx); !errors.Is(err, ErrUnsupportedType) || equity != nil {
+		t.Errorf("expected error %v, got: %v %v", ErrUnsupportedType, equity, err)
+	}
+}
+
+func TestNeedEquity(t *testing.T) {
+	tests := []struct {
+		pot   float64
+		call  float64
+		need  float64
+		ratio float64
+	}{
+		{300, 100, 0.25, 3},
+		{100, 100, 0.5, 1},
+		{150, 50, 0.25, 3},
+		{0, 0, 0, math.Inf(1)},
+	}
+	for i, test := range tests {
+		if f := NeedEquity(test.pot, test.call); f != test.need {
+			t.Errorf("test %d expected %f, got: %f", i, test.need, f)
+		}
+		r := PotOdds(test.pot, test.call)
+		if r != test.ratio {
+			t.Errorf("test %d expected %f, got: %f", i, test.ratio, r)
+		}
+		if f := BreakEven(r); f != test.need {
+			t.Errorf("test %d expected %f, got: %f", i, test.need, f)
+		}
+	}
+	if r, exp := ImpliedOdds(100, 50, 200), 6.0; r != exp {
+		t.Errorf("expected %f, got: %f", exp, r)
+	}
+}
+
 func TestExpValueCalc(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -649,33 +1416,6 @@ func testStartingCSV(t *testing.T, ctx context.Context, c0, c1 Card, wait *int64
 	atomic.AddInt64(wait, -1)
 }
 
-func calcStartingCactus(c0, c1 Card) EvalRank {
-	if r0 := c0.Rank(); r0 == c1.Rank() {
-		return calcStartingCactusPair(r0)
-	}
-	f, ev := calcs[Holdem], EvalOf(Holdem)
-	r, z := EvalRank(0), []Card{c0, c1, InvalidCard, InvalidCard, InvalidCard}
-	for g, v := NewCombinGen(Formatter([]Card{c0, c1}).Ranks(), 3); g.Next(); {
-		z[2], z[3], z[4] = New(v[0], Spade), New(v[1], Heart), New(v[2], Club)
-		f(ev, z, nil)
-		r = max(r, ev.HiRank)
-	}
-	return r
-}
-
-func calcStartingCactusPair(rank Rank) EvalRank {
-	var v []Card
-	for r := Two; r <= Five && len(v) != 3; r++ {
-		if r == rank {
-			continue
-		}
-		v = append(v, New(r, Club))
-	}
-	ev := EvalOf(Holdem)
-	calcs[Holdem](ev, []Card{New(rank, Heart), New(rank, Spade)}, v)
-	return ev.HiRank
-}
-
 type expValueRes struct {
 	c0, c1 Card
 	ev     ExpValue
@@ -683,6 +1423,32 @@ type expValueRes struct {
 	cactus EvalRank
 }
 
+func TestBinGenAll(t *testing.T) {
+	g, _ := NewCombinGen([]int{0, 1, 2, 3, 4}, 2)
+	var v [][]int
+	for c := range g.All() {
+		v = append(v, append([]int(nil), c...))
+	}
+	exp := [][]int{{0, 1}, {0, 2}, {0, 3}, {0, 4}, {1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}}
+	if !reflect.DeepEqual(v, exp) {
+		t.Errorf("expected %v, got: %v", exp, v)
+	}
+	g, _ = NewCombinGen([]int{0, 1, 2, 3, 4}, 3)
+	var n int
+	for range g.All() {
+		if n++; n == 4 {
+			break
+		}
+	}
+	var rest int
+	for range g.All() {
+		rest++
+	}
+	if rest != 6 {
+		t.Errorf("expected %d, got: %d", 6, rest)
+	}
+}
+
 func TestHashKey(t *testing.T) {
 	tests := []struct {
 		s   string
@@ -704,3 +1470,358 @@ func TestHashKey(t *testing.T) {
 		}
 	}
 }
+
+func TestHashKeyPockets(t *testing.T) {
+	tests := []struct {
+		key string
+		exp int
+	}{
+		{"AA", 6},
+		{"AKs", 4},
+		{"AKo", 12},
+		{"72o", 12},
+		{"32s", 4},
+	}
+	for i, test := range tests {
+		v, err := HashKeyPockets(test.key)
+		if err != nil {
+			t.Fatalf("test %d expected no error, got: %v", i, err)
+		}
+		if len(v) != test.exp {
+			t.Errorf("test %d expected %d, got: %d", i, test.exp, len(v))
+		}
+		for _, pocket := range v {
+			if key := HashKey(pocket[0], pocket[1]); key != test.key {
+				t.Errorf("test %d expected %s, got: %s", i, test.key, key)
+			}
+		}
+	}
+	if _, err := HashKeyPockets("AAs"); !errors.Is(err, ErrInvalidHashKey) {
+		t.Errorf("expected error %v, got: %v", ErrInvalidHashKey, err)
+	}
+}
+
+func TestCompareEquity(t *testing.T) {
+	ctx := context.Background()
+	pocket, board := Must("Ah As"), Must("7d Kc Td Kd")
+	types := []Type{Holdem, Short, Manila, Dallas}
+	m, err := CompareEquity(ctx, pocket, board, types, nil, 0)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	for _, typ := range types {
+		exp, ok := typ.ExpValue(ctx, pocket, WithBoard(board))
+		switch {
+		case !ok:
+			t.Fatalf("%s expected ok", typ)
+		case m[typ] == nil:
+			t.Fatalf("%s expected expected value", typ)
+		case *m[typ] != *exp:
+			t.Errorf("%s expected %v, got: %v", typ, exp, m[typ])
+		}
+	}
+	// sampled
+	m, err = CompareEquity(ctx, pocket, board, types[:2], rand.New(rand.NewSource(0)), 5000)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	for _, typ := range types[:2] {
+		exp, _ := typ.ExpValue(ctx, pocket, WithBoard(board))
+		switch expv := m[typ]; {
+		case !expv.Sampled || expv.Total != 5000:
+			t.Errorf("%s expected 5000 sampled, got: %t %d", typ, expv.Sampled, expv.Total)
+		case math.Abs(expv.Float64()-exp.Float64()) > 0.03:
+			t.Errorf("%s expected %f, got: %f", typ, exp.Float64(), expv.Float64())
+		}
+	}
+	// hi/lo pot split
+	pocket, board = Must("Ah 3h"), Must("Kh 7d 4c 9s")
+	if m, err = CompareEquity(ctx, pocket, board, []Type{Split}, nil, 0); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	var share float64
+	var n int
+	u := DeckFrench.Exclude(pocket, board)
+	for _, c := range u {
+		for g, v := NewCombinGen(Exclude(u, []Card{c}), 2); g.Next(); n++ {
+			run := &Run{Pockets: [][]Card{pocket, slices.Clone(v)}, Hi: append(slices.Clone(board), c)}
+			share += NewResult(Split, run, nil, true).Split(1)[0]
+		}
+	}
+	if exp := share / float64(n); math.Abs(m[Split].Float64()-exp) > 1e-9 {
+		t.Errorf("expected %f, got: %f", exp, m[Split].Float64())
+	}
+	// double boards split each board's half of the pot
+	pocket, board = Must("7h 7s"), Must("7d 7c Kh")
+	if m, err = CompareEquity(ctx, pocket, board, []Type{Holdem, Double}, rand.New(rand.NewSource(0)), 20000); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	share, n = 0, 0
+	r := rand.New(rand.NewSource(1))
+	u = DeckFrench.Exclude(pocket, board)
+	for ; n < 20000; n++ {
+		r.Shuffle(len(u), func(i, j int) {
+			u[i], u[j] = u[j], u[i]
+		})
+		run := &Run{
+			Pockets: [][]Card{pocket, slices.Clone(u[7:9])},
+			Hi:      append(slices.Clone(board), u[:2]...),
+			Lo:      slices.Clone(u[2:7]),
+		}
+		share += NewResult(Double, run, nil, true).Split(1)[0]
+	}
+	switch exp := share / float64(n); {
+	case m[Double].Total != 40000:
+		t.Errorf("expected 40000 outcomes, got: %d", m[Double].Total)
+	case math.Abs(m[Double].Float64()-exp) > 0.02:
+		t.Errorf("expected %f, got: %f", exp, m[Double].Float64())
+	case m[Holdem].Float64()-m[Double].Float64() < 0.1:
+		t.Errorf("expected double %f less than %f", m[Double].Float64(), m[Holdem].Float64())
+	}
+	// invalid
+	if _, err := CompareEquity(ctx, pocket, board, []Type{Omaha}, nil, 0); !errors.Is(err, ErrInvalidType) {
+		t.Errorf("expected error %v, got: %v", ErrInvalidType, err)
+	}
+	if _, err := CompareEquity(ctx, Must("Ah 2s"), nil, []Type{Short}, nil, 0); !errors.Is(err, ErrInvalidCard) {
+		t.Errorf("expected error %v, got: %v", ErrInvalidCard, err)
+	}
+}
+
+func TestGridCoords(t *testing.T) {
+	tests := []struct {
+		key    string
+		row    int
+		col    int
+		suited bool
+	}{
+		{"AA", 0, 0, false},
+		{"AKs", 0, 1, true},
+		{"AKo", 1, 0, false},
+		{"T9o", 5, 4, false},
+		{"T9s", 4, 5, true},
+		{"77", 7, 7, false},
+		{"32s", 11, 12, true},
+		{"22", 12, 12, false},
+	}
+	for i, test := range tests {
+		row, col, suited, err := GridCoords(test.key)
+		switch {
+		case err != nil:
+			t.Fatalf("test %d expected no error, got: %v", i, err)
+		case row != test.row || col != test.col || suited != test.suited:
+			t.Errorf("test %d expected %d,%d,%t, got: %d,%d,%t", i, test.row, test.col, test.suited, row, col, suited)
+		}
+		if key := GridKey(row, col); key != test.key {
+			t.Errorf("test %d expected %q, got: %q", i, test.key, key)
+		}
+	}
+	for _, key := range []string{"", "A", "AK", "KAs", "AAs", "AKx", "1Ks", "AKso"} {
+		if _, _, _, err := GridCoords(key); !errors.Is(err, ErrInvalidHashKey) {
+			t.Errorf("%q expected error %v, got: %v", key, ErrInvalidHashKey, err)
+		}
+	}
+	// every starting pocket hash key appears exactly once
+	m := make(map[string]bool)
+	for g, v := NewCombinGen(DeckFrench.Unshuffled(), 2); g.Next(); {
+		m[HashKey(v[0], v[1])] = true
+	}
+	n := 0
+	for it := NewGridIterator(); it.Next(); n++ {
+		row, col, suited, err := GridCoords(it.Key)
+		switch {
+		case err != nil:
+			t.Fatalf("%q expected no error, got: %v", it.Key, err)
+		case row != it.Row || col != it.Col || suited != it.Suited:
+			t.Errorf("%q expected %d,%d,%t, got: %d,%d,%t", it.Key, it.Row, it.Col, it.Suited, row, col, suited)
+		case !m[it.Key]:
+			t.Errorf("%q expected valid hash key", it.Key)
+		}
+		delete(m, it.Key)
+	}
+	if n != 169 || len(m) != 0 {
+		t.Errorf("expected 169 keys, got: %d (%d remaining)", n, len(m))
+	}
+}
+
+func TestWithFolds(t *testing.T) {
+	ctx := context.Background()
+	pockets := [][]Card{Must("Ah Kh"), Must("2c 2d"), Must("Js Ts")}
+	src := rand.New(rand.NewSource(0))
+	exp, _, _ := Holdem.Odds(ctx, pockets, nil, WithSamples(5000, rand.New(rand.NewSource(0))))
+	// no folds
+	odds, _, _ := Holdem.Odds(ctx, pockets, nil, WithSamples(5000, rand.New(rand.NewSource(0))), WithFolds([]float64{0, 0, 0}, src))
+	if !slices.Equal(odds.Counts, exp.Counts) || !odds.Approximate {
+		t.Errorf("expected %v, got: %v", exp.Counts, odds.Counts)
+	}
+	// always folds
+	odds, _, _ = Holdem.Odds(ctx, pockets, nil, WithSamples(5000, rand.New(rand.NewSource(0))), WithFolds([]float64{0, 1, 1}, src))
+	if odds.Counts[0] != 5000 || odds.Counts[1] != 0 || odds.Counts[2] != 0 {
+		t.Errorf("expected [5000 0 0], got: %v", odds.Counts)
+	}
+	// last remaining position never folds
+	odds, _, _ = Holdem.Odds(ctx, pockets, nil, WithSamples(5000, rand.New(rand.NewSource(0))), WithFolds([]float64{1, 1, 1}, src))
+	if odds.Counts[2] != 5000 {
+		t.Errorf("expected [0 0 5000], got: %v", odds.Counts)
+	}
+	// folding pair
+	odds, _, _ = Holdem.Odds(ctx, pockets, nil, WithSamples(5000, rand.New(rand.NewSource(0))), WithFolds([]float64{0, 0.5, 0}, src))
+	switch {
+	case exp.Percent(1)*0.7 < odds.Percent(1):
+		t.Errorf("expected %f to be less than %f", odds.Percent(1), exp.Percent(1))
+	case odds.Percent(0) <= exp.Percent(0), odds.Percent(2) <= exp.Percent(2):
+		t.Errorf("expected %f, %f to be greater than %f, %f", odds.Percent(0), odds.Percent(2), exp.Percent(0), exp.Percent(2))
+	}
+	// enumerated
+	board := Must("Qh 7h 2s")
+	odds, _, _ = Holdem.Odds(ctx, pockets, board, WithFolds([]float64{0, 0.5, 0.5}, src))
+	if !odds.Approximate || odds.Evaluated != 903 || odds.Combinations != 903 {
+		t.Errorf("expected approximate 903/903, got: %t %d/%d", odds.Approximate, odds.Evaluated, odds.Combinations)
+	}
+	// nil source
+	odds, _, _ = Holdem.Odds(ctx, pockets, board, WithFolds([]float64{0, 1, 1}, nil))
+	if odds.Counts[0] != 903 || odds.Counts[1] != 0 || odds.Counts[2] != 0 {
+		t.Errorf("expected [903 0 0], got: %v", odds.Counts)
+	}
+	// seeded from a source without Int63
+	if seed := seedOf(nil, maxSource{}); seed < 0 {
+		t.Errorf("expected non-negative seed, got: %d", seed)
+	}
+}
+
+// maxSource is a fold source returning the largest float64 less than 1.
+type maxSource struct{}
+
+func (maxSource) Float64() float64 {
+	return math.Nextafter(1, 0)
+}
+
+func TestTransitions(t *testing.T) {
+	for _, typ := range []Type{Holdem, Omaha, OmahaFive, Courchevel, Stud} {
+		for street := range typ.Streets() {
+			m, err := typ.Transitions(context.Background(), street, rand.New(rand.NewSource(0)), 2000)
+			if err != nil {
+				t.Fatalf("%s %d expected no error, got: %v", typ, street, err)
+			}
+			if m.Total != 2000 {
+				t.Errorf("%s %d expected %d, got: %d", typ, street, 2000, m.Total)
+			}
+			for i, from := range categories {
+				var sum float64
+				for j, to := range categories {
+					// a final category cannot be worse than a set
+					if i < j && m.Counts[i][j] != 0 {
+						t.Errorf("%s %d expected no %s -> %s transitions", typ, street, from, to)
+					}
+					sum += m.Prob(from, to)
+				}
+				if m.Totals[i] != 0 && math.Abs(sum-1) > 1e-9 {
+					t.Errorf("%s %d expected %s sum 1, got: %f", typ, street, from, sum)
+				}
+			}
+		}
+	}
+	m, _ := Holdem.Transitions(context.Background(), 0, rand.New(rand.NewSource(0)), 20000)
+	if p := m.Prob(Pair, Pair); p < 0.3 || 0.45 < p {
+		t.Errorf("expected pair to remain a pair ~0.36, got: %f", p)
+	}
+	if p := m.Prob(Nothing, Nothing); p < 0.15 || 0.25 < p {
+		t.Errorf("expected nothing to remain nothing ~0.2, got: %f", p)
+	}
+	if s := fmt.Sprintf("%v", m); !strings.HasPrefix(s, "Pair (") {
+		t.Errorf("expected pair line, got: %q", s)
+	}
+	// omaha uses exactly 2 pocket cards, so a pocket set is at most a pair
+	for _, typ := range []Type{Omaha, OmahaFive} {
+		m, _ := typ.Transitions(context.Background(), 0, rand.New(rand.NewSource(0)), 5000)
+		for _, from := range []EvalRank{FourOfAKind, ThreeOfAKind, TwoPair} {
+			if n := m.Totals[slices.Index(categories, from)]; n != 0 {
+				t.Errorf("%s expected no %s, got: %d", typ, from, n)
+			}
+		}
+	}
+	if r := partialCategory(Must("Ah As Ad Kh"), nil, true); r != Pair {
+		t.Errorf("expected %s, got: %s", Pair, r)
+	}
+	if r := partialCategory(Must("Ah As Ad Kh"), nil, false); r != ThreeOfAKind {
+		t.Errorf("expected %s, got: %s", ThreeOfAKind, r)
+	}
+	// a 3 card pocket flush with 2 flush cards on the board is not a flush
+	ev := EvalOf(Omaha)
+	if r := transitionCategory(ev, Must("Ah Kh Qh 2c"), Must("Jh Th 8c 7d 2s")); r != Pair {
+		t.Errorf("expected %s, got: %s", Pair, r)
+	}
+	for i, typ := range []Type{Draw, Razz} {
+		if _, err := typ.Transitions(context.Background(), 0, rand.New(rand.NewSource(0)), 1); !errors.Is(err, ErrUnsupportedType) {
+			t.Errorf("test %d expected error %v, got: %v", i, ErrUnsupportedType, err)
+		}
+	}
+	if _, err := Holdem.Transitions(context.Background(), 4, rand.New(rand.NewSource(0)), 1); !errors.Is(err, ErrInvalidStreet) {
+		t.Errorf("expected error %v, got: %v", ErrInvalidStreet, err)
+	}
+}
+
+func TestCalcDouble(t *testing.T) {
+	run := NewRun(2)
+	run.Pockets[0], run.Pockets[1] = Must("Ah Ad"), Must("Kh Qh")
+	run.Hi, run.Lo = Must("2h 7h 9c Js"), Must("Ks 3c 4d 8s")
+	c := NewOddsCalc(Double, WithRuns([]*Run{run}))
+	hi, lo, err := c.CalcErr(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	odds, err := c.CalcDouble(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	// 40 rivers for the hi board, and 39 for the lo board
+	if exp := 40 * 39; odds.Total != exp || odds.Hi.Combinations != exp {
+		t.Errorf("expected %d, got: %d %d", exp, odds.Total, odds.Hi.Combinations)
+	}
+	var equity float64
+	for i := range 2 {
+		if exp, v := hi.Percent(i), odds.Hi.Percent(i); exp != v {
+			t.Errorf("position %d expected hi %f, got: %f", i, exp, v)
+		}
+		if exp, v := lo.Percent(i), odds.Lo.Percent(i); math.Abs(float64(exp-v)) > 0.5 {
+			t.Errorf("position %d expected lo ~%f, got: %f", i, exp, v)
+		}
+		if odds.Scoop(i) > min(odds.HiEquity(i), odds.LoEquity(i)) {
+			t.Errorf("position %d expected scoop less than board equity", i)
+		}
+		equity += odds.Equity(i)
+	}
+	if math.Abs(equity-1) > 1e-9 {
+		t.Errorf("expected equity 1, got: %f", equity)
+	}
+	// aces win the hi board unless the flush comes, and the lo board unless a
+	// king, queen or straight comes
+	if p := odds.Scoop(0); p < 0.5 || 0.7 < p {
+		t.Errorf("expected scoop ~0.6, got: %f", p)
+	}
+	if s := fmt.Sprintf("%v", odds); strings.Count(s, "\n") != 2 {
+		t.Errorf("expected 2 lines, got: %q", s)
+	}
+	if _, err := NewOddsCalc(Holdem, WithRuns([]*Run{run})).CalcDouble(context.Background()); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("expected error %v, got: %v", ErrUnsupportedType, err)
+	}
+	run.Hi, run.Lo = nil, nil
+	if _, err := c.CalcDouble(context.Background()); !errors.Is(err, ErrInsufficientCards) {
+		t.Errorf("expected error %v, got: %v", ErrInsufficientCards, err)
+	}
+	c = NewOddsCalc(Double, WithRuns([]*Run{run}), WithSamples(2000, rand.New(rand.NewSource(0))))
+	if odds, err = c.CalcDouble(context.Background()); err != nil || odds.Total != 2000 {
+		t.Errorf("expected 2000 samples, got: %v %v", odds, err)
+	}
+	// pot shares include the lo board's half of the pot
+	run.Hi, run.Lo = Must("2h 7h 9c Js 3d"), Must("Ks 3c 4d 8s Kd")
+	c = NewOddsCalc(Double, WithRuns([]*Run{run}))
+	c.shares = &potShares{shares: make([]float64, 2)}
+	if _, _, err := c.CalcErr(context.Background()); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	for i := range 2 {
+		if e := c.shares.equity(i); e != 0.5 {
+			t.Errorf("position %d expected 0.5, got: %f", i, e)
+		}
+	}
+}
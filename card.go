@@ -3,6 +3,7 @@ package cardrank
 import (
 	"bytes"
 	"fmt"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -40,6 +41,20 @@ const (
 // InvalidRank is an invalid card rank.
 const InvalidRank = ^Rank(0)
 
+// RankFromIndex returns the card rank for the index (0-12 for [Two]-[Ace]).
+// Returns [InvalidRank] for an out of range index.
+func RankFromIndex(i int) Rank {
+	if 0 <= i && i <= int(Ace) {
+		return Rank(i)
+	}
+	return InvalidRank
+}
+
+// Ranks returns the card ranks, ordered by index ([Two]-[Ace]).
+func Ranks() []Rank {
+	return []Rank{Two, Three, Four, Five, Six, Seven, Eight, Nine, Ten, Jack, Queen, King, Ace}
+}
+
 // RankFromRune returns a rune's card rank.
 func RankFromRune(r rune) Rank {
 	switch r {
@@ -116,6 +131,15 @@ func (rank Rank) Index() int {
 	return int(rank)
 }
 
+// Prime returns the card rank's prime (2, 3, 5, ... 41 for [Two]-[Ace]), as
+// encoded in the lower bits of a [Card]. Returns 0 for an invalid rank.
+func (rank Rank) Prime() uint32 {
+	if Ace < rank {
+		return 0
+	}
+	return primes[rank]
+}
+
 // Name returns the card rank name.
 func (rank Rank) Name() string {
 	switch rank {
@@ -198,6 +222,22 @@ const (
 // InvalidSuit is an invalid card suit.
 const InvalidSuit = ^Suit(0)
 
+// SuitFromIndex returns the card suit for the index (0-3 for [Spade],
+// [Heart], [Diamond], [Club]). Returns [InvalidSuit] for an out of range
+// index.
+func SuitFromIndex(i int) Suit {
+	if 0 <= i && i < 4 {
+		return Suit(1 << i)
+	}
+	return InvalidSuit
+}
+
+// Suits returns the card suits, ordered by index ([Spade], [Heart],
+// [Diamond], [Club]).
+func Suits() []Suit {
+	return []Suit{Spade, Heart, Diamond, Club}
+}
+
 // SuitFromRune returns a rune's card suit.
 func SuitFromRune(r rune) Suit {
 	switch r {
@@ -328,6 +368,11 @@ type Card uint32
 // InvalidCard is an invalid card.
 const InvalidCard = ^Card(0)
 
+// Joker is a joker card, used as the bug in [California] (see [DeckJoker]).
+// Has no [Rank] or [Suit], and is represented as "Jk" or the unicode joker
+// playing card rune.
+const Joker = Card(0xf << 8)
+
 // New creates a card for the rank and suit.
 func New(rank Rank, suit Suit) Card {
 	if Ace < rank || (suit != Spade && suit != Heart && suit != Diamond && suit != Club) {
@@ -347,6 +392,8 @@ func FromRune(r rune) Card {
 		return New(runeCardRank(r, UnicodeDiamondAce), Diamond)
 	case unicode.Is(rangeC, r):
 		return New(runeCardRank(r, UnicodeClubAce), Club)
+	case r == UnicodeJoker:
+		return Joker
 	}
 	return InvalidCard
 }
@@ -360,15 +407,27 @@ func FromString(s string) Card {
 	case 1:
 		return FromRune(v[0])
 	case 2:
+		if isJoker(v[0], v[1]) {
+			return Joker
+		}
 		return New(RankFromRune(v[0]), SuitFromRune(v[1]))
 	}
 	return InvalidCard
 }
 
-// FromIndex creates a card from a numerical index (0-51).
+// isJoker returns true when the runes are a [Joker] ("Jk").
+func isJoker(r0, r1 rune) bool {
+	return (r0 == 'J' || r0 == 'j') && (r1 == 'K' || r1 == 'k')
+}
+
+// FromIndex creates a card from a numerical index (0-51, or 52 for a
+// [Joker]).
 func FromIndex(i int) Card {
-	if i < 52 {
-		return New(Rank(i%13), Suit(1<<(i/13)))
+	switch {
+	case 0 <= i && i < 52:
+		return New(RankFromIndex(i%13), SuitFromIndex(i/13))
+	case i == 52:
+		return Joker
 	}
 	return InvalidCard
 }
@@ -380,6 +439,7 @@ func FromIndex(i int) Card {
 //   - a rank followed by a suit (ex: "Ah", "ks", "10s", "Tc", "8d", "6c")
 //   - a rank followed by a white or black unicode suit pip (ex: "J♤", "K♠")
 //   - unicode playing card runes (ex: "🃆", "🂣").
+//   - a [Joker] (ex: "Jk", "🃏").
 //
 // Returns a single slice of all cards from all strings in v.
 func Parse(v ...string) ([]Card, error) {
@@ -415,6 +475,9 @@ func Parse(v ...string) ([]Card, error) {
 				c, i = 'T', i+1
 			}
 			card := New(RankFromRune(c), SuitFromRune(r[i+1]))
+			if isJoker(c, r[i+1]) {
+				card = Joker
+			}
 			if card == InvalidCard {
 				return nil, &ParseError{
 					S:   s,
@@ -451,6 +514,9 @@ func (c Card) Rank() Rank {
 
 // RankByte returns the card rank byte.
 func (c Card) RankByte() byte {
+	if c == Joker {
+		return 'J'
+	}
 	return c.Rank().Byte()
 }
 
@@ -466,6 +532,9 @@ func (c Card) Suit() Suit {
 
 // SuitByte returns the card suit byte.
 func (c Card) SuitByte() byte {
+	if c == Joker {
+		return 'k'
+	}
 	return c.Suit().Byte()
 }
 
@@ -474,8 +543,11 @@ func (c Card) SuitIndex() int {
 	return c.Suit().Index()
 }
 
-// Index returns the card index.
+// Index returns the card index (0-51, or 52 for a [Joker]).
 func (c Card) Index() int {
+	if c == Joker {
+		return 52
+	}
 	return c.SuitIndex()*13 + c.RankIndex()
 }
 
@@ -486,8 +558,11 @@ func (c Card) AceRank() int {
 
 // Rune returns the card's unicode playing card rune.
 func (c Card) Rune() rune {
-	if c == InvalidCard {
+	switch c {
+	case InvalidCard:
 		return '0'
+	case Joker:
+		return UnicodeJoker
 	}
 	var v rune
 	switch c.Suit() {
@@ -515,8 +590,11 @@ func (c Card) Rune() rune {
 // KnightRune returns the card's unicode playing card rune, substituting
 // knights for [Jack]'s.
 func (c Card) KnightRune() rune {
-	if c == InvalidCard {
+	switch c {
+	case InvalidCard:
 		return '0'
+	case Joker:
+		return UnicodeJoker
 	}
 	var v rune
 	switch c.Suit() {
@@ -629,12 +707,20 @@ func (c Card) Format(f fmt.State, verb rune) {
 	case 'C':
 		buf = append(buf, string(c.KnightRune())...)
 	case 'n', 'N':
-		buf = append(buf, c.Rank().Name()...)
+		if c == Joker {
+			buf = append(buf, "Joker"...)
+		} else {
+			buf = append(buf, c.Rank().Name()...)
+		}
 		if verb == 'n' {
 			buf = bytes.ToLower(buf)
 		}
 	case 'p', 'P':
-		buf = append(buf, c.Rank().PluralName()...)
+		if c == Joker {
+			buf = append(buf, "Jokers"...)
+		} else {
+			buf = append(buf, c.Rank().PluralName()...)
+		}
 		if verb == 'p' {
 			buf = bytes.ToLower(buf)
 		}
@@ -731,6 +817,77 @@ func (v Formatter) Ranks() []Rank {
 	return ranks
 }
 
+// CardSorter is a card collation order, used to sort cards for display.
+//
+// The zero value sorts by rank, high to low, with [Ace]'s high, and then by
+// suit (see [Suit]), high to low.
+type CardSorter struct {
+	// SuitFirst sorts by suit before rank.
+	SuitFirst bool
+	// AceLow sorts [Ace]'s as low.
+	AceLow bool
+	// RankAscending sorts ranks low to high.
+	RankAscending bool
+	// SuitAscending sorts suits low to high.
+	SuitAscending bool
+	// Trump is a suit sorted before all other suits, regardless of the sort
+	// order.
+	Trump Suit
+}
+
+// Compare compares a and b, returning -1 when a sorts before b, 1 when a
+// sorts after b, or 0 when equal.
+func (s CardSorter) Compare(a, b Card) int {
+	if s.Trump != 0 {
+		switch x, y := a.Suit() == s.Trump, b.Suit() == s.Trump; {
+		case x && !y:
+			return -1
+		case !x && y:
+			return 1
+		}
+	}
+	ra, rb, sa, sb := s.rank(a), s.rank(b), s.suit(a), s.suit(b)
+	if s.SuitFirst {
+		ra, rb, sa, sb = sa, sb, ra, rb
+	}
+	switch {
+	case ra < rb:
+		return -1
+	case rb < ra:
+		return 1
+	case sa < sb:
+		return -1
+	case sb < sa:
+		return 1
+	}
+	return 0
+}
+
+// Sort sorts v.
+func (s CardSorter) Sort(v []Card) {
+	slices.SortFunc(v, s.Compare)
+}
+
+// rank returns the sort key of the card's rank.
+func (s CardSorter) rank(c Card) int {
+	r := int(c.Rank())
+	if s.AceLow {
+		r = c.AceRank()
+	}
+	if !s.RankAscending {
+		return -r
+	}
+	return r
+}
+
+// suit returns the sort key of the card's suit.
+func (s CardSorter) suit(c Card) int {
+	if !s.SuitAscending {
+		return -int(c.Suit())
+	}
+	return int(c.Suit())
+}
+
 // ParseError is a parse error.
 type ParseError struct {
 	S   string
@@ -763,6 +920,7 @@ const (
 	UnicodeDiamondWhite rune = '♢'
 	UnicodeClubBlack    rune = '♣'
 	UnicodeClubWhite    rune = '♧'
+	UnicodeJoker        rune = '🃏'
 )
 
 // Exclude is returns v excluding any specified cards.
@@ -816,7 +974,7 @@ func init() {
 	copy(a[14:28], h)
 	copy(a[28:42], d)
 	copy(a[42:56], c)
-	rangeA = newRangeTable(a...)
+	rangeA = newRangeTable(append(a, UnicodeJoker)...)
 }
 
 // range tables for unicode playing card runes.
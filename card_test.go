@@ -25,6 +25,7 @@ func TestParse(t *testing.T) {
 		{"As Ks", []Card{New(Ace, Spade), New(King, Spade)}, nil},
 		{" 🂬   a♣  🃚  🂸  td ", []Card{New(Jack, Spade), New(Ace, Club), New(Ten, Club), New(Eight, Heart), New(Ten, Diamond)}, nil},
 		{"10D 10C 10S 10h", []Card{New(Ten, Diamond), New(Ten, Club), New(10, Spade), New(10, Heart)}, nil},
+		{"Jk jK 🃏 Js", []Card{Joker, Joker, Joker, New(Jack, Spade)}, nil},
 	}
 	for i, test := range tests {
 		v, err := Parse(test.s)
@@ -110,6 +111,12 @@ func TestCardIndex(t *testing.T) {
 			i++
 		}
 	}
+	if c := FromIndex(52); c != Joker || c.Index() != 52 {
+		t.Errorf("expected %s with index 52, got: %s %d", Joker, c, c.Index())
+	}
+	if c := FromIndex(53); c != InvalidCard {
+		t.Errorf("expected %v, got: %v", InvalidCard, c)
+	}
 }
 
 func TestFromRune(t *testing.T) {
@@ -131,6 +138,7 @@ func TestFromRune(t *testing.T) {
 		{'🃔', "4c"},
 		{'🃃', "3d"},
 		{'🂲', "2h"},
+		{'🃏', "Jk"},
 	}
 	for i, test := range tests {
 		c := FromRune(test.r)
@@ -278,3 +286,50 @@ func TestFormatterRanks(t *testing.T) {
 		}
 	}
 }
+
+func TestCardSorter(t *testing.T) {
+	tests := []struct {
+		s   CardSorter
+		exp string
+	}{
+		{CardSorter{}, "[Ah Kd Ks 3h 2c]"},
+		{CardSorter{AceLow: true, SuitAscending: true}, "[Ks Kd 3h 2c Ah]"},
+		{CardSorter{RankAscending: true}, "[2c 3h Kd Ks Ah]"},
+		{CardSorter{SuitFirst: true, SuitAscending: true, RankAscending: true}, "[Ks 3h Ah Kd 2c]"},
+		{CardSorter{Trump: Heart}, "[Ah 3h Kd Ks 2c]"},
+	}
+	for i, test := range tests {
+		v := Must("Ah 2c Kd Ks 3h")
+		test.s.Sort(v)
+		if s := fmt.Sprintf("%v", v); s != test.exp {
+			t.Errorf("test %d expected %s, got: %s", i, test.exp, s)
+		}
+	}
+}
+
+func TestRanksSuits(t *testing.T) {
+	for i, r := range Ranks() {
+		if r.Index() != i || RankFromIndex(i) != r {
+			t.Errorf("test %d expected %d, got: %d", i, i, r.Index())
+		}
+	}
+	for i, s := range Suits() {
+		if s.Index() != i || SuitFromIndex(i) != s {
+			t.Errorf("test %d expected %d, got: %d", i, i, s.Index())
+		}
+	}
+	if RankFromIndex(13) != InvalidRank || RankFromIndex(-1) != InvalidRank {
+		t.Errorf("expected invalid rank")
+	}
+	if SuitFromIndex(4) != InvalidSuit || SuitFromIndex(-1) != InvalidSuit {
+		t.Errorf("expected invalid suit")
+	}
+	for i := range 52 {
+		if c := FromIndex(i); c.Index() != i {
+			t.Errorf("test %d expected %d, got: %d", i, i, c.Index())
+		}
+	}
+	if c := FromIndex(-1); c != InvalidCard {
+		t.Errorf("expected %v, got: %v", InvalidCard, c)
+	}
+}
@@ -6,6 +6,7 @@
 package cardrank
 
 import (
+	"fmt"
 	"sort"
 	"unicode"
 )
@@ -18,6 +19,14 @@ var (
 	cactus     RankFunc
 	cactusFast RankFunc
 	twoPlusTwo func([]Card) EvalRank
+	// twoPlusTwo5 is a TwoPlusTwo rank func for 5 cards.
+	twoPlusTwo5 RankFunc
+	// twoPlusTwoBoard returns a TwoPlusTwo rank func for pockets, with the
+	// board's lookup state precomputed.
+	twoPlusTwoBoard func([]Card) func([]Card) EvalRank
+
+	// backend is the active Cactus eval backend.
+	backend Backend
 
 	// descs are the registered type descriptions.
 	descs = make(map[Type]TypeDesc)
@@ -35,11 +44,120 @@ func Init() {
 	if RankCactus == nil {
 		switch {
 		case cactusFast != nil:
-			RankCactus = cactusFast
+			RankCactus, backend = cactusFast, BackendCactusFast
 		case cactus != nil:
-			RankCactus = cactus
+			RankCactus, backend = cactus, BackendCactus
+		}
+		if twoPlusTwo != nil {
+			backend = BackendHybrid
+		}
+	}
+}
+
+// Backend is a Cactus eval backend.
+type Backend uint8
+
+// Backends.
+const (
+	// BackendHybrid uses [RankCactus] for 5 and 6 cards, and TwoPlusTwo for 7
+	// cards. Default when the TwoPlusTwo lookup table is available.
+	BackendHybrid Backend = iota
+	// BackendCactus uses [Cactus] for all cards.
+	BackendCactus
+	// BackendCactusFast uses [CactusFast] for all cards.
+	BackendCactusFast
+	// BackendTwoPlusTwo uses TwoPlusTwo for all cards, evaluating 5, 6 and 7
+	// cards natively.
+	BackendTwoPlusTwo
+)
+
+// Name returns the backend name.
+func (b Backend) Name() string {
+	switch b {
+	case BackendHybrid:
+		return "Hybrid"
+	case BackendCactus:
+		return "Cactus"
+	case BackendCactusFast:
+		return "CactusFast"
+	case BackendTwoPlusTwo:
+		return "TwoPlusTwo"
+	}
+	return ""
+}
+
+// Available returns true when the backend's rank funcs were included in the
+// build (see [build tags]).
+//
+// [build tags]: https://pkg.go.dev/github.com/cardrank/cardrank#readme-build-tags
+func (b Backend) Available() bool {
+	switch b {
+	case BackendHybrid:
+		return twoPlusTwo != nil && (cactusFast != nil || cactus != nil)
+	case BackendCactus:
+		return cactus != nil
+	case BackendCactusFast:
+		return cactusFast != nil
+	case BackendTwoPlusTwo:
+		return twoPlusTwo != nil
+	}
+	return false
+}
+
+// Backends returns the available backends.
+func Backends() []Backend {
+	var v []Backend
+	for _, b := range []Backend{BackendHybrid, BackendCactus, BackendCactusFast, BackendTwoPlusTwo} {
+		if b.Available() {
+			v = append(v, b)
 		}
 	}
+	return v
+}
+
+// ActiveBackend returns the active Cactus eval backend.
+func ActiveBackend() Backend {
+	return backend
+}
+
+// SetBackend sets the Cactus eval backend, changing [RankCactus] and
+// recreating the eval funcs of registered types. Allows trading memory for
+// speed at runtime, independent of build tags.
+//
+// SetBackend is not synchronized, and must be called before any evals (for
+// example, during a program's initialization, the same as [RegisterType]).
+// Eval funcs previously created with [NewHybridEvalWith], [NewCactusEvalWith]
+// or similar retain the backend active when they were created.
+func SetBackend(b Backend) error {
+	var f RankFunc
+	switch b {
+	case BackendHybrid:
+		if f = cactusFast; f == nil {
+			f = cactus
+		}
+	case BackendCactus:
+		f = cactus
+	case BackendCactusFast:
+		f = cactusFast
+	case BackendTwoPlusTwo:
+		f = twoPlusTwo5
+	default:
+		return ErrInvalidBackend
+	}
+	if f == nil || !b.Available() {
+		return fmt.Errorf("%s: %w", b.Name(), ErrUnavailableBackend)
+	}
+	RankCactus, backend = f, b
+	for typ, desc := range descs {
+		calcs[typ] = desc.Eval.NewWith(desc.board, false, desc.Low, desc.qualifier)
+		evals[typ] = desc.Eval.NewWith(desc.board, true, desc.Low, desc.qualifier)
+	}
+	return nil
+}
+
+// hybridTwoPlusTwo returns true when 7 cards are evaluated using TwoPlusTwo.
+func hybridTwoPlusTwo() bool {
+	return twoPlusTwo != nil && (backend == BackendHybrid || backend == BackendTwoPlusTwo)
 }
 
 // RegisterDefaultTypes registers default types.
@@ -66,10 +184,17 @@ func RegisterType(desc TypeDesc) error {
 			return ErrInvalidId
 		}
 	}
+	// check qualifier, using the default when not set
+	switch {
+	case !desc.qualified:
+		desc.qualifier, desc.qualified = Eight, true
+	case desc.qualifier < Five:
+		return fmt.Errorf("%w: %s qualifier", ErrInvalidType, desc.qualifier.Name())
+	}
 	desc.Num = len(descs)
 	descs[desc.Type] = desc
-	calcs[desc.Type] = desc.Eval.New(desc.board, false, desc.Low)
-	evals[desc.Type] = desc.Eval.New(desc.board, true, desc.Low)
+	calcs[desc.Type] = desc.Eval.NewWith(desc.board, false, desc.Low, desc.qualifier)
+	evals[desc.Type] = desc.Eval.NewWith(desc.board, true, desc.Low, desc.qualifier)
 	return nil
 }
 
@@ -107,6 +232,34 @@ const (
 	ErrInvalidCard Error = "invalid card"
 	// ErrInvalidType is the invalid type error.
 	ErrInvalidType Error = "invalid type"
+	// ErrInvalidBackend is the invalid backend error.
+	ErrInvalidBackend Error = "invalid backend"
+	// ErrUnavailableBackend is the unavailable backend error.
+	ErrUnavailableBackend Error = "unavailable backend"
+	// ErrContextCancelled is the context cancelled error.
+	ErrContextCancelled Error = "context cancelled"
+	// ErrInsufficientCards is the insufficient cards error.
+	ErrInsufficientCards Error = "insufficient cards"
+	// ErrUnsupportedType is the unsupported type error.
+	ErrUnsupportedType Error = "unsupported type"
+	// ErrInvalidEval is the invalid eval error.
+	ErrInvalidEval Error = "invalid eval"
+	// ErrInvalidPosition is the invalid position error.
+	ErrInvalidPosition Error = "invalid position"
+	// ErrInvalidStreet is the invalid street error.
+	ErrInvalidStreet Error = "invalid street"
+	// ErrInvalidHashKey is the invalid hash key error.
+	ErrInvalidHashKey Error = "invalid hash key"
+	// ErrInvalidDealId is the invalid deal id error.
+	ErrInvalidDealId Error = "invalid deal id"
+	// ErrPendingDiscards is the pending discards error.
+	ErrPendingDiscards Error = "pending discards"
+	// ErrInvalidShuffler is the invalid shuffler error.
+	ErrInvalidShuffler Error = "invalid shuffler"
+	// ErrInvalidResult is the invalid result error.
+	ErrInvalidResult Error = "invalid result"
+	// ErrInvalidCount is the invalid count error.
+	ErrInvalidCount Error = "invalid count"
 )
 
 // primes are the first 13 prime numbers (one per card rank).
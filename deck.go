@@ -2,7 +2,12 @@ package cardrank
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"iter"
+	"math/rand"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -34,6 +39,9 @@ const (
 	// DeckLeduc is a deck of 6 playing cards, a [King], [Queen], and a [Jack]
 	// of the [Spade] and [Heart] suits (see [Leduc]).
 	DeckLeduc = DeckType(^uint8(0) - 2)
+	// DeckJoker is a standard deck of 52 playing cards with an additional
+	// [Joker], for a total of 53 cards (see [California]).
+	DeckJoker = DeckType(^uint8(0) - 3)
 )
 
 // Name returns the deck name.
@@ -53,6 +61,8 @@ func (typ DeckType) Name() string {
 		return "Kuhn"
 	case DeckLeduc:
 		return "Leduc"
+	case DeckJoker:
+		return "Joker"
 	}
 	return ""
 }
@@ -62,7 +72,7 @@ func (typ DeckType) Desc(short bool) string {
 	switch french := typ == DeckFrench; {
 	case french && short:
 		return ""
-	case french, typ == DeckKuhn, typ == DeckLeduc:
+	case french, typ == DeckKuhn, typ == DeckLeduc, typ == DeckJoker:
 		return typ.Name()
 	}
 	return typ.Name() + " (" + strconv.Itoa(int(typ+2)) + "+)"
@@ -99,7 +109,7 @@ func (typ DeckType) Unshuffled() []Card {
 	case DeckFrench, DeckShort, DeckManila, DeckSpanish, DeckRoyal:
 		v := make([]Card, 4*(Ace-Rank(typ)+1))
 		var i int
-		for _, s := range []Suit{Spade, Heart, Diamond, Club} {
+		for _, s := range Suits() {
 			for r := Rank(typ); r <= Ace; r++ {
 				v[i] = New(r, s)
 				i++
@@ -115,6 +125,8 @@ func (typ DeckType) Unshuffled() []Card {
 			New(King, Spade), New(Queen, Spade), New(Jack, Spade),
 			New(King, Heart), New(Queen, Heart), New(Jack, Heart),
 		}
+	case DeckJoker:
+		return append(DeckFrench.Unshuffled(), Joker)
 	}
 	return nil
 }
@@ -128,6 +140,7 @@ var (
 	deckRoyal   []Card
 	deckKuhn    []Card
 	deckLeduc   []Card
+	deckJoker   []Card
 )
 
 func init() {
@@ -138,6 +151,7 @@ func init() {
 	deckRoyal = DeckRoyal.Unshuffled()
 	deckKuhn = DeckKuhn.Unshuffled()
 	deckLeduc = DeckLeduc.Unshuffled()
+	deckJoker = DeckJoker.Unshuffled()
 }
 
 // v returns the cards for the type.
@@ -157,10 +171,23 @@ func (typ DeckType) v() []Card {
 		return deckKuhn
 	case DeckLeduc:
 		return deckLeduc
+	case DeckJoker:
+		return deckJoker
 	}
 	return nil
 }
 
+// All returns an iterator over the type's unshuffled cards.
+func (typ DeckType) All() iter.Seq[Card] {
+	return func(yield func(Card) bool) {
+		for _, c := range typ.v() {
+			if !yield(c) {
+				return
+			}
+		}
+	}
+}
+
 // Shoe creates a card shoe composed of count number of decks of unshuffled
 // cards.
 func (typ DeckType) Shoe(count int) *Deck {
@@ -193,6 +220,230 @@ func (typ DeckType) Exclude(ex ...[]Card) []Card {
 	return Exclude(typ.v(), ex...)
 }
 
+// HandsOfRank calls yield for each 5 card combination of the deck's cards
+// having the fixed eval rank category (see [EvalRank.Fixed]), as ranked by
+// [RankCactus], stopping when yield returns false. The yielded cards are a
+// copy. Does not include a [Joker].
+//
+// Example:
+//
+//	var count int
+//	cardrank.DeckFrench.HandsOfRank(cardrank.FullHouse, func([]cardrank.Card) bool {
+//		count++
+//		return true
+//	})
+//	fmt.Println(count) // 3744
+func (typ DeckType) HandsOfRank(category EvalRank, yield func([]Card) bool) {
+	category = category.Fixed()
+	g, v := NewCombinGen(Exclude(typ.v(), []Card{Joker}), 5)
+	for g.Next() {
+		if RankCactus(v[0], v[1], v[2], v[3], v[4]).Fixed() != category {
+			continue
+		}
+		if !yield(slices.Clone(v)) {
+			return
+		}
+	}
+}
+
+// RandomHand returns a random hand of n cards from the deck's cards, excluding
+// the board and any excluded cards, satisfying every constraint, such as when
+// generating scenarios for training or simulation. Hands are generated using
+// the shuffler until a hand satisfies the constraints. Returns false when no
+// hand satisfies the constraints after the number of tries.
+//
+// Example:
+//
+//	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+//	hand, ok := cardrank.DeckFrench.RandomHand(r, 4, nil, 10000, nil, cardrank.HandDoubleSuited())
+func (typ DeckType) RandomHand(shuffler Shuffler, n int, board []Card, tries int, ex []Card, constraints ...HandConstraint) ([]Card, bool) {
+	v := typ.Exclude(board, ex)
+	if n < 0 || len(v) < n {
+		return nil, false
+	}
+	for range tries {
+		shuffler.Shuffle(len(v), func(i, j int) {
+			v[i], v[j] = v[j], v[i]
+		})
+		if satisfies(v[:n], board, constraints) {
+			return slices.Clone(v[:n]), true
+		}
+	}
+	return nil, false
+}
+
+// satisfies returns true when the hand and board satisfy every constraint.
+func satisfies(hand, board []Card, constraints []HandConstraint) bool {
+	for _, f := range constraints {
+		if !f(hand, board) {
+			return false
+		}
+	}
+	return true
+}
+
+// HandConstraint is a constraint for a random hand and board. See
+// [DeckType.RandomHand].
+type HandConstraint func(hand, board []Card) bool
+
+// HandPair is a hand constraint for a hand containing a pair of the minimum
+// rank or better.
+func HandPair(minimum Rank) HandConstraint {
+	return func(hand, _ []Card) bool {
+		var ranks [13]int
+		for _, c := range hand {
+			if r := c.Rank(); minimum <= r && r <= Ace {
+				if ranks[r]++; ranks[r] == 2 {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// HandRanks is a hand constraint for a hand having only cards between the
+// low and high ranks, inclusive.
+func HandRanks(low, high Rank) HandConstraint {
+	return func(hand, _ []Card) bool {
+		for _, c := range hand {
+			if r := c.Rank(); r < low || high < r {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// HandSuited is a hand constraint for a hand having only cards of the same
+// suit.
+func HandSuited() HandConstraint {
+	return func(hand, _ []Card) bool {
+		for i := 1; i < len(hand); i++ {
+			if hand[i].Suit() != hand[0].Suit() {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// HandDoubleSuited is a hand constraint for a hand having 2 or more cards of
+// exactly 2 suits, such as a double-suited [Omaha] pocket.
+func HandDoubleSuited() HandConstraint {
+	return func(hand, _ []Card) bool {
+		var suits [4]int
+		for _, c := range hand {
+			if c != Joker {
+				suits[c.SuitIndex()]++
+			}
+		}
+		var n int
+		for _, count := range suits {
+			if 2 <= count {
+				n++
+			}
+		}
+		return n == 2
+	}
+}
+
+// HandFlushDraw is a hand constraint for a hand making a flush draw on the
+// board, having exactly 4 cards of a suit in the hand and board, with at
+// least 1 of the suit from the hand.
+func HandFlushDraw() HandConstraint {
+	return func(hand, board []Card) bool {
+		var suits, pocket [4]int
+		for _, c := range hand {
+			if c != Joker {
+				suits[c.SuitIndex()]++
+				pocket[c.SuitIndex()]++
+			}
+		}
+		for _, c := range board {
+			if c != Joker {
+				suits[c.SuitIndex()]++
+			}
+		}
+		for i, count := range suits {
+			if 5 <= count {
+				return false
+			}
+			if count == 4 && pocket[i] != 0 {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Strip returns a stripped deck of the deck type, missing the specified cards.
+func (typ DeckType) Strip(missing ...Card) StrippedDeck {
+	return StrippedDeck{
+		Type:    typ,
+		Missing: missing,
+	}
+}
+
+// StrippedDeck is a deck type with specific cards removed, such as a deck
+// verified to be missing a damaged card. See [WithDeadCards] to calculate odds
+// with a stripped deck.
+type StrippedDeck struct {
+	// Type is the deck type.
+	Type DeckType
+	// Missing are the cards removed from the deck.
+	Missing []Card
+}
+
+// Unshuffled returns a set of the stripped deck's unshuffled cards.
+func (d StrippedDeck) Unshuffled() []Card {
+	return Exclude(d.Type.v(), d.Missing)
+}
+
+// All returns an iterator over the stripped deck's unshuffled cards.
+func (d StrippedDeck) All() iter.Seq[Card] {
+	return func(yield func(Card) bool) {
+		for _, c := range d.Unshuffled() {
+			if !yield(c) {
+				return
+			}
+		}
+	}
+}
+
+// Exclude returns a set of the stripped deck's unshuffled cards excluding any
+// supplied cards.
+func (d StrippedDeck) Exclude(ex ...[]Card) []Card {
+	return Exclude(d.Type.v(), append(ex, d.Missing)...)
+}
+
+// Shoe creates a card shoe composed of count number of stripped decks of
+// unshuffled cards.
+func (d StrippedDeck) Shoe(count int) *Deck {
+	v := d.Unshuffled()
+	n := len(v)
+	deck := &Deck{
+		v: make([]Card, n*count),
+		l: count * n,
+	}
+	for i := range count {
+		copy(deck.v[i*n:], v)
+	}
+	return deck
+}
+
+// New returns a new deck.
+func (d StrippedDeck) New() *Deck {
+	return d.Shoe(1)
+}
+
+// Shuffle returns a new deck, shuffled by the shuffler.
+func (d StrippedDeck) Shuffle(shuffler Shuffler, shuffles int) *Deck {
+	deck := d.Shoe(1)
+	deck.Shuffle(shuffler, shuffles)
+	return deck
+}
+
 // Deck is a set of playing cards.
 type Deck struct {
 	i int
@@ -244,6 +495,18 @@ func (d *Deck) All() []Card {
 	return v
 }
 
+// Cards returns an iterator over all cards in the deck, without copying or
+// advancing.
+func (d *Deck) Cards() iter.Seq[Card] {
+	return func(yield func(Card) bool) {
+		for _, c := range d.v[:min(d.l, len(d.v))] {
+			if !yield(c) {
+				return
+			}
+		}
+	}
+}
+
 // Reset resets the deck.
 func (d *Deck) Reset() {
 	d.i = 0
@@ -261,6 +524,36 @@ func (d *Deck) Draw(count int) []Card {
 	return cards
 }
 
+// Peek returns a copy of the next count cards from the top (front) of the
+// deck, without advancing.
+func (d *Deck) Peek(count int) []Card {
+	if count < 0 {
+		return nil
+	}
+	l := min(d.i+count, d.l)
+	if l <= d.i {
+		return nil
+	}
+	v := make([]Card, l-d.i)
+	copy(v, d.v[d.i:l])
+	return v
+}
+
+// DrawAt draws the card at index of the remaining cards in the deck, where 0
+// is the top (front) of the deck. The cards above the drawn card are moved
+// down, preserving the order of the remaining cards. Returns [InvalidCard]
+// when index is out of range.
+func (d *Deck) DrawAt(index int) Card {
+	if index < 0 || d.l <= d.i+index {
+		return InvalidCard
+	}
+	c := d.v[d.i+index]
+	copy(d.v[d.i+1:d.i+index+1], d.v[d.i:d.i+index])
+	d.v[d.i] = c
+	d.i++
+	return c
+}
+
 // Shuffle shuffles the deck's cards using the shuffler.
 func (d *Deck) Shuffle(shuffler Shuffler, shuffles int) {
 	for range shuffles {
@@ -270,6 +563,38 @@ func (d *Deck) Shuffle(shuffler Shuffler, shuffles int) {
 	}
 }
 
+// RemainingByRank returns the count of remaining cards in the deck for each
+// rank, indexed by rank index (0-12 for [Two]-[Ace]).
+func (d *Deck) RemainingByRank() [13]int {
+	ranks, _ := countCards(d.v[min(d.i, d.l, len(d.v)):min(d.l, len(d.v))])
+	return ranks
+}
+
+// RemainingBySuit returns the count of remaining cards in the deck for each
+// suit, indexed by suit index (0-3 for [Spade], [Heart], [Diamond], [Club]).
+func (d *Deck) RemainingBySuit() [4]int {
+	_, suits := countCards(d.v[min(d.i, d.l, len(d.v)):min(d.l, len(d.v))])
+	return suits
+}
+
+// countCards returns the count of cards in v for each rank and suit.
+func countCards(v []Card) ([13]int, [4]int) {
+	var ranks [13]int
+	var suits [4]int
+	for _, c := range v {
+		if c == Joker {
+			continue
+		}
+		if i := c.RankIndex(); 0 <= i && i < 13 {
+			ranks[i]++
+		}
+		if i := c.SuitIndex(); 0 <= i && i < 4 {
+			suits[i]++
+		}
+	}
+	return ranks, suits
+}
+
 // Dealer maintains deal state for a type, streets, deck, positions, runs,
 // results, and wins. Use as a street and run iterator for a [Type]. See usage
 // details in the [package example].
@@ -282,28 +607,125 @@ type Dealer struct {
 	Active  map[int]bool
 	Runs    []*Run
 	Results []*Result
+	// Origin is the position dealt the first pocket card.
+	Origin int
+	// Reverse deals pocket cards in reverse (counterclockwise) position
+	// order.
+	Reverse bool
+	// Profile is the description profile used for results and summaries.
+	Profile *DescProfile
 	runs    int
 	st      int
 	s       int
 	r       int
 	e       int
+	muck    []Card
+	mark    runMark
+	base    *Run
+	audit   []AuditEntry
+	deltas  map[byte]map[int]int
+	posts   []Post
+	deal    *DealId
+	// deactivated is the street each inactive position was deactivated on.
+	deactivated map[int]int
+	events      func(Event)
+	auto        *autoCalc
+	hi          *Odds
+	lo          *Odds
+	err         error
+}
+
+// autoCalc are the odds calculation settings used after each street is dealt.
+type autoCalc struct {
+	ctx    context.Context
+	folded bool
+	opts   []CalcOption
+}
+
+// runMark is the count of cards in a run, and the dealer state, prior to
+// dealing a street, and whether the runs were changed after dealing the
+// street.
+type runMark struct {
+	ok      bool
+	changed bool
+	pockets []int
+	hi      int
+	lo      int
+	discard int
+	street  int
+	run     int
+	s       int
+	r       int
+	deck    int
+	muck    int
+	audit   int
+}
+
+// DealerOption is a dealer option.
+type DealerOption func(*Dealer)
+
+// WithAutoCalc is a dealer option to calculate the run odds after each
+// street is dealt, bounded by the context, including whether or not to
+// include folded positions. The calculated odds are available via
+// [Dealer.Odds]. See [Dealer.CalcErr].
+func WithAutoCalc(ctx context.Context, folded bool, opts ...CalcOption) DealerOption {
+	return func(d *Dealer) {
+		d.auto = &autoCalc{
+			ctx:    ctx,
+			folded: folded,
+			opts:   opts,
+		}
+	}
+}
+
+// WithDescProfile is a dealer option to set the description profile used for
+// results and summaries. See [DescProfile].
+func WithDescProfile(profile *DescProfile) DealerOption {
+	return func(d *Dealer) {
+		d.Profile = profile
+	}
+}
+
+// WithEvents is a dealer option to set a func called for each dealer event.
+func WithEvents(f func(Event)) DealerOption {
+	return func(d *Dealer) {
+		d.events = f
+	}
 }
 
 // NewDealer creates a new dealer for a provided deck and pocket count.
-func NewDealer(desc TypeDesc, deck *Deck, count int) *Dealer {
+func NewDealer(desc TypeDesc, deck *Deck, count int, opts ...DealerOption) *Dealer {
 	d := &Dealer{
 		TypeDesc: desc,
 		Deck:     deck,
 		Count:    count,
 	}
+	for _, o := range opts {
+		o(d)
+	}
 	d.init()
 	return d
 }
 
 // NewShuffledDealer creates a new deck and dealer, shuffling the deck multiple
 // times and returning the dealer with the created deck and pocket count.
-func NewShuffledDealer(desc TypeDesc, shuffler Shuffler, shuffles, count int) *Dealer {
-	return NewDealer(desc, desc.Deck.Shuffle(shuffler, shuffles), count)
+func NewShuffledDealer(desc TypeDesc, shuffler Shuffler, shuffles, count int, opts ...DealerOption) *Dealer {
+	return NewDealer(desc, desc.Deck.Shuffle(shuffler, shuffles), count, opts...)
+}
+
+// NewSeededDealer creates a new deck and dealer, shuffling the deck multiple
+// times using a math/rand source seeded with seed. The deal can be reproduced
+// from the dealer's deal id (see [Dealer.DealId]).
+func NewSeededDealer(desc TypeDesc, seed int64, shuffles, count int, opts ...DealerOption) *Dealer {
+	d := NewShuffledDealer(desc, rand.New(rand.NewSource(seed)), shuffles, count, opts...)
+	d.deal = &DealId{
+		Type:     desc.Type,
+		Deck:     desc.Deck,
+		Seed:     seed,
+		Shuffles: shuffles,
+		Count:    count,
+	}
+	return d
 }
 
 // init inits the street position and active positions.
@@ -316,6 +738,14 @@ func (d *Dealer) init() {
 	d.s = -1
 	d.r = -1
 	d.e = -1
+	d.muck = nil
+	d.mark = runMark{}
+	d.base = nil
+	d.audit = nil
+	d.deltas = nil
+	d.posts = nil
+	d.deactivated = nil
+	d.hi, d.lo, d.err = nil, nil, nil
 	for i := range d.Count {
 		d.Active[i] = true
 	}
@@ -335,6 +765,15 @@ func (d *Dealer) Format(f fmt.State, verb rune) {
 	_, _ = f.Write(buf)
 }
 
+// DealId returns the deal id that reproduces the dealer's deal. Returns false
+// when the dealer was not created using [NewSeededDealer].
+func (d *Dealer) DealId() (DealId, bool) {
+	if d.deal == nil {
+		return DealId{}, false
+	}
+	return *d.deal, true
+}
+
 // Inactive returns the inactive positions.
 func (d *Dealer) Inactive() []int {
 	var v []int
@@ -347,15 +786,72 @@ func (d *Dealer) Inactive() []int {
 }
 
 // Deactivate deactivates positions, which will not be dealt further cards and
-// will not be included during eval.
+// will not be included during eval. See [Dealer.DeactivateErr].
 func (d *Dealer) Deactivate(positions ...int) bool {
+	return d.DeactivateErr(positions...) == nil
+}
+
+// DeactivateErr deactivates positions, which will not be dealt further cards
+// and will not be included during eval. Positions that are already inactive
+// are ignored. Returns [ErrInvalidPosition] for an invalid position, or
+// [ErrInvalidStreet] when a run other than the first has been dealt. No
+// positions are deactivated when an error is returned.
+func (d *Dealer) DeactivateErr(positions ...int) error {
 	if d.r != -1 && d.r != 0 {
-		return false
+		return fmt.Errorf("%w: run %d already dealt", ErrInvalidStreet, d.r)
+	}
+	for _, position := range positions {
+		if position < 0 || d.Count <= position {
+			return fmt.Errorf("%w: %d", ErrInvalidPosition, position)
+		}
 	}
 	for _, position := range positions {
+		if !d.Active[position] {
+			continue
+		}
 		delete(d.Active, position)
+		if d.deactivated == nil {
+			d.deactivated = make(map[int]int)
+		}
+		d.deactivated[position] = d.s
+		d.event(EventDeactivate, position)
+	}
+	return nil
+}
+
+// Reactivate reactivates a position deactivated on the current street, such
+// as when correcting an accidental fold. Returns [ErrInvalidPosition] for an
+// invalid or active position, or [ErrInvalidStreet] when a run other than the
+// first has been dealt, the position was deactivated on a prior street, or
+// results have been evaluated.
+func (d *Dealer) Reactivate(position int) error {
+	s, ok := d.deactivated[position]
+	switch {
+	case d.r != -1 && d.r != 0:
+		return fmt.Errorf("%w: run %d already dealt", ErrInvalidStreet, d.r)
+	case position < 0 || d.Count <= position, d.Active[position]:
+		return fmt.Errorf("%w: %d", ErrInvalidPosition, position)
+	case !ok || s != d.s:
+		return fmt.Errorf("%w: position %d not deactivated on the current street", ErrInvalidStreet, position)
+	case d.Results != nil:
+		return fmt.Errorf("%w: results already evaluated", ErrInvalidStreet)
+	}
+	delete(d.deactivated, position)
+	d.Active[position] = true
+	d.event(EventReactivate, position)
+	return nil
+}
+
+// event calls the dealer's event func (see [WithEvents]).
+func (d *Dealer) event(kind EventKind, position int) {
+	if d.events != nil {
+		d.events(Event{
+			Kind:     kind,
+			Street:   d.s,
+			Run:      d.r,
+			Position: position,
+		})
 	}
-	return true
 }
 
 // Id returns the current street id.
@@ -412,7 +908,14 @@ func (d *Dealer) HasCalc() bool {
 		if p != 2 && d.s == 0 {
 			return false
 		}
-		return b != 0 && len(d.Runs[d.r].Pockets[0]) >= p
+		// odds are only calculated for pockets of the same length
+		pockets := d.Runs[d.r].Pockets
+		for i := range pockets {
+			if d.Active[i] && len(pockets[i]) != len(pockets[0]) {
+				return false
+			}
+		}
+		return b != 0 && len(pockets[0]) >= p
 	}
 	return false
 }
@@ -425,6 +928,51 @@ func (d *Dealer) Pocket() int {
 	return 0
 }
 
+// PocketFor returns the number of pocket cards to be dealt to the position on
+// the current street, including the position's pocket delta (see
+// [Dealer.SetPocketDelta]).
+func (d *Dealer) PocketFor(position int) int {
+	if 0 <= d.s && d.s < len(d.Streets) {
+		return max(d.Streets[d.s].Pocket+d.deltas[d.Streets[d.s].Id][position], 0)
+	}
+	return 0
+}
+
+// SetPocketDelta sets the position's adjustment to the number of pocket cards
+// dealt on the street with the id, such as +1 to deal an additional card
+// (replacing a lost card), or -1 to deal one fewer card. Deltas are cleared
+// on [Dealer.Reset].
+//
+// Returns [ErrInvalidPosition] for an invalid position, or [ErrInvalidStreet]
+// when there is no street with the id, the street has already been dealt, or
+// when the delta would deal fewer than 0 cards. Positions with a differing
+// number of pocket cards are each evaluated using their own pocket, and
+// [Dealer.HasCalc] returns false.
+func (d *Dealer) SetPocketDelta(id byte, position, delta int) error {
+	if position < 0 || d.Count <= position {
+		return fmt.Errorf("%w: %d", ErrInvalidPosition, position)
+	}
+	i := slices.IndexFunc(d.Streets, func(street StreetDesc) bool {
+		return street.Id == id
+	})
+	switch {
+	case i == -1:
+		return fmt.Errorf("%w: no street %c", ErrInvalidStreet, id)
+	case i <= d.s:
+		return fmt.Errorf("%w: street %c already dealt", ErrInvalidStreet, id)
+	case d.Streets[i].Pocket+delta < 0:
+		return fmt.Errorf("%w: street %c pocket delta %d", ErrInvalidStreet, id, delta)
+	}
+	if d.deltas == nil {
+		d.deltas = make(map[byte]map[int]int)
+	}
+	if d.deltas[id] == nil {
+		d.deltas[id] = make(map[int]int)
+	}
+	d.deltas[id][position] = delta
+	return nil
+}
+
 // PocketUp returns the number of pocket cards to be turned up on the current
 // street.
 func (d *Dealer) PocketUp() int {
@@ -452,6 +1000,15 @@ func (d *Dealer) PocketDraw() int {
 	return 0
 }
 
+// PocketKeep returns the number of pocket cards each position keeps after
+// discarding on the current street.
+func (d *Dealer) PocketKeep() int {
+	if 0 <= d.s && d.s < len(d.Streets) {
+		return d.Streets[d.s].PocketKeep
+	}
+	return 0
+}
+
 // Board returns the number of board cards to be dealt on the current street.
 func (d *Dealer) Board() int {
 	if 0 <= d.s && d.s < len(d.Streets) {
@@ -482,6 +1039,92 @@ func (d *Dealer) Discarded() []Card {
 	return nil
 }
 
+// DiscardedOn returns the cards discarded on the street with the id for the
+// current run.
+func (d *Dealer) DiscardedOn(id byte) []Card {
+	if 0 <= d.r && d.r < d.runs {
+		return d.Runs[d.r].DiscardedOn(id)
+	}
+	return nil
+}
+
+// PendingDiscards returns the active positions that have not yet discarded
+// their pocket down to the keep count on the current street (see
+// [Dealer.DiscardPocket]).
+func (d *Dealer) PendingDiscards() []int {
+	keep := d.PocketKeep()
+	if keep == 0 || d.r < 0 || d.runs <= d.r {
+		return nil
+	}
+	var v []int
+	for i, pocket := range d.Runs[d.r].Pockets {
+		if d.Active[i] && keep < len(pocket) {
+			v = append(v, i)
+		}
+	}
+	return v
+}
+
+// Pending returns true when there are pending pocket discards on the current
+// street (see [Dealer.PendingDiscards]).
+func (d *Dealer) Pending() bool {
+	return len(d.PendingDiscards()) != 0
+}
+
+// DiscardPocket discards the cards from the position's pocket on the current
+// street, leaving the position with the street's keep count of pocket cards
+// (see [StreetDesc.PocketKeep]). The discarded cards are recorded as the
+// position's pocket discards (see [Dealer.PocketDiscarded]), separate from
+// the street's discards. [Dealer.Next] will not advance until all active
+// positions have discarded.
+//
+// Returns [ErrInvalidPosition] for an invalid or inactive position,
+// [ErrInvalidStreet] when the current street does not have a keep count or
+// the runs have changed, or [ErrInvalidCard] when the cards are not in the
+// position's pocket or would not leave the keep count.
+func (d *Dealer) DiscardPocket(position int, cards ...Card) error {
+	keep := d.PocketKeep()
+	switch {
+	case position < 0 || d.Count <= position || !d.Active[position]:
+		return fmt.Errorf("%w: %d", ErrInvalidPosition, position)
+	case keep == 0:
+		return fmt.Errorf("%w: street does not discard pockets", ErrInvalidStreet)
+	case d.base != nil || d.Results != nil:
+		return fmt.Errorf("%w: runs already changed", ErrInvalidStreet)
+	}
+	run := d.Runs[d.r]
+	pocket := run.Pockets[position]
+	if len(pocket)-len(cards) != keep {
+		return fmt.Errorf("%w: must discard %d cards", ErrInvalidCard, max(len(pocket)-keep, 0))
+	}
+	v := slices.Clone(pocket)
+	for _, c := range cards {
+		i := slices.Index(v, c)
+		if i == -1 {
+			return fmt.Errorf("%w: %s not in pocket", ErrInvalidCard, c)
+		}
+		v = slices.Delete(v, i, i+1)
+	}
+	run.Pockets[position] = v
+	if run.pockets == nil {
+		run.pockets = make(map[int][]Card)
+	}
+	run.pockets[position] = append(run.pockets[position], cards...)
+	// pockets no longer match the mark
+	d.mark.ok = false
+	d.event(EventDiscard, position)
+	return nil
+}
+
+// PocketDiscarded returns the cards discarded from the position's pocket for
+// the current run (see [Dealer.DiscardPocket]).
+func (d *Dealer) PocketDiscarded(position int) []Card {
+	if 0 <= d.r && d.r < d.runs {
+		return d.Runs[d.r].PocketDiscarded(position)
+	}
+	return nil
+}
+
 // Run returns the current run.
 func (d *Dealer) Run() (int, *Run) {
 	if 0 <= d.r && d.r < d.runs {
@@ -491,8 +1134,50 @@ func (d *Dealer) Run() (int, *Run) {
 }
 
 // Calc calculates the run odds, including whether or not to include folded
-// positions.
+// positions. See [Dealer.CalcErr].
 func (d *Dealer) Calc(ctx context.Context, folded bool, opts ...CalcOption) (*Odds, *Odds, bool) {
+	hi, lo, err := d.CalcErr(ctx, folded, opts...)
+	return hi, lo, err == nil
+}
+
+// CalcErr calculates the run odds, including whether or not to include folded
+// positions. When not including folded positions, the upcards of folded
+// [Stud] positions are dead cards (see [Dealer.Upcards]). Returns
+// [ErrInsufficientCards] when no run has been dealt, or the errors returned
+// by [OddsCalc.CalcErr].
+func (d *Dealer) CalcErr(ctx context.Context, folded bool, opts ...CalcOption) (*Odds, *Odds, error) {
+	if 0 <= d.r && d.r < d.runs {
+		return NewOddsCalc(
+			d.Type,
+			append(
+				append(d.deadUpcards(folded), opts...),
+				WithRuns(d.Runs[:d.r+1]),
+				WithActive(d.Active, folded),
+			)...,
+		).CalcErr(ctx)
+	}
+	return nil, nil, ErrInsufficientCards
+}
+
+// deadUpcards returns dead card options for the upcards of the inactive
+// positions when not including folded positions, excluding the exposed cards
+// of folded [Stud] positions from the unused cards (see [WithDeadCards]).
+func (d *Dealer) deadUpcards(folded bool) []CalcOption {
+	if folded || !d.Type.Up() {
+		return nil
+	}
+	var opts []CalcOption
+	for _, i := range d.Inactive() {
+		if v := d.Upcards(i); len(v) != 0 {
+			opts = append(opts, WithDeadCards(v))
+		}
+	}
+	return opts
+}
+
+// CalcDouble calculates the run's double board odds, including whether or not
+// to include folded positions. See [OddsCalc.CalcDouble].
+func (d *Dealer) CalcDouble(ctx context.Context, folded bool, opts ...CalcOption) (*DoubleOdds, error) {
 	if 0 <= d.r && d.r < d.runs {
 		return NewOddsCalc(
 			d.Type,
@@ -501,9 +1186,86 @@ func (d *Dealer) Calc(ctx context.Context, folded bool, opts ...CalcOption) (*Od
 				WithRuns(d.Runs[:d.r+1]),
 				WithActive(d.Active, folded),
 			)...,
-		).Calc(ctx)
+		).CalcDouble(ctx)
+	}
+	return nil, ErrInsufficientCards
+}
+
+// RunReport creates a report comparing each active position's equity on the
+// street after which the runs were changed (ie, at all-in, see
+// [Dealer.ChangeRuns]) with the pot share realized on each run. Returns
+// [ErrInvalidStreet] when the runs were not changed, [ErrInsufficientCards]
+// when the results have not been evaluated, or the errors returned by
+// [OddsCalc.CalcErr].
+func (d *Dealer) RunReport(ctx context.Context, opts ...CalcOption) (*RunReport, error) {
+	switch {
+	case d.base == nil:
+		return nil, fmt.Errorf("%w: runs not changed", ErrInvalidStreet)
+	case d.Results == nil:
+		return nil, ErrInsufficientCards
+	}
+	calc := NewOddsCalc(
+		d.Type,
+		append(
+			opts,
+			WithRuns([]*Run{d.base}),
+			WithActive(d.Active, false),
+		)...,
+	)
+	report := &RunReport{
+		Street:   d.st,
+		Equity:   make([]float64, d.Count),
+		Runs:     make([][]float64, len(d.Results)),
+		Realized: make([]float64, d.Count),
+	}
+	var err error
+	if d.Type.Double() {
+		var odds *DoubleOdds
+		if odds, err = calc.CalcDouble(ctx); err != nil {
+			return nil, err
+		}
+		report.Hi, report.Lo = odds.Hi, odds.Lo
+		for i := range d.Count {
+			report.Equity[i] = odds.Equity(i)
+		}
+	} else {
+		calc.shares = &potShares{shares: make([]float64, len(d.base.Pockets))}
+		if report.Hi, report.Lo, err = calc.CalcErr(ctx); err != nil {
+			return nil, err
+		}
+		for i := range min(d.Count, len(calc.shares.shares)) {
+			report.Equity[i] = calc.shares.equity(i)
+		}
+	}
+	for r, res := range d.Results {
+		report.Runs[r] = make([]float64, d.Count)
+		copy(report.Runs[r], res.Split(1))
+		for i, f := range report.Runs[r] {
+			report.Realized[i] += f / float64(len(d.Results))
+		}
+	}
+	return report, nil
+}
+
+// Odds returns the run odds calculated after the last street was dealt when
+// the dealer was created using [WithAutoCalc]. Returns nil odds when the odds
+// are not available for calculation (see [Dealer.HasCalc]), or the error
+// returned by [Dealer.CalcErr].
+func (d *Dealer) Odds() (*Odds, *Odds, error) {
+	return d.hi, d.lo, d.err
+}
+
+// autoCalc calculates the run odds when the dealer was created using
+// [WithAutoCalc].
+func (d *Dealer) autoCalc() {
+	switch {
+	case d.auto == nil:
+		return
+	case !d.HasCalc():
+		d.hi, d.lo, d.err = nil, nil, nil
+		return
 	}
-	return nil, nil, false
+	d.hi, d.lo, d.err = d.CalcErr(d.auto.ctx, d.auto.folded, d.auto.opts...)
 }
 
 // Result returns the current result.
@@ -521,21 +1283,36 @@ func (d *Dealer) Reset() {
 }
 
 // ChangeRuns changes the number of runs, returning true if successful.
+//
+// The first change to the runs must be made prior to the last street having
+// been dealt, and marks the street after which each additional run is dealt.
+// Subsequent changes can increase the runs (such as on the last street of the
+// current run), or remove runs that have not yet been dealt. Removed runs
+// have not drawn cards from the deck. Returns false when results have been
+// evaluated.
 func (d *Dealer) ChangeRuns(runs int) bool {
 	switch {
 	// check state
-	case d.r != 0,
-		d.runs != 1,
-		len(d.Runs) != 1,
-		len(d.Streets) <= d.s,
+	case runs < 1,
+		d.r < 0,
+		d.Results != nil,
 		!d.HasActive():
 		return false
+	case d.base == nil:
+		if d.r != 0 || d.runs != 1 || len(d.Runs) != 1 || len(d.Streets) <= d.s {
+			return false
+		}
+		d.base, d.st = d.Runs[0].Dupe(), d.s
+	case runs <= d.r:
+		// runs already dealt cannot be removed
+		return false
 	}
-	d.Runs = append(d.Runs, make([]*Run, runs-1)...)
-	for run := 1; run < runs; run++ {
-		d.Runs[run] = d.Runs[0].Dupe()
+	d.Runs = d.Runs[:min(runs, len(d.Runs))]
+	for len(d.Runs) < runs {
+		d.Runs = append(d.Runs, d.base.Dupe())
 	}
-	d.st, d.runs = d.s, runs
+	d.runs = runs
+	d.mark.changed = true
 	return true
 }
 
@@ -543,7 +1320,23 @@ func (d *Dealer) ChangeRuns(runs int) bool {
 // additional pocket and board cards for each street and run. Returns true when
 // there are at least 2 active positions for a [Type] having Max greater than 1
 // and when there are additional streets or runs.
+//
+// Returns false without advancing while there are pending pocket discards
+// (see [Dealer.Pending]). Use [Dealer.NextErr] to distinguish pending
+// discards from the end of the hand.
 func (d *Dealer) Next() bool {
+	ok, _ := d.NextErr()
+	return ok
+}
+
+// NextErr iterates the current street and run (see [Dealer.Next]). Returns
+// [ErrPendingDiscards] without advancing while there are pending pocket
+// discards (see [Dealer.PendingDiscards]).
+func (d *Dealer) NextErr() (bool, error) {
+	if v := d.PendingDiscards(); len(v) != 0 {
+		return false, fmt.Errorf("%w: positions %v", ErrPendingDiscards, v)
+	}
+	s, r := d.s, d.r
 	switch {
 	case d.s == -1 && d.r == -1:
 		d.s, d.r = 0, 0
@@ -552,40 +1345,218 @@ func (d *Dealer) Next() bool {
 	}
 	switch n := len(d.Streets); {
 	case n <= d.s && d.r == d.runs-1, !d.HasActive():
-		return false
+		return false, nil
 	case len(d.Streets) <= d.s && d.r < d.runs:
 		d.s, d.r = d.st+1, d.r+1
 	}
+	d.markRun(d.Runs[d.r], s, r)
 	d.Deal(d.s, d.Runs[d.r])
-	return d.s < len(d.Streets) || d.r < d.runs-1
+	d.autoCalc()
+	return d.s < len(d.Streets) || d.r < d.runs-1, nil
 }
 
-// NextResult iterates the next result.
-func (d *Dealer) NextResult() bool {
-	if d.Results == nil {
-		switch n := len(d.Active); {
-		case d.Results != nil:
-		case n == 1 && d.runs == 1 && d.Max != 1:
-			// only one active position
-			var i int
-			for ; i < d.Count && !d.Active[i]; i++ {
-			}
-			res := &Result{
-				Evals:   []*Eval{EvalOf(d.Type)},
-				HiOrder: []int{i},
-				HiPivot: 1,
-			}
-			if d.Low || d.Double {
-				res.LoOrder, res.LoPivot = res.HiOrder, res.HiPivot
-			}
-			d.Results = []*Result{res}
-		case n > 1 || d.Max == 1:
-			d.Results = make([]*Result, d.runs)
-			for i := range d.runs {
-				d.Results[i] = NewResult(d.Type, d.Runs[i], d.Active, false)
+// markRun marks the count of cards in the run, prior to dealing the current
+// street, where s and r are the street and run prior to the call to
+// [Dealer.Next].
+func (d *Dealer) markRun(run *Run, s, r int) {
+	d.mark.ok, d.mark.changed = true, false
+	d.mark.pockets = make([]int, len(run.Pockets))
+	for i, pocket := range run.Pockets {
+		d.mark.pockets[i] = len(pocket)
+	}
+	d.mark.hi, d.mark.lo, d.mark.discard = len(run.Hi), len(run.Lo), len(run.Discard)
+	d.mark.street, d.mark.run, d.mark.s, d.mark.r = d.s, d.r, s, r
+	d.mark.deck, d.mark.muck, d.mark.audit = d.Deck.i, len(d.muck), len(d.audit)
+}
+
+// Undo reverts the last street dealt by [Dealer.Next], returning the cards
+// dealt on the street and run to the deck, and restoring the previous street
+// and run. Only the last dealt street can be reverted. Returns false when no
+// street has been dealt, the street has already been reverted, the runs were
+// changed on or after the street (see [Dealer.ChangeRuns]), or when results
+// have been evaluated.
+func (d *Dealer) Undo() bool {
+	if !d.mark.ok || d.mark.changed || d.Results != nil || d.base != nil && d.mark.street <= d.st {
+		return false
+	}
+	run := d.Runs[d.mark.run]
+	for i, n := range d.mark.pockets {
+		run.Pockets[i] = run.Pockets[i][:n]
+	}
+	run.Discard, run.Hi, run.Lo = run.Discard[:d.mark.discard], run.Hi[:d.mark.hi], run.Lo[:d.mark.lo]
+	if id := d.Streets[d.mark.street].Id; run.discards != nil {
+		delete(run.discards, id)
+	}
+	d.Deck.i, d.muck, d.audit = d.mark.deck, d.muck[:d.mark.muck], d.audit[:d.mark.audit]
+	d.s, d.r = d.mark.s, d.mark.r
+	d.mark.ok = false
+	d.autoCalc()
+	return true
+}
+
+// RedealStreet voids the cards dealt on the current street and run, placing
+// the voided cards in the muck (see [Dealer.Muck]), and deals the street again
+// from the deck. Returns false when no street has been dealt, or when results
+// have been evaluated.
+func (d *Dealer) RedealStreet() bool {
+	if d.s < 0 || len(d.Streets) <= d.s || d.r < 0 || d.runs <= d.r ||
+		!d.mark.ok || d.mark.street != d.s || d.Results != nil {
+		return false
+	}
+	run := d.Runs[d.r]
+	d.auditMuck()
+	for i, n := range d.mark.pockets {
+		d.muck = append(d.muck, run.Pockets[i][n:]...)
+		run.Pockets[i] = run.Pockets[i][:n]
+	}
+	d.muck = append(d.muck, run.Discard[d.mark.discard:]...)
+	d.muck = append(d.muck, run.Hi[d.mark.hi:]...)
+	d.muck = append(d.muck, run.Lo[d.mark.lo:]...)
+	run.Discard, run.Hi, run.Lo = run.Discard[:d.mark.discard], run.Hi[:d.mark.hi], run.Lo[:d.mark.lo]
+	if id := d.Streets[d.s].Id; run.discards != nil {
+		delete(run.discards, id)
+	}
+	d.Deal(d.s, run)
+	d.autoCalc()
+	return true
+}
+
+// auditMuck records the cards dealt on the current street and run as voided
+// in the audit log.
+func (d *Dealer) auditMuck() {
+	var v []AuditEntry
+	for i := len(d.audit) - 1; 0 <= i; i-- {
+		entry := d.audit[i]
+		if entry.Street != d.s || entry.Run != d.r || entry.Purpose == AuditMuck {
+			break
+		}
+		entry.Purpose = AuditMuck
+		v = append(v, entry)
+	}
+	slices.Reverse(v)
+	d.audit = append(d.audit, v...)
+}
+
+// Muck returns the cards voided by [Dealer.RedealStreet].
+func (d *Dealer) Muck() []Card {
+	return d.muck
+}
+
+// Visible returns the cards visible to the position: the position's pocket
+// cards, the up pocket cards of the other positions, the board cards of all
+// runs, and the non-discarded cards voided to the muck. Use -1 for an
+// observer without a pocket.
+//
+// The up pocket cards for a street are the last cards dealt to each
+// position on the street (see [StreetDesc.PocketUp]).
+func (d *Dealer) Visible(position int) []Card {
+	muck, purposes := d.mucked()
+	up := d.up(muck)
+	var v []Card
+	for _, entry := range d.audit {
+		switch {
+		case entry.Purpose == AuditMuck:
+			if purposes[entry.Index] != AuditDiscard {
+				v = append(v, entry.Card)
 			}
+		case muck[entry.Index], entry.Purpose == AuditDiscard:
+		case entry.Purpose != AuditPocket, entry.Position == position, up[entry.Index]:
+			v = append(v, entry.Card)
 		}
 	}
+	return v
+}
+
+// Upcards returns the pocket cards turned up for the position, such as the
+// exposed cards of a [Stud] position.
+func (d *Dealer) Upcards(position int) []Card {
+	muck, _ := d.mucked()
+	up := d.up(muck)
+	var v []Card
+	for _, entry := range d.audit {
+		if entry.Purpose == AuditPocket && entry.Position == position && up[entry.Index] {
+			v = append(v, entry.Card)
+		}
+	}
+	return v
+}
+
+// mucked returns the deck indexes of the mucked cards in the audit log, and
+// the original purpose of each card.
+func (d *Dealer) mucked() (map[int]bool, map[int]AuditPurpose) {
+	muck, purposes := make(map[int]bool), make(map[int]AuditPurpose)
+	for _, entry := range d.audit {
+		if entry.Purpose == AuditMuck {
+			muck[entry.Index] = true
+		} else {
+			purposes[entry.Index] = entry.Purpose
+		}
+	}
+	return muck, purposes
+}
+
+// up returns the deck indexes of the dealt pocket cards turned up, being the
+// last cards dealt to each position on a street revealing pocket cards.
+func (d *Dealer) up(muck map[int]bool) map[int]bool {
+	type key struct {
+		run, street, pos int
+	}
+	dealt := make(map[key]int)
+	for _, entry := range d.audit {
+		if entry.Purpose == AuditPocket && !muck[entry.Index] {
+			dealt[key{entry.Run, entry.Street, entry.Position}]++
+		}
+	}
+	up := make(map[int]bool)
+	for _, entry := range d.audit {
+		if entry.Purpose != AuditPocket || muck[entry.Index] {
+			continue
+		}
+		// decrement to the count of cards dealt to the position after this
+		// card on the street
+		k := key{entry.Run, entry.Street, entry.Position}
+		if dealt[k]--; dealt[k] < d.Streets[entry.Street].PocketUp {
+			up[entry.Index] = true
+		}
+	}
+	return up
+}
+
+// UnseenByRank returns the count of cards not visible to the position (see
+// [Dealer.Visible]) for each rank, indexed by rank index (0-12 for
+// [Two]-[Ace]).
+func (d *Dealer) UnseenByRank(position int) [13]int {
+	ranks, _ := d.unseen(position)
+	return ranks
+}
+
+// UnseenBySuit returns the count of cards not visible to the position (see
+// [Dealer.Visible]) for each suit, indexed by suit index (0-3 for [Spade],
+// [Heart], [Diamond], [Club]).
+func (d *Dealer) UnseenBySuit(position int) [4]int {
+	_, suits := d.unseen(position)
+	return suits
+}
+
+// unseen returns the count of the deck's cards not visible to the position
+// for each rank and suit.
+func (d *Dealer) unseen(position int) ([13]int, [4]int) {
+	ranks, suits := countCards(d.Deck.v[:min(d.Deck.l, len(d.Deck.v))])
+	r, s := countCards(d.Visible(position))
+	for i := range ranks {
+		ranks[i] -= r[i]
+	}
+	for i := range suits {
+		suits[i] -= s[i]
+	}
+	return ranks, suits
+}
+
+// NextResult iterates the next result.
+func (d *Dealer) NextResult() bool {
+	if d.Results == nil {
+		d.Results = d.results()
+	}
 	if d.runs <= d.e {
 		return false
 	}
@@ -593,18 +1564,56 @@ func (d *Dealer) NextResult() bool {
 	return d.e < d.runs
 }
 
+// results evaluates the results for the runs.
+func (d *Dealer) results() []*Result {
+	switch n := len(d.Active); {
+	case n == 1 && d.runs == 1 && d.Max != 1:
+		// only one active position
+		var i int
+		for ; i < d.Count && !d.Active[i]; i++ {
+		}
+		res := &Result{
+			Evals:   []*Eval{EvalOf(d.Type)},
+			HiOrder: []int{i},
+			HiPivot: 1,
+			Low:     d.Low,
+			Profile: d.Profile,
+		}
+		if d.Low || d.Double {
+			res.LoOrder, res.LoPivot = res.HiOrder, res.HiPivot
+		}
+		return []*Result{res}
+	case n > 1 || d.Max == 1:
+		results := make([]*Result, d.runs)
+		for i := range d.runs {
+			results[i] = NewResult(d.Type, d.Runs[i], d.Active, false)
+			results[i].Profile = d.Profile
+		}
+		return results
+	}
+	return nil
+}
+
 // Deal deals pocket and board cards for the street and run, discarding cards
 // accordingly.
 func (d *Dealer) Deal(street int, run *Run) {
 	desc := d.Streets[street]
 	// pockets
-	if p := desc.Pocket; 0 < p {
+	p, deltas := desc.Pocket, d.deltas[desc.Id]
+	n := p
+	for _, delta := range deltas {
+		n = max(n, p+delta)
+	}
+	if 0 < n {
 		if n := desc.PocketDiscard; 0 < n {
-			run.Discard = append(run.Discard, d.Deck.Draw(n)...)
+			run.discard(desc.Id, d.draw(street, AuditDiscard, -1, n))
 		}
-		for range p {
-			for i := range d.Count {
-				run.Pockets[i] = append(run.Pockets[i], d.Deck.Draw(1)...)
+		order := d.DealOrder()
+		for j := range n {
+			for _, i := range order {
+				if j < p+deltas[i] {
+					run.Pockets[i] = append(run.Pockets[i], d.draw(street, AuditPocket, i, 1)...)
+				}
 			}
 		}
 	}
@@ -613,25 +1622,462 @@ func (d *Dealer) Deal(street int, run *Run) {
 		// hi
 		disc := desc.BoardDiscard
 		if 0 < disc {
-			run.Discard = append(run.Discard, d.Deck.Draw(disc)...)
+			run.discard(desc.Id, d.draw(street, AuditDiscard, -1, disc))
 		}
-		run.Hi = append(run.Hi, d.Deck.Draw(b)...)
+		run.Hi = append(run.Hi, d.draw(street, AuditHi, -1, b)...)
 		// lo
 		if d.Double {
 			if 0 < disc {
-				run.Discard = append(run.Discard, d.Deck.Draw(disc)...)
+				run.discard(desc.Id, d.draw(street, AuditDiscard, -1, disc))
+			}
+			run.Lo = append(run.Lo, d.draw(street, AuditLo, -1, b)...)
+		}
+	}
+}
+
+// draw draws count cards from the deck for the street, recording the drawn
+// cards in the audit log.
+func (d *Dealer) draw(street int, purpose AuditPurpose, pos, count int) []Card {
+	i := d.Deck.i
+	v := d.Deck.Draw(count)
+	for j, c := range v {
+		d.audit = append(d.audit, AuditEntry{
+			Street:   street,
+			Name:     d.Streets[street].Name,
+			Run:      d.r,
+			Purpose:  purpose,
+			Position: pos,
+			Index:    i + j,
+			Card:     c,
+		})
+	}
+	return v
+}
+
+// Audit returns the dealer's audit log, containing an entry for each card
+// drawn from the deck or voided, in deal order.
+func (d *Dealer) Audit() []AuditEntry {
+	return d.audit
+}
+
+// WriteAudit writes the dealer's audit log as JSON to w.
+func (d *Dealer) WriteAudit(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(d.audit); err != nil {
+		return fmt.Errorf("unable to encode audit: %w", err)
+	}
+	return nil
+}
+
+// WriteSummary writes a text summary of the dealer to w, with the deck, the
+// pockets, discards and boards of each dealt street and run, and the
+// showdown of each run when all streets and runs have been dealt. Positions
+// are described using names when provided.
+func (d *Dealer) WriteSummary(w io.Writer, names ...string) error {
+	var sb strings.Builder
+	name := func(i int) string {
+		if i < len(names) {
+			return strconv.Itoa(i) + " " + names[i]
+		}
+		return strconv.Itoa(i)
+	}
+	// deck
+	deck := d.Deck.All()
+	sb.WriteString("Deck:\n")
+	for i := 0; i < len(deck); i += 8 {
+		fmt.Fprintf(&sb, "  %v\n", deck[i:min(i+8, len(deck))])
+	}
+	// posts
+	if len(d.posts) != 0 {
+		sb.WriteString("Posts:\n")
+		for _, post := range d.posts {
+			fmt.Fprintf(&sb, "  %s: %s %d\n", name(post.Position), post.Name, post.Amount)
+		}
+	}
+	// streets
+	last := min(d.s, len(d.Streets)-1)
+	for r := 0; r <= d.r && r < d.runs; r++ {
+		run, start, end := d.Runs[r], 0, len(d.Streets)-1
+		if r != 0 {
+			start = d.st + 1
+		}
+		if r == d.r {
+			end = last
+		}
+		if end < start {
+			continue
+		}
+		fmt.Fprintf(&sb, "Run %d:\n", r)
+		var pocket, board int
+		var discard []Card
+		for s := range start {
+			pocket, board = pocket+d.Streets[s].Pocket, board+d.Streets[s].Board
+			discard = append(discard, run.DiscardedOn(d.Streets[s].Id)...)
+		}
+		for s := start; s <= end; s++ {
+			desc := d.Streets[s]
+			pocket, board = pocket+desc.Pocket, board+desc.Board
+			discard = append(discard, run.DiscardedOn(desc.Id)...)
+			fmt.Fprintf(&sb, "  %s\n", desc.Desc())
+			if 0 < desc.Pocket {
+				for i, v := range run.Pockets {
+					fmt.Fprintf(&sb, "    %s: %v\n", name(i), v[:min(pocket, len(v))])
+				}
+			}
+			if len(discard) != 0 {
+				fmt.Fprintf(&sb, "    Discard: %v\n", discard)
+			}
+			if 0 < desc.Board {
+				fmt.Fprintf(&sb, "    Board: %v\n", run.Hi[:min(board, len(run.Hi))])
+				if d.Double {
+					fmt.Fprintf(&sb, "           %v\n", run.Lo[:min(board, len(run.Lo))])
+				}
 			}
-			run.Lo = append(run.Lo, d.Deck.Draw(b)...)
 		}
 	}
+	// showdown
+	results := d.Results
+	if results == nil && d.r == d.runs-1 && last == len(d.Streets)-1 {
+		results = d.results()
+	}
+	if results != nil {
+		sb.WriteString("Showdown:\n")
+	}
+	for r, res := range results {
+		fmt.Fprintf(&sb, "  Run %d:\n", r)
+		evs := res.Evals
+		if len(evs) != d.Count {
+			// evals of a single active position are indexed by the order
+			evs = make([]*Eval, d.Count)
+			for j, pos := range res.HiOrder {
+				evs[pos] = res.Evals[j]
+			}
+		}
+		for i, ev := range evs {
+			if !d.Active[i] || ev == nil {
+				fmt.Fprintf(&sb, "    %d: inactive\n", i)
+				continue
+			}
+			hi := ev.ProfileDesc(d.Profile, false)
+			fmt.Fprintf(&sb, "    %d: %v %v %s\n", i, hi.Best, hi.Unused, hi)
+			if d.Low || d.Double {
+				lo := ev.ProfileDesc(d.Profile, true)
+				fmt.Fprintf(&sb, "       %v %v %s\n", lo.Best, lo.Unused, lo)
+			}
+		}
+		hi, lo := res.Win(names...)
+		fmt.Fprintf(&sb, "    Result: %S\n", hi)
+		if lo != nil {
+			fmt.Fprintf(&sb, "            %S\n", lo)
+		}
+	}
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return fmt.Errorf("unable to write summary: %w", err)
+	}
+	return nil
+}
+
+// EventKind is a dealer event kind.
+type EventKind uint8
+
+// Event kinds.
+const (
+	// EventDeactivate is a position deactivated (see [Dealer.Deactivate]).
+	EventDeactivate EventKind = iota
+	// EventReactivate is a position reactivated (see [Dealer.Reactivate]).
+	EventReactivate
+	// EventDiscard is a position's pocket discarded (see
+	// [Dealer.DiscardPocket]).
+	EventDiscard
+)
+
+// Name returns the event kind name.
+func (kind EventKind) Name() string {
+	switch kind {
+	case EventDeactivate:
+		return "deactivate"
+	case EventReactivate:
+		return "reactivate"
+	case EventDiscard:
+		return "discard"
+	}
+	return ""
+}
+
+// MarshalText satisfies the [encoding.TextMarshaler] interface.
+func (kind EventKind) MarshalText() ([]byte, error) {
+	return []byte(kind.Name()), nil
+}
+
+// Event is a dealer event (see [WithEvents]).
+type Event struct {
+	// Kind is the event kind.
+	Kind EventKind
+	// Street is the street index.
+	Street int
+	// Run is the run index.
+	Run int
+	// Position is the position.
+	Position int
+}
+
+// AuditPurpose is the purpose of a card in a dealer's audit log.
+type AuditPurpose uint8
+
+// Audit purposes.
+const (
+	// AuditPocket is a card dealt to a position's pocket.
+	AuditPocket AuditPurpose = iota
+	// AuditDiscard is a discarded (burned) card.
+	AuditDiscard
+	// AuditHi is a card dealt to the Hi board.
+	AuditHi
+	// AuditLo is a card dealt to the Lo board.
+	AuditLo
+	// AuditMuck is a previously dealt card voided to the muck (see
+	// [Dealer.RedealStreet]).
+	AuditMuck
+)
+
+// Name returns the audit purpose name.
+func (purpose AuditPurpose) Name() string {
+	switch purpose {
+	case AuditPocket:
+		return "pocket"
+	case AuditDiscard:
+		return "discard"
+	case AuditHi:
+		return "hi"
+	case AuditLo:
+		return "lo"
+	case AuditMuck:
+		return "muck"
+	}
+	return ""
+}
+
+// MarshalText satisfies the [encoding.TextMarshaler] interface.
+func (purpose AuditPurpose) MarshalText() ([]byte, error) {
+	return []byte(purpose.Name()), nil
+}
+
+// AuditEntry is a dealer audit log entry.
+type AuditEntry struct {
+	// Street is the street index.
+	Street int
+	// Name is the street name.
+	Name string
+	// Run is the run index.
+	Run int
+	// Purpose is the purpose of the card.
+	Purpose AuditPurpose
+	// Position is the pocket position, or -1 when not a pocket card.
+	Position int
+	// Index is the card's index in the deck.
+	Index int
+	// Card is the card.
+	Card Card
+}
+
+// DealOrder returns the positions in the order pocket cards are dealt,
+// starting at the origin and continuing clockwise (or counterclockwise when
+// reversed).
+func (d *Dealer) DealOrder() []int {
+	v := make([]int, d.Count)
+	for i := range d.Count {
+		if d.Reverse {
+			v[i] = mod(d.Origin-i, d.Count)
+		} else {
+			v[i] = mod(d.Origin+i, d.Count)
+		}
+	}
+	return v
+}
+
+// SetButton sets the origin to the position immediately after the button,
+// in the dealing direction (ie, left of the button when dealing clockwise,
+// or right of the button when dealing counterclockwise).
+func (d *Dealer) SetButton(button int) {
+	if d.Reverse {
+		d.Origin = mod(button-1, d.Count)
+	} else {
+		d.Origin = mod(button+1, d.Count)
+	}
+}
+
+// DealId is a reproducible deal identifier, encoding the type, deck type,
+// shuffle seed, shuffle count, and pocket count of a seeded deal (see
+// [NewSeededDealer]).
+//
+// A deal id's text form is the type id and the deck type, seed, shuffles,
+// and count in base 36, separated by a '.', such as "Hh.0.2n9c.1.6".
+type DealId struct {
+	Type     Type
+	Deck     DeckType
+	Seed     int64
+	Shuffles int
+	Count    int
+}
+
+// ParseDealId parses a deal id from its text form.
+func ParseDealId(s string) (DealId, error) {
+	v := strings.Split(s, ".")
+	if len(v) != 5 {
+		return DealId{}, fmt.Errorf("%w: %q", ErrInvalidDealId, s)
+	}
+	typ, err := IdToType(v[0])
+	if err != nil {
+		return DealId{}, fmt.Errorf("%w: %q: %w", ErrInvalidDealId, s, err)
+	}
+	if _, ok := descs[typ]; !ok {
+		return DealId{}, fmt.Errorf("%w: %q: %w", ErrInvalidDealId, s, ErrInvalidType)
+	}
+	var n [4]int64
+	for i, bits := range []int{8, 64, 32, 32} {
+		if n[i], err = strconv.ParseInt(v[i+1], 36, bits); err != nil {
+			return DealId{}, fmt.Errorf("%w: %q: %w", ErrInvalidDealId, s, err)
+		}
+	}
+	id := DealId{
+		Type:     typ,
+		Deck:     DeckType(n[0]),
+		Seed:     n[1],
+		Shuffles: int(n[2]),
+		Count:    int(n[3]),
+	}
+	switch {
+	case n[0] < 0 || id.Deck.Name() == "":
+		return DealId{}, fmt.Errorf("%w: %q: invalid deck %d", ErrInvalidDealId, s, n[0])
+	case id.Shuffles < 0:
+		return DealId{}, fmt.Errorf("%w: %q: invalid shuffles %d", ErrInvalidDealId, s, id.Shuffles)
+	case id.Count < 1:
+		return DealId{}, fmt.Errorf("%w: %q: invalid count %d", ErrInvalidDealId, s, id.Count)
+	}
+	return id, nil
+}
+
+// String satisfies the [fmt.Stringer] interface.
+func (id DealId) String() string {
+	return id.Type.Id() +
+		"." + strconv.FormatInt(int64(id.Deck), 36) +
+		"." + strconv.FormatInt(id.Seed, 36) +
+		"." + strconv.FormatInt(int64(id.Shuffles), 36) +
+		"." + strconv.FormatInt(int64(id.Count), 36)
+}
+
+// MarshalText satisfies the [encoding.TextMarshaler] interface.
+func (id DealId) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText satisfies the [encoding.TextUnmarshaler] interface.
+func (id *DealId) UnmarshalText(buf []byte) error {
+	var err error
+	*id, err = ParseDealId(string(buf))
+	return err
+}
+
+// Dealer creates a new seeded dealer that reproduces the deal.
+func (id DealId) Dealer(opts ...DealerOption) *Dealer {
+	desc := id.Type.Desc()
+	desc.Deck = id.Deck
+	return NewSeededDealer(desc, id.Seed, id.Shuffles, id.Count, opts...)
+}
+
+// Post is a forced bet posted by a position prior to the deal, such as an
+// ante or blind.
+type Post struct {
+	// Position is the posting position.
+	Position int
+	// Name is the post name, such as a blind name (see [TypeDesc.Blinds]).
+	Name string
+	// Amount is the posted amount.
+	Amount int64
+}
+
+// Post records a forced bet posted by the position, such as an ante or
+// blind, so that hand histories contain the complete state prior to the deal
+// (see [Dealer.WriteSummary]). Posts must be recorded prior to the first
+// street being dealt.
+func (d *Dealer) Post(position int, name string, amount int64) error {
+	switch {
+	case position < 0 || d.Count <= position:
+		return fmt.Errorf("%w: %d", ErrInvalidPosition, position)
+	case d.s != -1:
+		return fmt.Errorf("%w: cannot post after %s", ErrInvalidStreet, d.Streets[min(d.s, len(d.Streets)-1)].Name)
+	}
+	d.posts = append(d.posts, Post{
+		Position: position,
+		Name:     name,
+		Amount:   amount,
+	})
+	return nil
+}
+
+// PostAntes records an ante posted by each active position, in deal order.
+// See [Dealer.Post].
+func (d *Dealer) PostAntes(amount int64) error {
+	for _, position := range d.DealOrder() {
+		if d.Active[position] {
+			if err := d.Post(position, "Ante", amount); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// PostBlinds records the blinds posted by the active positions in deal order,
+// using the type's blind names (see [TypeDesc.Blinds]). When heads-up (ie,
+// only 2 active positions), the button (the last active position in deal
+// order) posts the first blind. See [Dealer.Post].
+func (d *Dealer) PostBlinds(amounts ...int64) error {
+	var positions []int
+	for _, position := range d.DealOrder() {
+		if d.Active[position] {
+			positions = append(positions, position)
+		}
+	}
+	if len(positions) == 2 {
+		positions[0], positions[1] = positions[1], positions[0]
+	}
+	for i, position := range positions {
+		if i == len(amounts) {
+			break
+		}
+		name := "Blind"
+		if i < len(d.Blinds) {
+			name = d.Blinds[i]
+		}
+		if err := d.Post(position, name, amounts[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Posts returns the recorded posts, in the order posted.
+func (d *Dealer) Posts() []Post {
+	return d.posts
+}
+
+// mod returns the non-negative modulus of i and n.
+func mod(i, n int) int {
+	if n == 0 {
+		return 0
+	}
+	return (i%n + n) % n
 }
 
 // Run holds pockets, and a Hi/Lo board for a deal.
 type Run struct {
-	Discard []Card
-	Pockets [][]Card
-	Hi      []Card
-	Lo      []Card
+	Discard  []Card
+	Pockets  [][]Card
+	Hi       []Card
+	Lo       []Card
+	discards map[byte][]Card
+	pockets  map[int][]Card
 }
 
 // NewRun creates a new run for the pocket count.
@@ -641,8 +2087,27 @@ func NewRun(count int) *Run {
 	}
 }
 
-// Dupe creates a duplicate of run, with a copy of the pockets and Hi and Lo
-// board.
+// DiscardedOn returns the cards discarded on the street with the id.
+func (run *Run) DiscardedOn(id byte) []Card {
+	return run.discards[id]
+}
+
+// PocketDiscarded returns the cards discarded from the position's pocket.
+func (run *Run) PocketDiscarded(position int) []Card {
+	return run.pockets[position]
+}
+
+// discard adds the cards discarded on the street with the id.
+func (run *Run) discard(id byte, v []Card) {
+	if run.discards == nil {
+		run.discards = make(map[byte][]Card)
+	}
+	run.Discard = append(run.Discard, v...)
+	run.discards[id] = append(run.discards[id], v...)
+}
+
+// Dupe creates a duplicate of run, with a copy of the pockets, Hi and Lo
+// board, and the cards discarded on each street.
 func (run *Run) Dupe() *Run {
 	r := new(Run)
 	if run.Pockets != nil {
@@ -660,51 +2125,119 @@ func (run *Run) Dupe() *Run {
 		r.Lo = make([]Card, len(run.Lo))
 		copy(r.Lo, run.Lo)
 	}
+	if run.discards != nil {
+		r.discards = make(map[byte][]Card, len(run.discards))
+		for id, v := range run.discards {
+			r.discards[id] = slices.Clone(v)
+		}
+	}
+	if run.pockets != nil {
+		r.pockets = make(map[int][]Card, len(run.pockets))
+		for i, v := range run.pockets {
+			r.pockets[i] = slices.Clone(v)
+		}
+	}
 	return r
 }
 
 // Eval returns the evals for the run.
 func (run *Run) Eval(typ Type, active map[int]bool, calc bool) []*Eval {
+	return run.eval(typ, active, calc, nil)
+}
+
+// eval returns the evals for the run, reusing the evals in evs, and the
+// evals' cards, when it has the same length as the run's pockets.
+func (run *Run) eval(typ Type, active map[int]bool, calc bool, evs []*Eval) []*Eval {
 	n := len(run.Pockets)
-	evs := make([]*Eval, n)
-	var f EvalFunc
-	if calc {
-		f = calcs[typ]
-	} else {
-		f = evals[typ]
+	if len(evs) != n {
+		evs = make([]*Eval, n)
+	}
+	double := typ.Double()
+	var hi, lo BoardEval
+	var tmp *Eval
+	hi.init(typ, run.Hi, !calc)
+	if double {
+		lo.init(typ, run.Lo, !calc)
+		tmp = new(Eval)
 	}
-	for i, double := 0, typ.Double(); i < n; i++ {
-		if active == nil || active[i] {
+	for i := range n {
+		switch ev := evs[i]; {
+		case active != nil && !active[i]:
+			evs[i] = nil
+			continue
+		case ev == nil:
 			evs[i] = EvalOf(typ)
-			f(evs[i], run.Pockets[i], run.Hi)
-			if double {
-				ev := EvalOf(typ)
-				f(ev, run.Pockets[i], run.Lo)
-				evs[i].LoRank, evs[i].LoBest, evs[i].LoUnused = ev.HiRank, ev.HiBest, ev.HiUnused
+		default:
+			*ev = Eval{
+				Type:     typ,
+				HiRank:   Invalid,
+				HiBest:   ev.HiBest[:0],
+				HiUnused: ev.HiUnused[:0],
+				LoRank:   Invalid,
+				LoBest:   ev.LoBest[:0],
+				LoUnused: ev.LoUnused[:0],
+				reuse:    true,
 			}
 		}
+		hi.eval(evs[i], run.Pockets[i])
+		if double {
+			// evaluate the lo board using the eval's lo cards
+			*tmp = Eval{
+				Type:     typ,
+				HiRank:   Invalid,
+				HiBest:   evs[i].LoBest,
+				HiUnused: evs[i].LoUnused,
+				LoRank:   Invalid,
+				reuse:    evs[i].reuse,
+			}
+			lo.eval(tmp, run.Pockets[i])
+			evs[i].SetLoFrom(tmp)
+		}
 	}
 	return evs
 }
 
-// CalcStart returns the run's starting odds.
+// CalcStart returns the run's starting odds, using the [Holdem] starting
+// pocket data.
+//
+// Pockets with more than 2 cards use the average of each 2-card combination
+// of the pocket (see [StartingExpValue]). The returned odds are marked as an
+// estimate.
 func (run *Run) CalcStart(low bool) (*Odds, *Odds) {
+	return run.calcStart(Holdem, low)
+}
+
+// calcStart returns the run's starting odds, using the type's starting pocket
+// data (see [RegisterStarting]).
+func (run *Run) calcStart(typ Type, low bool) (*Odds, *Odds) {
 	count := len(run.Pockets)
 	hi := NewOdds(count, nil)
-	hi.Total = startingTotal
+	hi.Total, hi.Estimate = startingTotal, true
 	var lo *Odds
 	if low {
 		lo = NewOdds(count, nil)
-		lo.Total = startingTotal
+		lo.Total, lo.Estimate = startingTotal, true
 	}
+	_, registered := startings[typ]
 	for i, pocket := range run.Pockets {
-		expv := StartingExpValue(pocket)
+		expv := typ.StartingExpValue(pocket)
 		if expv == nil {
 			return nil, nil
 		}
-		hi.Counts[i] = int(expv.Wins + expv.Losses)
+		if registered && i == 0 {
+			hi.Total = int(expv.Total)
+			if low {
+				lo.Total = int(expv.Total)
+			}
+		}
+		// scale expanded combinations to the common total
+		n := int(expv.Wins + expv.Losses)
+		if expv.Total != 0 && int(expv.Total) != hi.Total {
+			n = int(float64(expv.Wins+expv.Losses) / float64(expv.Total) * float64(hi.Total))
+		}
+		hi.Counts[i], hi.Losses[i] = n, max(hi.Total-n, 0)
 		if low {
-			lo.Counts[i] = int(expv.Wins + expv.Losses)
+			lo.Counts[i], lo.Losses[i] = n, max(lo.Total-n, 0)
 		}
 	}
 	return hi, lo
@@ -717,57 +2250,267 @@ type Result struct {
 	HiPivot int
 	LoOrder []int
 	LoPivot int
+	// Describers are the describers of each position, used to order and
+	// describe the result. Set from the evals by [NewResult], or to any
+	// describers by [ResultOf]. When nil, the evals are used.
+	Describers []Describer
+	// Low is true when the result is for a Hi/Lo type. When false, whether
+	// the result is for a Hi/Lo type is determined from the evals' type.
+	Low bool
+	// BoardPlays is true when the Hi winning hand is made entirely by the Hi
+	// board (see [Eval.BoardPlays]). Only set by [NewResult].
+	BoardPlays bool
+	// Profile is the description profile used for the wins.
+	Profile *DescProfile
 }
 
 // NewResult creates a result for the run, storing the calculated or evaluated
 // result.
 func NewResult(typ Type, run *Run, active map[int]bool, calc bool) *Result {
 	evs := run.Eval(typ, active, calc)
-	hiOrder, hiPivot := Order(evs, false)
-	var loOrder []int
-	var loPivot int
+	res := &Result{
+		Evals:      evs,
+		Describers: describers(evs),
+	}
+	res.HiOrder, res.HiPivot = Order(evs, false)
 	if typ.Low() || typ.Double() {
-		loOrder, loPivot = Order(evs, true)
+		res.LoOrder, res.LoPivot = Order(evs, true)
+	}
+	if res.HiPivot != 0 && evs[res.HiOrder[0]] != nil {
+		res.BoardPlays = evs[res.HiOrder[0]].BoardPlays(run.Hi)
+	}
+	return res
+}
+
+// ResultOf creates a result for any [Describer], such as an alternate eval
+// backend, ordering the Hi, and the Lo when lo is true. Use nil for positions
+// that were not evaluated.
+func ResultOf(lo bool, descs ...Describer) *Result {
+	res := &Result{
+		Describers: descs,
+		Low:        lo,
 	}
-	return &Result{
-		Evals:   evs,
-		HiOrder: hiOrder,
-		HiPivot: hiPivot,
-		LoOrder: loOrder,
-		LoPivot: loPivot,
+	res.HiOrder, res.HiPivot = OrderOf(descs, false)
+	if lo {
+		res.LoOrder, res.LoPivot = OrderOf(descs, true)
 	}
+	return res
 }
 
-// Win returns the Hi and Lo win.
+// describers returns the evals as describers, where a nil eval is a nil
+// describer.
+func describers(evs []*Eval) []Describer {
+	if evs == nil {
+		return nil
+	}
+	v := make([]Describer, len(evs))
+	for i, ev := range evs {
+		if ev != nil {
+			v[i] = ev
+		}
+	}
+	return v
+}
+
+// descs returns the result's describers, or its evals as describers.
+func (res *Result) descs() []Describer {
+	if res.Describers == nil {
+		return describers(res.Evals)
+	}
+	return res.Describers
+}
+
+// Describer returns the describer for the position, or nil when the position
+// was not evaluated.
+func (res *Result) Describer(pos int) Describer {
+	if v := res.descs(); 0 <= pos && pos < len(v) {
+		return v[pos]
+	}
+	return nil
+}
+
+// count returns the count of positions in the result.
+func (res *Result) count() int {
+	return len(res.descs())
+}
+
+// low returns true when the result is for a Hi/Lo type.
+func (res *Result) low() bool {
+	if res.Low || len(res.HiOrder) == 0 {
+		return res.Low
+	}
+	if ev, ok := res.Describer(res.HiOrder[0]).(*Eval); ok {
+		return ev.Type.Low()
+	}
+	return false
+}
+
+// Win returns the Hi and Lo win. Both wins are marked as a scoop when the same
+// position wins the Hi and Lo outright (see [Result.Scoop]), and as three
+// quarters when a position wins one outright and splits the other (see
+// [Result.ThreeQuarters]).
 func (res *Result) Win(names ...string) (*Win, *Win) {
-	low := res.Evals[res.HiOrder[0]].Type.Low()
+	_, scoop := res.Scoop()
+	_, quarters := res.ThreeQuarters()
 	var lo *Win
 	if res.LoOrder != nil && res.LoPivot != 0 {
-		lo = NewWin(res.Evals, res.LoOrder, res.LoPivot, true, false, names)
+		lo = NewWin(res.Evals, res.LoOrder, res.LoPivot, true, scoop, names)
+		lo.Describers, lo.ThreeQuarters, lo.Profile = res.descs(), quarters, res.Profile
 	}
-	hi := NewWin(res.Evals, res.HiOrder, res.HiPivot, false, low && lo == nil, names)
+	hi := NewWin(res.Evals, res.HiOrder, res.HiPivot, false, scoop, names)
+	hi.Describers, hi.ThreeQuarters, hi.Profile = res.descs(), quarters, res.Profile
 	return hi, lo
 }
 
+// Scoop returns the position that wins both the Hi and Lo outright, or the
+// position that wins the Hi outright when no position made a Lo. Returns
+// false when the result is not for a Hi/Lo type.
+func (res *Result) Scoop() (int, bool) {
+	switch {
+	case res.HiPivot != 1, res.LoOrder == nil && !res.low():
+		return -1, false
+	case res.LoPivot == 0, res.LoPivot == 1 && res.LoOrder[0] == res.HiOrder[0]:
+		return res.HiOrder[0], true
+	}
+	return -1, false
+}
+
+// ThreeQuarters returns the position that wins one of the Hi or Lo outright,
+// and splits the other with exactly one other position, and is therefore
+// awarded three quarters of the pot.
+func (res *Result) ThreeQuarters() (int, bool) {
+	switch {
+	case res.LoOrder == nil, res.LoPivot == 0:
+	case res.HiPivot == 1 && res.LoPivot == 2 && slices.Contains(res.LoOrder[:2], res.HiOrder[0]):
+		return res.HiOrder[0], true
+	case res.LoPivot == 1 && res.HiPivot == 2 && slices.Contains(res.HiOrder[:2], res.LoOrder[0]):
+		return res.LoOrder[0], true
+	}
+	return -1, false
+}
+
+// Guts settles a [Guts] pot for the result, where the winning positions split
+// the pot, and each other evaluated position (ie, each active position that
+// stayed in) matches the pot. Returns the net amount for each evaluated
+// position, and the next pot made of the matched amounts. When no position
+// stayed in, the pot carries over as the next pot.
+func (res *Result) Guts(pot float64) (map[int]float64, float64) {
+	m := make(map[int]float64)
+	if res.HiPivot == 0 || len(res.HiOrder) == 0 || res.Describer(res.HiOrder[0]) == nil {
+		return m, pot
+	}
+	var next float64
+	share := pot / float64(res.HiPivot)
+	for i, pos := range res.HiOrder {
+		switch {
+		case res.Describer(pos) == nil:
+		case i < res.HiPivot:
+			m[pos] = share
+		default:
+			m[pos], next = -pot, next+pot
+		}
+	}
+	return m, next
+}
+
+// Split splits the pot for the result, returning the amount won by each
+// position. For a Hi/Lo result, the Hi winners split half of the pot and the
+// Lo winners split the other half, with the Hi winners splitting the whole
+// pot when no position made a Lo.
+func (res *Result) Split(pot float64) []float64 {
+	v := make([]float64, res.count())
+	if res.HiPivot == 0 || len(res.HiOrder) == 0 || res.Describer(res.HiOrder[0]) == nil {
+		return v
+	}
+	hi := pot
+	if res.LoOrder != nil && res.LoPivot != 0 {
+		hi = pot / 2
+		for _, pos := range res.LoOrder[:res.LoPivot] {
+			v[pos] += (pot - hi) / float64(res.LoPivot)
+		}
+	}
+	for _, pos := range res.HiOrder[:res.HiPivot] {
+		v[pos] += hi / float64(res.HiPivot)
+	}
+	return v
+}
+
+// RunReport is a report comparing each position's equity at all-in with the
+// pot share realized on each run of a run-it-N-times pot (see
+// [Dealer.RunReport]). Pot shares are a fraction of the pot, and do not
+// account for rake.
+type RunReport struct {
+	// Street is the street index after which the runs were changed.
+	Street int
+	// Hi is the Hi odds on the street.
+	Hi *Odds
+	// Lo is the Lo odds on the street.
+	Lo *Odds
+	// Equity is each position's equity on the street, as the expected pot
+	// share split between the Hi and Lo winners of each board the same as
+	// [Result.Split].
+	Equity []float64
+	// Runs is each position's realized pot share for each run.
+	Runs [][]float64
+	// Realized is each position's realized pot share across all runs.
+	Realized []float64
+}
+
+// Diff returns the difference between the position's realized pot share and
+// equity, where a negative difference is a position that realized less than
+// its equity.
+func (report *RunReport) Diff(pos int) float64 {
+	return report.Realized[pos] - report.Equity[pos]
+}
+
+// Format satisfies the [fmt.Formatter] interface, writing a line for each
+// position with equity or a realized pot share.
+func (report *RunReport) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's':
+		for i := range report.Equity {
+			if report.Equity[i] == 0 && report.Realized[i] == 0 {
+				continue
+			}
+			fmt.Fprintf(f, "%d: equity %.1f%% realized %.1f%% (%+.1f%%)", i, 100*report.Equity[i], 100*report.Realized[i], 100*report.Diff(i))
+			for r, run := range report.Runs {
+				fmt.Fprintf(f, ", run %d %.1f%%", r, 100*run[i])
+			}
+			fmt.Fprintln(f)
+		}
+	default:
+		fmt.Fprintf(f, "%%!%c(ERROR=unknown verb, run report)", verb)
+	}
+}
+
 // Win formats win information.
 type Win struct {
 	Evals []*Eval
-	Order []int
-	Pivot int
-	Low   bool
-	Scoop bool
-	Names []string
+	// Describers are the describers of each position, used to describe the
+	// win (see [Result.Describers]). When nil, the evals are used.
+	Describers []Describer
+	Order      []int
+	Pivot      int
+	Low        bool
+	Scoop      bool
+	Names      []string
+	// ThreeQuarters is true when a position is awarded three quarters of the
+	// pot (see [Result.ThreeQuarters]).
+	ThreeQuarters bool
+	// Profile is the description profile used for descriptions.
+	Profile *DescProfile
 }
 
 // NewWin creates a new win.
 func NewWin(evs []*Eval, order []int, pivot int, low, scoop bool, names []string) *Win {
 	return &Win{
-		Evals: evs,
-		Order: order,
-		Pivot: pivot,
-		Low:   low,
-		Scoop: scoop,
-		Names: names,
+		Evals:      evs,
+		Describers: describers(evs),
+		Order:      order,
+		Pivot:      pivot,
+		Low:        low,
+		Scoop:      scoop,
+		Names:      names,
 	}
 }
 
@@ -775,7 +2518,7 @@ func NewWin(evs []*Eval, order []int, pivot int, low, scoop bool, names []string
 func (win *Win) Desc() []*EvalDesc {
 	var v []*EvalDesc
 	for i := range win.Pivot {
-		if d := win.Evals[win.Order[i]].Desc(win.Low); d != nil && d.Rank != 0 && d.Rank != Invalid {
+		if d := win.desc(i); d != nil && d.Qualified {
 			v = append(v, d)
 		}
 	}
@@ -786,11 +2529,43 @@ func (win *Win) Desc() []*EvalDesc {
 func (win *Win) Invalid() bool {
 	switch {
 	case win == nil, win.Pivot == 0,
-		len(win.Evals) == 0, len(win.Order) == 0:
+		len(win.descs()) == 0, len(win.Order) == 0:
 		return false
 	}
-	d := win.Evals[win.Order[0]].Desc(win.Low)
-	return d == nil || d.Rank == 0 || d.Rank == Invalid
+	d := win.desc(0)
+	return d == nil || !d.Qualified
+}
+
+// desc returns the description of the eval at i in the order.
+func (win *Win) desc(i int) *EvalDesc {
+	if v, pos := win.descs(), win.Order[i]; pos < len(v) {
+		return profileDesc(v[pos], win.Profile, win.Low)
+	}
+	return nil
+}
+
+// descs returns the win's describers, or its evals as describers.
+func (win *Win) descs() []Describer {
+	if win.Describers == nil {
+		return describers(win.Evals)
+	}
+	return win.Describers
+}
+
+// profileDesc returns the Hi/Lo description of the describer using the
+// description profile.
+func profileDesc(d Describer, p *DescProfile, low bool) *EvalDesc {
+	if d == nil {
+		return nil
+	}
+	if ev, ok := d.(*Eval); ok {
+		return ev.ProfileDesc(p, low)
+	}
+	desc := d.Desc(low)
+	if desc != nil {
+		desc.f = p.Func(desc.Type, low)
+	}
+	return desc
 }
 
 // Format satisfies the [fmt.Formatter] interface.
@@ -803,7 +2578,7 @@ func (win *Win) Format(f fmt.State, verb rune) {
 		}
 		fmt.Fprint(f, strings.Join(v, ", ")+" "+win.Verb())
 	case 's':
-		win.Evals[win.Order[0]].Desc(win.Low).Format(f, 's')
+		win.desc(0).Format(f, 's')
 	case 'S':
 		if !win.Invalid() {
 			var v []string
@@ -824,8 +2599,7 @@ func (win *Win) Format(f fmt.State, verb rune) {
 	case 'v':
 		var v []string
 		for i := range win.Pivot {
-			desc := win.Evals[win.Order[i]].Desc(win.Low)
-			v = append(v, fmt.Sprintf("%v", desc.Best))
+			v = append(v, fmt.Sprintf("%v", win.desc(i).Best))
 		}
 		fmt.Fprint(f, strings.Join(v, ", "))
 	default:
@@ -833,17 +2607,79 @@ func (win *Win) Format(f fmt.State, verb rune) {
 	}
 }
 
-// Verb returns the win verb.
+// Verb returns the win verb. See [Win.Kind] for producing localized or
+// custom result sentences.
 func (win *Win) Verb() string {
+	kind := win.Kind()
+	if kind == WinThreeQuarters {
+		kind = WinWins
+	}
+	return kind.Name()
+}
+
+// Kind returns the win kind.
+func (win *Win) Kind() WinKind {
 	switch {
 	case win.Scoop:
-		return "scoops"
+		return WinScoop
+	case win.ThreeQuarters && win.Pivot == 1:
+		return WinThreeQuarters
 	case win.Pivot > 2:
-		return "push"
+		return WinPush
 	case win.Pivot == 2:
-		return "split"
+		return WinSplit
 	case win.Pivot == 0:
+		return WinNone
+	}
+	return WinWins
+}
+
+// Winners returns the winning positions.
+func (win *Win) Winners() []int {
+	return win.Order[:win.Pivot]
+}
+
+// WinKind is a win kind.
+type WinKind uint8
+
+// Win kinds.
+const (
+	// WinNone is when there are no winners.
+	WinNone WinKind = iota
+	// WinWins is a single winner.
+	WinWins
+	// WinSplit is 2 winners splitting.
+	WinSplit
+	// WinPush is more than 2 winners pushing.
+	WinPush
+	// WinScoop is a single winner scooping both the Hi and Lo.
+	WinScoop
+	// WinThreeQuarters is a single winner winning one of the Hi or Lo
+	// outright, and splitting the other with exactly one other position (see
+	// [Result.ThreeQuarters]).
+	WinThreeQuarters
+)
+
+// Name returns the win kind name.
+func (kind WinKind) Name() string {
+	switch kind {
+	case WinNone:
 		return "none"
+	case WinWins:
+		return "wins"
+	case WinSplit:
+		return "split"
+	case WinPush:
+		return "push"
+	case WinScoop:
+		return "scoops"
+	case WinThreeQuarters:
+		return "wins three quarters"
 	}
-	return "wins"
+	return ""
+}
+
+// MarshalText satisfies the [encoding.TextMarshaler] interface.
+func (kind WinKind) MarshalText() ([]byte, error) {
+	return []byte(kind.Name()), nil
 }
@@ -3,7 +3,9 @@ package cardrank
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"math"
 	"math/rand"
 	"reflect"
 	"slices"
@@ -23,6 +25,7 @@ func TestDeck(t *testing.T) {
 		{32, DeckManila, "789TJQKA"},
 		{28, DeckSpanish, "89TJQKA"},
 		{20, DeckRoyal, "TJQKA"},
+		{53, DeckJoker, "23456789TJQKA"},
 	}
 	for _, test := range tests {
 		t.Run(test.typ.Name(), func(t *testing.T) {
@@ -157,6 +160,125 @@ func testDeckShoe(t *testing.T, exp int, typ DeckType) {
 	}
 }
 
+func TestDeckPeekDrawAt(t *testing.T) {
+	d := DeckOf(Must("2c 3c 4c 5c 6c 7c")...)
+	if v, exp := d.Peek(3), Must("2c 3c 4c"); !slices.Equal(v, exp) {
+		t.Errorf("expected %v, got: %v", exp, v)
+	}
+	if n := d.Remaining(); n != 6 {
+		t.Errorf("expected %d, got: %d", 6, n)
+	}
+	if c, exp := d.DrawAt(2), Must("4c")[0]; c != exp {
+		t.Errorf("expected %v, got: %v", exp, c)
+	}
+	if v, exp := d.Peek(10), Must("2c 3c 5c 6c 7c"); !slices.Equal(v, exp) {
+		t.Errorf("expected %v, got: %v", exp, v)
+	}
+	if c, exp := d.DrawAt(0), Must("2c")[0]; c != exp {
+		t.Errorf("expected %v, got: %v", exp, c)
+	}
+	if c, exp := d.DrawAt(3), Must("7c")[0]; c != exp {
+		t.Errorf("expected %v, got: %v", exp, c)
+	}
+	if c := d.DrawAt(3); c != InvalidCard {
+		t.Errorf("expected %v, got: %v", InvalidCard, c)
+	}
+	if v, exp := d.Draw(10), Must("3c 5c 6c"); !slices.Equal(v, exp) {
+		t.Errorf("expected %v, got: %v", exp, v)
+	}
+	if v := d.Peek(1); v != nil {
+		t.Errorf("expected nil, got: %v", v)
+	}
+	d.Reset()
+	if v, exp := d.Draw(6), Must("4c 2c 7c 3c 5c 6c"); !slices.Equal(v, exp) {
+		t.Errorf("expected %v, got: %v", exp, v)
+	}
+}
+
+func TestDeckCards(t *testing.T) {
+	for _, typ := range []DeckType{DeckFrench, DeckShort, DeckJoker} {
+		if v, exp := slices.Collect(typ.All()), typ.Unshuffled(); !slices.Equal(v, exp) {
+			t.Errorf("%s expected %v, got: %v", typ, exp, v)
+		}
+		d := typ.Shuffle(rand.New(rand.NewSource(1)), 1)
+		d.Draw(5)
+		if v, exp := slices.Collect(d.Cards()), d.All(); !slices.Equal(v, exp) {
+			t.Errorf("%s expected %v, got: %v", typ, exp, v)
+		}
+		if n := d.Remaining(); n != len(typ.Unshuffled())-5 {
+			t.Errorf("%s expected %d, got: %d", typ, len(typ.Unshuffled())-5, n)
+		}
+	}
+}
+
+func TestStrippedDeck(t *testing.T) {
+	missing := Must("Ah 7c")
+	d := DeckFrench.Strip(missing...)
+	v := d.Unshuffled()
+	switch {
+	case len(v) != 50:
+		t.Fatalf("expected 50, got: %d", len(v))
+	case slices.Contains(v, missing[0]), slices.Contains(v, missing[1]):
+		t.Errorf("expected %v to be missing", missing)
+	}
+	if u := slices.Collect(d.All()); !slices.Equal(u, v) {
+		t.Errorf("expected %v, got: %v", v, u)
+	}
+	if n := len(d.Exclude(Must("Kh Qh"))); n != 48 {
+		t.Errorf("expected 48, got: %d", n)
+	}
+	deck := d.Shuffle(rand.New(rand.NewSource(1)), 1)
+	if n := deck.Remaining(); n != 50 {
+		t.Errorf("expected 50, got: %d", n)
+	}
+	for c := range deck.Cards() {
+		if slices.Contains(missing, c) {
+			t.Errorf("expected %s to be missing", c)
+		}
+	}
+	if n := d.Shoe(2).Remaining(); n != 100 {
+		t.Errorf("expected 100, got: %d", n)
+	}
+}
+
+func TestHandsOfRank(t *testing.T) {
+	tests := []struct {
+		typ      DeckType
+		category EvalRank
+		exp      int
+	}{
+		{DeckFrench, StraightFlush, 40},
+		{DeckFrench, FourOfAKind, 624},
+		{DeckFrench, FullHouse, 3744},
+		{DeckFrench, Flush, 5108},
+		{DeckJoker, FullHouse, 3744},
+		{DeckRoyal, StraightFlush, 4},
+		{DeckRoyal, Straight, 1020},
+		{DeckKuhn, Nothing, 0},
+	}
+	for i, test := range tests {
+		var count int
+		test.typ.HandsOfRank(test.category, func(v []Card) bool {
+			if r := RankCactus(v[0], v[1], v[2], v[3], v[4]).Fixed(); r != test.category {
+				t.Errorf("test %d expected %n, got: %n", i, test.category, r)
+			}
+			count++
+			return true
+		})
+		if count != test.exp {
+			t.Errorf("test %d expected %d, got: %d", i, test.exp, count)
+		}
+	}
+	var count int
+	DeckFrench.HandsOfRank(FourOfAKind, func([]Card) bool {
+		count++
+		return count < 10
+	})
+	if count != 10 {
+		t.Errorf("expected 10, got: %d", count)
+	}
+}
+
 func TestDealer(t *testing.T) {
 	// seed := time.Now().UnixNano()
 	// seed := int64(1676122011905868217)
@@ -182,6 +304,38 @@ func TestDealer(t *testing.T) {
 	}
 }
 
+func TestDealerOrder(t *testing.T) {
+	tests := []struct {
+		button  int
+		reverse bool
+		exp     []int
+	}{
+		{3, false, []int{0, 1, 2, 3}},
+		{0, false, []int{1, 2, 3, 0}},
+		{2, false, []int{3, 0, 1, 2}},
+		{0, true, []int{3, 2, 1, 0}},
+		{2, true, []int{1, 0, 3, 2}},
+	}
+	for i, test := range tests {
+		d := Holdem.Dealer(rand.New(rand.NewSource(1)), 1, 4)
+		deck := d.Deck.All()
+		d.Reverse = test.reverse
+		d.SetButton(test.button)
+		if order := d.DealOrder(); !slices.Equal(order, test.exp) {
+			t.Errorf("test %d expected %v, got: %v", i, test.exp, order)
+		}
+		if !d.Next() {
+			t.Fatalf("test %d expected next", i)
+		}
+		pockets := d.Runs[0].Pockets
+		for j, pos := range test.exp {
+			if exp := []Card{deck[j], deck[j+4]}; !slices.Equal(pockets[pos], exp) {
+				t.Errorf("test %d position %d expected %v, got: %v", i, pos, exp, pockets[pos])
+			}
+		}
+	}
+}
+
 func TestDealerRuns(t *testing.T) {
 	tests := []struct {
 		typ   Type
@@ -205,7 +359,13 @@ func TestDealerRuns(t *testing.T) {
 					if b, exp := d.ChangeRuns(3), true; b != exp {
 						t.Fatalf("expected %t, got: %t", exp, b)
 					}
-					if b, exp := d.Deactivate(3, 4), true; b != exp {
+					var positions []int
+					for _, position := range []int{3, 4} {
+						if position < d.Count {
+							positions = append(positions, position)
+						}
+					}
+					if b, exp := d.Deactivate(positions...), true; b != exp {
 						t.Fatalf("expected %t, got: %t", exp, b)
 					}
 				}
@@ -214,6 +374,366 @@ func TestDealerRuns(t *testing.T) {
 	}
 }
 
+func TestDealerChangeRuns(t *testing.T) {
+	d := Holdem.Dealer(rand.New(rand.NewSource(1)), 1, 2)
+	deck := d.Deck.All()
+	if d.ChangeRuns(2) {
+		t.Fatalf("expected false prior to dealing")
+	}
+	// pre-flop, flop
+	for range 2 {
+		if !d.Next() {
+			t.Fatalf("expected next")
+		}
+	}
+	if !d.ChangeRuns(2) {
+		t.Fatalf("expected true")
+	}
+	// turn, river
+	for range 2 {
+		if !d.Next() {
+			t.Fatalf("expected next")
+		}
+	}
+	if d.ChangeRuns(0) {
+		t.Errorf("expected false")
+	}
+	if !d.ChangeRuns(3) {
+		t.Fatalf("expected true")
+	}
+	if n := len(d.Runs); n != 3 {
+		t.Fatalf("expected %d runs, got: %d", 3, n)
+	}
+	// run 1 turn
+	if !d.Next() {
+		t.Fatalf("expected next")
+	}
+	if r, _ := d.Run(); r != 1 {
+		t.Fatalf("expected run %d, got: %d", 1, r)
+	}
+	if d.ChangeRuns(1) {
+		t.Errorf("expected false when removing dealt run")
+	}
+	if !d.ChangeRuns(2) {
+		t.Fatalf("expected true")
+	}
+	// run 1 river
+	if !d.Next() {
+		t.Fatalf("expected next")
+	}
+	if d.Next() {
+		t.Fatalf("expected no next")
+	}
+	if n := len(d.Runs); n != 2 {
+		t.Fatalf("expected %d runs, got: %d", 2, n)
+	}
+	// 4 pocket cards, 3 flop cards, 2 turn and 2 river cards, and 5 discards
+	if exp, n := len(deck)-16, d.Deck.Remaining(); n != exp {
+		t.Errorf("expected %d remaining, got: %d", exp, n)
+	}
+	if exp := []Card{deck[5], deck[6], deck[7], deck[9], deck[11]}; !slices.Equal(d.Runs[0].Hi, exp) {
+		t.Errorf("expected %v, got: %v", exp, d.Runs[0].Hi)
+	}
+	if exp := []Card{deck[5], deck[6], deck[7], deck[13], deck[15]}; !slices.Equal(d.Runs[1].Hi, exp) {
+		t.Errorf("expected %v, got: %v", exp, d.Runs[1].Hi)
+	}
+	for i := range 2 {
+		if !slices.Equal(d.Runs[0].Pockets[i], d.Runs[1].Pockets[i]) {
+			t.Errorf("expected %v, got: %v", d.Runs[0].Pockets[i], d.Runs[1].Pockets[i])
+		}
+	}
+	for i, exp := range [][]Card{{deck[4]}, {deck[4]}} {
+		if v := d.Runs[i].DiscardedOn('f'); !slices.Equal(v, exp) {
+			t.Errorf("run %d expected %v, got: %v", i, exp, v)
+		}
+	}
+	for i, exp := range [][]Card{{deck[8]}, {deck[12]}} {
+		if v := d.Runs[i].DiscardedOn('t'); !slices.Equal(v, exp) {
+			t.Errorf("run %d expected %v, got: %v", i, exp, v)
+		}
+	}
+	if !d.NextResult() {
+		t.Fatalf("expected result")
+	}
+	if d.ChangeRuns(3) {
+		t.Errorf("expected false after results")
+	}
+}
+
+func TestDealerAudit(t *testing.T) {
+	d := Holdem.Dealer(rand.New(rand.NewSource(1)), 1, 2)
+	deck := d.Deck.All()
+	// pre-flop, flop
+	for range 2 {
+		if !d.Next() {
+			t.Fatalf("expected next")
+		}
+	}
+	if !d.ChangeRuns(2) {
+		t.Fatalf("expected true")
+	}
+	for d.Next() {
+	}
+	audit := d.Audit()
+	if n, exp := len(audit), 4+4+4+4; n != exp {
+		t.Fatalf("expected %d entries, got: %d", exp, n)
+	}
+	for i, entry := range audit {
+		if entry.Index != i || entry.Card != deck[i] {
+			t.Errorf("entry %d expected %d %v, got: %d %v", i, i, deck[i], entry.Index, entry.Card)
+		}
+	}
+	exp := []AuditEntry{
+		{0, "Pre-Flop", 0, AuditPocket, 0, 0, deck[0]},
+		{0, "Pre-Flop", 0, AuditPocket, 1, 1, deck[1]},
+		{1, "Flop", 0, AuditDiscard, -1, 4, deck[4]},
+		{1, "Flop", 0, AuditHi, -1, 5, deck[5]},
+		{2, "Turn", 1, AuditDiscard, -1, 12, deck[12]},
+		{3, "River", 1, AuditHi, -1, 15, deck[15]},
+	}
+	for i, j := range []int{0, 1, 4, 5, 12, 15} {
+		if audit[j] != exp[i] {
+			t.Errorf("entry %d expected %v, got: %v", j, exp[i], audit[j])
+		}
+	}
+	buf := new(bytes.Buffer)
+	if err := d.WriteAudit(buf); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if s := buf.String(); !strings.Contains(s, `"Purpose": "discard"`) || !strings.Contains(s, `"Card": "`+deck[4].String()+`"`) {
+		t.Errorf("expected purpose and card, got: %s", s)
+	}
+	d.Reset()
+	if n := len(d.Audit()); n != 0 {
+		t.Errorf("expected %d, got: %d", 0, n)
+	}
+}
+
+func TestDealerDiscardedOn(t *testing.T) {
+	v := DeckFrench.Unshuffled()
+	d := NewDealer(Holdem.Desc(), DeckOf(v...), 2)
+	exp := map[byte][]Card{
+		'p': nil,
+		'f': {v[4]},
+		't': {v[8]},
+		'r': {v[10]},
+	}
+	var all []Card
+	for d.Next() {
+		id := d.Id()
+		if s := d.DiscardedOn(id); !slices.Equal(s, exp[id]) {
+			t.Errorf("street %c expected %v, got: %v", id, exp[id], s)
+		}
+		all = append(all, exp[id]...)
+		if s := d.Discarded(); !slices.Equal(s, all) {
+			t.Errorf("street %c expected %v, got: %v", id, all, s)
+		}
+	}
+	if s := d.DiscardedOn('x'); s != nil {
+		t.Errorf("expected nil, got: %v", s)
+	}
+	// duplicated runs copy the discards
+	_, run := d.Run()
+	dupe := run.Dupe()
+	for id, v := range exp {
+		if s := dupe.DiscardedOn(id); !slices.Equal(s, v) {
+			t.Errorf("street %c expected %v, got: %v", id, v, s)
+		}
+	}
+	dupe.discard('f', v[20:21])
+	if s := run.DiscardedOn('f'); !slices.Equal(s, exp['f']) {
+		t.Errorf("expected %v, got: %v", exp['f'], s)
+	}
+}
+
+func TestDealerRedealStreet(t *testing.T) {
+	v := DeckFrench.Unshuffled()
+	d := NewDealer(Holdem.Desc(), DeckOf(v...), 2)
+	if d.RedealStreet() {
+		t.Fatalf("expected false")
+	}
+	for d.Next() && d.Id() != 'f' {
+	}
+	_, run := d.Run()
+	if exp := v[5:8]; !slices.Equal(run.Hi, exp) {
+		t.Fatalf("expected %v, got: %v", exp, run.Hi)
+	}
+	if !d.RedealStreet() {
+		t.Fatalf("expected true")
+	}
+	if exp := v[9:12]; !slices.Equal(run.Hi, exp) {
+		t.Errorf("expected %v, got: %v", exp, run.Hi)
+	}
+	if exp := v[8:9]; !slices.Equal(d.Discarded(), exp) || !slices.Equal(d.DiscardedOn('f'), exp) {
+		t.Errorf("expected %v, got: %v / %v", exp, d.Discarded(), d.DiscardedOn('f'))
+	}
+	if exp := v[4:8]; !slices.Equal(d.Muck(), exp) {
+		t.Errorf("expected %v, got: %v", exp, d.Muck())
+	}
+	var muck []Card
+	for _, entry := range d.Audit() {
+		if entry.Purpose == AuditMuck {
+			muck = append(muck, entry.Card)
+		}
+	}
+	if exp := v[4:8]; !slices.Equal(muck, exp) {
+		t.Errorf("expected %v, got: %v", exp, muck)
+	}
+	if exp := [][]Card{{v[0], v[2]}, {v[1], v[3]}}; !reflect.DeepEqual(run.Pockets, exp) {
+		t.Errorf("expected %v, got: %v", exp, run.Pockets)
+	}
+	for d.Next() {
+	}
+	if exp := []Card{v[9], v[10], v[11], v[13], v[15]}; !slices.Equal(run.Hi, exp) {
+		t.Errorf("expected %v, got: %v", exp, run.Hi)
+	}
+	for d.NextResult() {
+	}
+	if d.RedealStreet() {
+		t.Errorf("expected false")
+	}
+	d.Reset()
+	if d.Muck() != nil {
+		t.Errorf("expected nil muck")
+	}
+}
+
+func TestDealerUndo(t *testing.T) {
+	v := DeckFrench.Unshuffled()
+	d := NewDealer(Holdem.Desc(), DeckOf(v...), 2)
+	if d.Undo() {
+		t.Fatalf("expected false")
+	}
+	// undo pre-flop
+	if !d.Next() || !d.Undo() {
+		t.Fatalf("expected true")
+	}
+	run := d.Runs[0]
+	if d.Street() != -1 || d.Deck.Remaining() != 52 || len(run.Pockets[0]) != 0 || len(d.Audit()) != 0 {
+		t.Fatalf("expected initial state, got: %d %d %v %d", d.Street(), d.Deck.Remaining(), run.Pockets, len(d.Audit()))
+	}
+	for d.Next() && d.Id() != 'f' {
+	}
+	n, audit := d.Deck.Remaining(), len(d.Audit())
+	if !d.Next() || d.Id() != 't' {
+		t.Fatalf("expected turn")
+	}
+	if !d.Undo() {
+		t.Fatalf("expected true")
+	}
+	if d.Undo() {
+		t.Errorf("expected false")
+	}
+	if d.Id() != 'f' || d.Deck.Remaining() != n || len(d.Audit()) != audit {
+		t.Errorf("expected %c %d %d, got: %c %d %d", 'f', n, audit, d.Id(), d.Deck.Remaining(), len(d.Audit()))
+	}
+	if exp := v[5:8]; !slices.Equal(run.Hi, exp) {
+		t.Errorf("expected %v, got: %v", exp, run.Hi)
+	}
+	if exp := v[4:5]; !slices.Equal(d.Discarded(), exp) || d.DiscardedOn('t') != nil {
+		t.Errorf("expected %v, got: %v / %v", exp, d.Discarded(), d.DiscardedOn('t'))
+	}
+	// redeal and undo
+	if !d.Next() || !d.RedealStreet() || !d.Undo() {
+		t.Fatalf("expected true")
+	}
+	if len(d.Muck()) != 0 || d.Deck.Remaining() != n {
+		t.Errorf("expected no muck and %d remaining, got: %v %d", n, d.Muck(), d.Deck.Remaining())
+	}
+	for d.Next() {
+	}
+	if exp := []Card{v[5], v[6], v[7], v[9], v[11]}; !slices.Equal(run.Hi, exp) {
+		t.Errorf("expected %v, got: %v", exp, run.Hi)
+	}
+	for d.NextResult() {
+	}
+	if d.Undo() {
+		t.Errorf("expected false")
+	}
+	// changed runs
+	d = NewDealer(Holdem.Desc(), DeckOf(v...), 2)
+	for d.Next() && d.Id() != 't' {
+	}
+	if !d.ChangeRuns(2) {
+		t.Fatalf("expected true")
+	}
+	if d.Undo() {
+		t.Errorf("expected false")
+	}
+	if !d.Next() || !d.Undo() || d.Id() != 't' {
+		t.Errorf("expected undo of river, got: %c", d.Id())
+	}
+	// runs changed after the first change
+	if !d.Next() || !d.ChangeRuns(3) {
+		t.Fatalf("expected true")
+	}
+	if d.Undo() {
+		t.Errorf("expected false")
+	}
+	if !d.Next() || !d.Undo() || d.Id() != 'r' {
+		t.Errorf("expected undo of second run river, got: %c", d.Id())
+	}
+}
+
+func TestDealerPocketDelta(t *testing.T) {
+	d := NewDealer(Omaha.Desc(), DeckFrench.Shuffle(rand.New(rand.NewSource(0)), 1), 3)
+	for _, test := range []struct {
+		id       byte
+		position int
+		delta    int
+		exp      error
+	}{
+		{'p', 3, 1, ErrInvalidPosition},
+		{'p', -1, 1, ErrInvalidPosition},
+		{'x', 0, 1, ErrInvalidStreet},
+		{'p', 0, -5, ErrInvalidStreet},
+		{'p', 1, 1, nil},
+		{'p', 2, -1, nil},
+		{'f', 0, 1, nil},
+	} {
+		if err := d.SetPocketDelta(test.id, test.position, test.delta); !errors.Is(err, test.exp) {
+			t.Fatalf("%c %d %d expected %v, got: %v", test.id, test.position, test.delta, test.exp, err)
+		}
+	}
+	if !d.Next() {
+		t.Fatalf("expected true")
+	}
+	for i, exp := range []int{4, 5, 3} {
+		if n := d.PocketFor(i); n != exp {
+			t.Errorf("position %d expected %d, got: %d", i, exp, n)
+		}
+		if n := len(d.Runs[0].Pockets[i]); n != exp {
+			t.Errorf("position %d expected %d cards, got: %d", i, exp, n)
+		}
+	}
+	if d.HasCalc() {
+		t.Errorf("expected no calc")
+	}
+	if err := d.SetPocketDelta('p', 0, 1); !errors.Is(err, ErrInvalidStreet) {
+		t.Errorf("expected %v, got: %v", ErrInvalidStreet, err)
+	}
+	for d.Next() {
+	}
+	for i, exp := range []int{5, 5, 3} {
+		if n := len(d.Runs[0].Pockets[i]); n != exp {
+			t.Errorf("position %d expected %d cards, got: %d", i, exp, n)
+		}
+	}
+	if !d.NextResult() {
+		t.Fatalf("expected result")
+	}
+	_, res := d.Result()
+	for i, ev := range res.Evals {
+		if ev.HiRank == 0 || ev.HiRank == Invalid {
+			t.Errorf("position %d expected valid rank, got: %d", i, ev.HiRank)
+		}
+	}
+	d.Reset()
+	if d.Next(); len(d.Runs[0].Pockets[1]) != 4 {
+		t.Errorf("expected deltas cleared, got: %d", len(d.Runs[0].Pockets[1]))
+	}
+}
+
 type dealFunc func(r *rand.Rand, d *Dealer)
 
 func testDealer(t *testing.T, typ Type, count int, seed int64, f dealFunc) {
@@ -351,6 +871,153 @@ func testHasNext(t *testing.T, typ Type, exp, count int) {
 	}
 }
 
+func TestResultGuts(t *testing.T) {
+	tests := []struct {
+		v      []string
+		active map[int]bool
+		pot    float64
+		exp    map[int]float64
+		next   float64
+	}{
+		{[]string{"Ah Kh Qh", "As Ad 2c", "Ks Qd 9c"}, map[int]bool{0: true, 1: true, 2: true}, 10, map[int]float64{0: 10, 1: -10, 2: -10}, 20},
+		{[]string{"Ah Kh Qh", "As Ad 2c", "Ks Qd 9c"}, map[int]bool{1: true, 2: true}, 10, map[int]float64{1: 10, 2: -10}, 10},
+		{[]string{"Ah Kh Qh", "As Ad 2c", "Ks Qd 9c"}, map[int]bool{1: true}, 10, map[int]float64{1: 10}, 0},
+		{[]string{"Ah Kh Qh", "As Ad 2c", "Ks Qd 9c"}, map[int]bool{}, 10, map[int]float64{}, 10},
+		{[]string{"Ah Kd 2c", "As Kc 2d", "Ks Qd 9c"}, map[int]bool{0: true, 1: true, 2: true}, 10, map[int]float64{0: 5, 1: 5, 2: -10}, 10},
+	}
+	for i, test := range tests {
+		run := NewRun(len(test.v))
+		for j, s := range test.v {
+			run.Pockets[j] = Must(s)
+		}
+		res := NewResult(Guts, run, test.active, false)
+		m, next := res.Guts(test.pot)
+		if !reflect.DeepEqual(m, test.exp) {
+			t.Errorf("test %d expected %v, got: %v", i, test.exp, m)
+		}
+		if next != test.next {
+			t.Errorf("test %d expected next %f, got: %f", i, test.next, next)
+		}
+	}
+}
+
+func TestResultScoop(t *testing.T) {
+	tests := []struct {
+		hiOrder  []int
+		hiPivot  int
+		loOrder  []int
+		loPivot  int
+		scoop    int
+		quarters int
+		verb     string
+	}{
+		{[]int{0, 1, 2}, 1, []int{0, 1, 2}, 0, 0, -1, "scoops"},
+		{[]int{0, 1, 2}, 1, []int{0, 1, 2}, 1, 0, -1, "scoops"},
+		{[]int{0, 1, 2}, 1, []int{1, 0, 2}, 1, -1, -1, "wins"},
+		{[]int{0, 1, 2}, 1, []int{1, 0, 2}, 2, -1, 0, "wins"},
+		{[]int{1, 2, 0}, 2, []int{2, 0, 1}, 1, -1, 2, "split"},
+		{[]int{1, 2, 0}, 2, []int{0, 1, 2}, 1, -1, -1, "split"},
+		{[]int{1, 2, 0}, 2, []int{1, 2, 0}, 2, -1, -1, "split"},
+		{[]int{0, 1, 2}, 1, []int{1, 2, 0}, 2, -1, -1, "wins"},
+		{[]int{0, 1, 2}, 1, nil, 0, 0, -1, "scoops"},
+		{[]int{1, 2, 0}, 2, nil, 0, -1, -1, "split"},
+	}
+	for i, test := range tests {
+		res := &Result{
+			Evals:   []*Eval{EvalOf(OmahaHiLo), EvalOf(OmahaHiLo), EvalOf(OmahaHiLo)},
+			HiOrder: test.hiOrder,
+			HiPivot: test.hiPivot,
+			LoOrder: test.loOrder,
+			LoPivot: test.loPivot,
+		}
+		if pos, ok := res.Scoop(); pos != test.scoop || ok != (test.scoop != -1) {
+			t.Errorf("test %d expected scoop %d, got: %d", i, test.scoop, pos)
+		}
+		if pos, ok := res.ThreeQuarters(); pos != test.quarters || ok != (test.quarters != -1) {
+			t.Errorf("test %d expected three quarters %d, got: %d", i, test.quarters, pos)
+		}
+		hi, lo := res.Win()
+		if s := hi.Verb(); s != test.verb {
+			t.Errorf("test %d expected %q, got: %q", i, test.verb, s)
+		}
+		if hi.Scoop != (test.scoop != -1) {
+			t.Errorf("test %d expected hi scoop %t, got: %t", i, test.scoop != -1, hi.Scoop)
+		}
+		if lo != nil && lo.Scoop != (test.scoop != -1) {
+			t.Errorf("test %d expected lo scoop %t, got: %t", i, test.scoop != -1, lo.Scoop)
+		}
+		if hi.ThreeQuarters != (test.quarters != -1) {
+			t.Errorf("test %d expected three quarters %t, got: %t", i, test.quarters != -1, hi.ThreeQuarters)
+		}
+		if test.quarters != -1 && lo.Pivot == 1 {
+			if kind := lo.Kind(); kind != WinThreeQuarters {
+				t.Errorf("test %d expected lo %s, got: %s", i, WinThreeQuarters.Name(), kind.Name())
+			}
+		}
+	}
+}
+
+func TestResultSplit(t *testing.T) {
+	tests := []struct {
+		hiOrder []int
+		hiPivot int
+		loOrder []int
+		loPivot int
+		exp     []float64
+	}{
+		{[]int{0, 1, 2}, 1, nil, 0, []float64{120, 0, 0}},
+		{[]int{1, 2, 0}, 2, nil, 0, []float64{0, 60, 60}},
+		{[]int{0, 1, 2}, 1, []int{0, 1, 2}, 0, []float64{120, 0, 0}},
+		{[]int{0, 1, 2}, 1, []int{1, 0, 2}, 1, []float64{60, 60, 0}},
+		{[]int{0, 1, 2}, 1, []int{1, 2, 0}, 2, []float64{60, 30, 30}},
+		{[]int{1, 2, 0}, 2, []int{1, 0, 2}, 1, []float64{0, 90, 30}},
+	}
+	for i, test := range tests {
+		res := &Result{
+			Evals:   []*Eval{EvalOf(OmahaHiLo), EvalOf(OmahaHiLo), EvalOf(OmahaHiLo)},
+			HiOrder: test.hiOrder,
+			HiPivot: test.hiPivot,
+			LoOrder: test.loOrder,
+			LoPivot: test.loPivot,
+		}
+		v := res.Split(120)
+		for j := range test.exp {
+			if v[j] != test.exp[j] {
+				t.Errorf("test %d expected %v, got: %v", i, test.exp, v)
+				break
+			}
+		}
+	}
+}
+
+func TestWinKind(t *testing.T) {
+	tests := []struct {
+		order []int
+		pivot int
+		scoop bool
+		exp   WinKind
+		verb  string
+	}{
+		{[]int{0, 1, 2}, 0, false, WinNone, "none"},
+		{[]int{1, 0, 2}, 1, false, WinWins, "wins"},
+		{[]int{1, 2, 0}, 2, false, WinSplit, "split"},
+		{[]int{2, 0, 1}, 3, false, WinPush, "push"},
+		{[]int{0, 1, 2}, 1, true, WinScoop, "scoops"},
+	}
+	for i, test := range tests {
+		win := NewWin(nil, test.order, test.pivot, false, test.scoop, nil)
+		if kind := win.Kind(); kind != test.exp {
+			t.Errorf("test %d expected %d, got: %d", i, test.exp, kind)
+		}
+		if s := win.Verb(); s != test.verb {
+			t.Errorf("test %d expected %q, got: %q", i, test.verb, s)
+		}
+		if v := win.Winners(); !slices.Equal(v, test.order[:test.pivot]) {
+			t.Errorf("test %d expected %v, got: %v", i, test.order[:test.pivot], v)
+		}
+	}
+}
+
 func TestRunOut(t *testing.T) {
 	// seed := time.Now().UnixNano()
 	const seed = 1679273183508957122
@@ -478,3 +1145,656 @@ func sidebyside(t *testing.T, pad, gap string, v ...string) {
 		t.Log(s)
 	}
 }
+
+func TestDealerAutoCalc(t *testing.T) {
+	ctx := context.Background()
+	d := Holdem.Dealer(rand.New(rand.NewSource(0)), 1, 3, WithAutoCalc(ctx, false))
+	if hi, lo, err := d.Odds(); hi != nil || lo != nil || err != nil {
+		t.Fatalf("expected no odds, got: %v %v %v", hi, lo, err)
+	}
+	d.Deactivate(2)
+	for d.Next() {
+		hi, _, err := d.Odds()
+		exp, _, _ := d.Calc(ctx, false)
+		switch {
+		case err != nil:
+			t.Fatalf("street %c expected no error, got: %v", d.Id(), err)
+		case hi == nil:
+			t.Fatalf("street %c expected odds", d.Id())
+		case !reflect.DeepEqual(hi, exp):
+			t.Errorf("street %c expected %v, got: %v", d.Id(), exp, hi)
+		}
+	}
+	// cancelled context
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	d = Holdem.Dealer(rand.New(rand.NewSource(0)), 1, 2, WithAutoCalc(ctx, false))
+	for d.Next() {
+		if d.Id() == 'f' {
+			break
+		}
+	}
+	if _, _, err := d.Odds(); !errors.Is(err, ErrContextCancelled) {
+		t.Errorf("expected error %v, got: %v", ErrContextCancelled, err)
+	}
+	// no odds without auto calc
+	d = Holdem.Dealer(rand.New(rand.NewSource(0)), 1, 2)
+	for d.Next() {
+		if hi, _, _ := d.Odds(); hi != nil {
+			t.Fatalf("street %c expected no odds", d.Id())
+		}
+	}
+}
+
+func TestDealerSummaryUncontested(t *testing.T) {
+	d := Holdem.Dealer(rand.New(rand.NewSource(1)), 1, 3)
+	d.Next()
+	d.Deactivate(0, 2)
+	for d.Next() {
+	}
+	for d.NextResult() {
+	}
+	var buf bytes.Buffer
+	if err := d.WriteSummary(&buf); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if s, exp := buf.String(), "    0: inactive\n    1: [] [] None\n    2: inactive\n"; !strings.Contains(s, exp) {
+		t.Errorf("expected summary to contain %q, got: %q", exp, s)
+	}
+}
+
+func TestRandomHand(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	board := Must("Ah 7h 2c")
+	tests := []struct {
+		n           int
+		board       []Card
+		constraints []HandConstraint
+	}{
+		{2, nil, []HandConstraint{HandPair(Ten)}},
+		{2, board, []HandConstraint{HandFlushDraw()}},
+		{4, nil, []HandConstraint{HandDoubleSuited()}},
+		{2, nil, []HandConstraint{HandSuited(), HandRanks(Ten, Ace)}},
+		{4, board, []HandConstraint{HandDoubleSuited(), HandFlushDraw()}},
+	}
+	for i, test := range tests {
+		for range 20 {
+			hand, ok := DeckFrench.RandomHand(r, test.n, test.board, 10000, Must("Kh"), test.constraints...)
+			switch {
+			case !ok:
+				t.Fatalf("test %d expected ok", i)
+			case len(hand) != test.n:
+				t.Fatalf("test %d expected %d cards, got: %d", i, test.n, len(hand))
+			case !satisfies(hand, test.board, test.constraints):
+				t.Errorf("test %d expected %v to satisfy constraints", i, hand)
+			case slices.Contains(hand, Must("Kh")[0]):
+				t.Errorf("test %d expected %v to exclude Kh", i, hand)
+			}
+			for _, c := range test.board {
+				if slices.Contains(hand, c) {
+					t.Errorf("test %d expected %v to exclude %s", i, hand, c)
+				}
+			}
+		}
+	}
+	constraintTests := []struct {
+		f     HandConstraint
+		hand  string
+		board string
+		exp   bool
+	}{
+		{HandPair(Ten), "Th Tc", "", true},
+		{HandPair(Ten), "9h 9c", "", false},
+		{HandPair(Ten), "Th 9c Ts", "", true},
+		{HandRanks(Ten, Ace), "Th Ac", "", true},
+		{HandRanks(Ten, Ace), "Th 9c", "", false},
+		{HandSuited(), "Th Ah", "", true},
+		{HandSuited(), "Th Ac", "", false},
+		{HandDoubleSuited(), "Ah Kh Qs Js", "", true},
+		{HandDoubleSuited(), "Ah Kh Qh Js", "", false},
+		{HandDoubleSuited(), "Ah Kh Qs Jd", "", false},
+		{HandFlushDraw(), "Kh 3h", "Ah 7h 2c", true},
+		{HandFlushDraw(), "Kh 3s", "Ah 7h 2h", true},
+		{HandFlushDraw(), "Kh 3h", "Ah 7h 2h", false},
+		{HandFlushDraw(), "Kc 3s", "Ah 7h 2h 9h", false},
+		{HandDoubleSuited(), "Ah Kh Jk Js", "", false},
+		{HandFlushDraw(), "Jk 3s", "As 7s 2h", false},
+		{HandFlushDraw(), "Kh 3s", "Jk 7s 2s", false},
+	}
+	for i, test := range constraintTests {
+		if b := test.f(Must(test.hand), Must(test.board)); b != test.exp {
+			t.Errorf("test %d expected %t, got: %t", i, test.exp, b)
+		}
+	}
+	if _, ok := DeckFrench.RandomHand(r, 2, nil, 100, nil, HandRanks(Ace, Two)); ok {
+		t.Errorf("expected not ok")
+	}
+}
+
+func TestDealerPost(t *testing.T) {
+	d := NewDealer(Holdem.Desc(), DeckOf(DeckFrench.Unshuffled()...), 4)
+	d.SetButton(3)
+	d.Deactivate(2)
+	if err := d.PostAntes(5); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if err := d.PostBlinds(50, 100); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if err := d.Post(2, "Dead Blind", 100); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	exp := []Post{
+		{0, "Ante", 5},
+		{1, "Ante", 5},
+		{3, "Ante", 5},
+		{0, "Small Blind", 50},
+		{1, "Big Blind", 100},
+		{2, "Dead Blind", 100},
+	}
+	if posts := d.Posts(); !slices.Equal(posts, exp) {
+		t.Errorf("expected %v, got: %v", exp, posts)
+	}
+	if err := d.Post(4, "Ante", 5); !errors.Is(err, ErrInvalidPosition) {
+		t.Errorf("expected error %v, got: %v", ErrInvalidPosition, err)
+	}
+	d.Next()
+	if err := d.Post(0, "Ante", 5); !errors.Is(err, ErrInvalidStreet) {
+		t.Errorf("expected error %v, got: %v", ErrInvalidStreet, err)
+	}
+	var buf bytes.Buffer
+	if err := d.WriteSummary(&buf, "Alice", "Bob"); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if s, exp := buf.String(), "Posts:\n  0 Alice: Ante 5\n  1 Bob: Ante 5\n  3: Ante 5\n  0 Alice: Small Blind 50\n  1 Bob: Big Blind 100\n  2: Dead Blind 100\nRun 0:\n"; !strings.Contains(s, exp) {
+		t.Errorf("expected summary to contain %q, got: %q", exp, s)
+	}
+	d.Reset()
+	if posts := d.Posts(); posts != nil {
+		t.Errorf("expected no posts, got: %v", posts)
+	}
+}
+
+func TestDealerPostBlindsHeadsUp(t *testing.T) {
+	d := NewDealer(Holdem.Desc(), DeckOf(DeckFrench.Unshuffled()...), 3)
+	d.SetButton(2)
+	d.Deactivate(1)
+	if err := d.PostBlinds(50, 100); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	exp := []Post{
+		{2, "Small Blind", 50},
+		{0, "Big Blind", 100},
+	}
+	if posts := d.Posts(); !slices.Equal(posts, exp) {
+		t.Errorf("expected %v, got: %v", exp, posts)
+	}
+}
+
+func TestDeckRemaining(t *testing.T) {
+	d := DeckFrench.New()
+	exp := [13]int{4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4}
+	if v := d.RemainingByRank(); v != exp {
+		t.Errorf("expected %v, got: %v", exp, v)
+	}
+	d = DeckOf(Must("Ah As Kh 2c 2d")...)
+	d.Draw(1)
+	if v, exp := d.RemainingByRank(), [13]int{0: 2, 11: 1, 12: 1}; v != exp {
+		t.Errorf("expected %v, got: %v", exp, v)
+	}
+	if v, exp := d.RemainingBySuit(), [4]int{1, 1, 1, 1}; v != exp {
+		t.Errorf("expected %v, got: %v", exp, v)
+	}
+	d.Draw(4)
+	if v, exp := d.RemainingBySuit(), [4]int{}; v != exp {
+		t.Errorf("expected %v, got: %v", exp, v)
+	}
+	d = DeckOf(Must("Jk As 2c")...)
+	if v, exp := d.RemainingBySuit(), [4]int{1, 0, 0, 1}; v != exp {
+		t.Errorf("expected %v, got: %v", exp, v)
+	}
+	if v, exp := d.RemainingByRank(), [13]int{0: 1, 12: 1}; v != exp {
+		t.Errorf("expected %v, got: %v", exp, v)
+	}
+}
+
+func TestDealerVisible(t *testing.T) {
+	d := NewDealer(Stud.Desc(), DeckOf(DeckFrench.Unshuffled()...), 3)
+	d.Next()
+	_, run := d.Run()
+	p := run.Pockets
+	if v, exp := d.Visible(0), []Card{p[0][0], p[0][1], p[0][2], p[1][2], p[2][2]}; !slices.Equal(v, exp) {
+		t.Errorf("expected %v, got: %v", exp, v)
+	}
+	if v, exp := d.Visible(-1), []Card{p[0][2], p[1][2], p[2][2]}; !slices.Equal(v, exp) {
+		t.Errorf("expected %v, got: %v", exp, v)
+	}
+	ranks, suits := countCards(DeckFrench.Unshuffled())
+	for _, c := range []Card{p[0][2], p[1][2], p[2][2]} {
+		ranks[c.RankIndex()]--
+		suits[c.SuitIndex()]--
+	}
+	if v := d.UnseenByRank(-1); v != ranks {
+		t.Errorf("expected %v, got: %v", ranks, v)
+	}
+	if v := d.UnseenBySuit(-1); v != suits {
+		t.Errorf("expected %v, got: %v", suits, v)
+	}
+	d.Next()
+	if v, exp := d.Visible(-1), []Card{p[0][2], p[1][2], p[2][2], p[0][3], p[1][3], p[2][3]}; !slices.Equal(v, exp) {
+		t.Errorf("expected %v, got: %v", exp, v)
+	}
+	d = NewDealer(Holdem.Desc(), DeckOf(DeckFrench.Unshuffled()...), 2)
+	d.Next()
+	d.Next()
+	_, run = d.Run()
+	flop := slices.Clone(run.Hi)
+	if !d.RedealStreet() {
+		t.Fatalf("expected redeal")
+	}
+	if v, exp := d.Visible(-1), append(flop, run.Hi...); !slices.Equal(v, exp) {
+		t.Errorf("expected %v, got: %v", exp, v)
+	}
+	if v, exp := len(d.Visible(1)), 8; v != exp {
+		t.Errorf("expected %d, got: %d", exp, v)
+	}
+}
+
+func TestDealId(t *testing.T) {
+	d := NewSeededDealer(Short.Desc(), -1234567, 3, 6)
+	id, ok := d.DealId()
+	if !ok {
+		t.Fatalf("expected deal id")
+	}
+	s := id.String()
+	if exp := "Hs.4.-qglj.3.6"; s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+	parsed, err := ParseDealId(s)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if parsed != id {
+		t.Errorf("expected %v, got: %v", id, parsed)
+	}
+	r := parsed.Dealer()
+	for d.Next() && r.Next() {
+	}
+	if exp, v := d.Deck.All(), r.Deck.All(); !slices.Equal(exp, v) {
+		t.Errorf("expected %v, got: %v", exp, v)
+	}
+	for i := range 6 {
+		if exp, v := d.Runs[0].Pockets[i], r.Runs[0].Pockets[i]; !slices.Equal(exp, v) {
+			t.Errorf("test %d expected %v, got: %v", i, exp, v)
+		}
+	}
+	var u DealId
+	if err := u.UnmarshalText([]byte(s)); err != nil || u != id {
+		t.Errorf("expected %v, got: %v (%v)", id, u, err)
+	}
+	if _, ok := NewDealer(Holdem.Desc(), NewDeck(), 2).DealId(); ok {
+		t.Errorf("expected no deal id")
+	}
+	for i, s := range []string{"", "Hh.0.1.1", "Hh.0.1.1.0", "Zz.0.1.1.2", "Hh.z.1.1.2", "Hh.0.!.1.2", "Hh.0.1.-1.2"} {
+		if _, err := ParseDealId(s); !errors.Is(err, ErrInvalidDealId) {
+			t.Errorf("test %d expected error %v, got: %v", i, ErrInvalidDealId, err)
+		}
+	}
+}
+
+// rankDesc is a describer without an eval.
+type rankDesc struct {
+	hi, lo EvalRank
+	v      []Card
+}
+
+func (d rankDesc) Rank(low bool) (EvalRank, bool) {
+	if low {
+		return d.lo, true
+	}
+	return d.hi, true
+}
+
+func (d rankDesc) Desc(low bool) *EvalDesc {
+	if low {
+		return &EvalDesc{Type: DescLow, Rank: d.lo, Best: d.v, Qualified: d.lo != Invalid}
+	}
+	return &EvalDesc{Type: DescCactus, Rank: d.hi, Best: d.v, Qualified: true}
+}
+
+func TestResultOf(t *testing.T) {
+	v := Must("Ah Kh Qh Jh Th")
+	evs := []Describer{
+		rankDesc{10, Invalid, v},
+		nil,
+		rankDesc{1, Invalid, v},
+		rankDesc{1, 300, v},
+	}
+	if order, pivot := OrderOf(evs, false); !slices.Equal(order, []int{2, 3, 0, 1}) || pivot != 2 {
+		t.Errorf("expected [2 3 0 1] 2, got: %v %d", order, pivot)
+	}
+	if groups := OrderGroupsOf(evs, false); !reflect.DeepEqual(groups, [][]int{{2, 3}, {0}}) {
+		t.Errorf("expected [[2 3] [0]], got: %v", groups)
+	}
+	res := ResultOf(true, evs...)
+	if !slices.Equal(res.LoOrder, []int{3, 0, 2, 1}) || res.LoPivot != 1 {
+		t.Errorf("expected [3 0 2 1] 1, got: %v %d", res.LoOrder, res.LoPivot)
+	}
+	hi, lo := res.Win()
+	if s, exp := fmt.Sprintf("%d", hi), "2, 3 split"; s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+	if s, exp := fmt.Sprintf("%s", hi), "Straight Flush, Ace-high, Royal"; s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+	if s, exp := fmt.Sprintf("%d", lo), "3 wins"; s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+	if kind := lo.Kind(); kind != WinThreeQuarters {
+		t.Errorf("expected %s, got: %s", WinThreeQuarters.Name(), kind.Name())
+	}
+	if v := res.Split(100); !slices.Equal(v, []float64{0, 0, 25, 75}) {
+		t.Errorf("expected [0 0 25 75], got: %v", v)
+	}
+}
+
+func TestDealerRunReport(t *testing.T) {
+	d := Holdem.Dealer(rand.New(rand.NewSource(1)), 1, 3)
+	if _, err := d.RunReport(context.Background()); !errors.Is(err, ErrInvalidStreet) {
+		t.Errorf("expected error %v, got: %v", ErrInvalidStreet, err)
+	}
+	d.Next()
+	d.Deactivate(2)
+	d.Next()
+	if !d.ChangeRuns(2) {
+		t.Fatalf("expected true")
+	}
+	if _, err := d.RunReport(context.Background()); !errors.Is(err, ErrInsufficientCards) {
+		t.Errorf("expected error %v, got: %v", ErrInsufficientCards, err)
+	}
+	for d.Next() {
+	}
+	for d.NextResult() {
+	}
+	report, err := d.RunReport(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if report.Street != 1 || len(report.Runs) != 2 {
+		t.Fatalf("expected street 1 and 2 runs, got: %d %d", report.Street, len(report.Runs))
+	}
+	var equity, realized float64
+	for i := range 3 {
+		equity, realized = equity+report.Equity[i], realized+report.Realized[i]
+		if exp := (report.Runs[0][i] + report.Runs[1][i]) / 2; report.Realized[i] != exp {
+			t.Errorf("position %d expected %f, got: %f", i, exp, report.Realized[i])
+		}
+	}
+	if math.Abs(equity-1) > 1e-9 || math.Abs(realized-1) > 1e-9 {
+		t.Errorf("expected equity and realized of 1, got: %f %f", equity, realized)
+	}
+	if report.Equity[2] != 0 || report.Realized[2] != 0 {
+		t.Errorf("expected no equity for folded position")
+	}
+	if exp := report.Realized[0] - report.Equity[0]; report.Diff(0) != exp {
+		t.Errorf("expected %f, got: %f", exp, report.Diff(0))
+	}
+	if s := fmt.Sprintf("%v", report); !strings.HasPrefix(s, "0: equity ") || strings.Count(s, "\n") != 2 {
+		t.Errorf("expected 2 lines, got: %q", s)
+	}
+}
+
+func TestDealerRunReportHiLo(t *testing.T) {
+	d := OmahaHiLo.Dealer(rand.New(rand.NewSource(2)), 1, 3)
+	for range 3 {
+		d.Next()
+	}
+	if !d.ChangeRuns(2) {
+		t.Fatalf("expected true")
+	}
+	base := d.base.Dupe()
+	for d.Next() {
+	}
+	for d.NextResult() {
+	}
+	report, err := d.RunReport(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	exp := make([]float64, 3)
+	u := DeckFrench.Exclude(append(base.Pockets, base.Hi)...)
+	for _, c := range u {
+		run := base.Dupe()
+		run.Hi = append(run.Hi, c)
+		for i, f := range NewResult(OmahaHiLo, run, d.Active, true).Split(1) {
+			exp[i] += f / float64(len(u))
+		}
+	}
+	for i := range 3 {
+		if math.Abs(report.Equity[i]-exp[i]) > 1e-9 {
+			t.Errorf("position %d expected %f, got: %f", i, exp[i], report.Equity[i])
+		}
+	}
+}
+
+func TestDealerReactivate(t *testing.T) {
+	var events []Event
+	d := NewDealer(Holdem.Desc(), DeckOf(DeckFrench.Unshuffled()...), 4, WithEvents(func(e Event) {
+		events = append(events, e)
+	}))
+	d.Next()
+	if err := d.DeactivateErr(1, 4); !errors.Is(err, ErrInvalidPosition) {
+		t.Errorf("expected error %v, got: %v", ErrInvalidPosition, err)
+	}
+	if !d.Active[1] {
+		t.Errorf("expected position 1 to remain active")
+	}
+	if d.Deactivate(-1) {
+		t.Errorf("expected false")
+	}
+	if err := d.DeactivateErr(1, 2, 2); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	for _, position := range []int{0, -1, 4} {
+		if err := d.Reactivate(position); !errors.Is(err, ErrInvalidPosition) {
+			t.Errorf("position %d expected error %v, got: %v", position, ErrInvalidPosition, err)
+		}
+	}
+	if err := d.Reactivate(1); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !d.Active[1] || d.Active[2] {
+		t.Errorf("expected position 1 active and 2 inactive")
+	}
+	d.Next()
+	if err := d.Reactivate(2); !errors.Is(err, ErrInvalidStreet) {
+		t.Errorf("expected error %v, got: %v", ErrInvalidStreet, err)
+	}
+	exp := []Event{
+		{EventDeactivate, 0, 0, 1},
+		{EventDeactivate, 0, 0, 2},
+		{EventReactivate, 0, 0, 1},
+	}
+	if !slices.Equal(events, exp) {
+		t.Errorf("expected %v, got: %v", exp, events)
+	}
+	d.Reset()
+	if err := d.Reactivate(2); err == nil || len(d.Inactive()) != 0 {
+		t.Errorf("expected error and no inactive positions, got: %v %v", err, d.Inactive())
+	}
+	// pre-flop, flop
+	for range 2 {
+		d.Next()
+	}
+	if !d.ChangeRuns(2) {
+		t.Fatalf("expected true")
+	}
+	// turn
+	d.Next()
+	if err := d.DeactivateErr(3); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	// river, run 1 turn
+	for range 2 {
+		d.Next()
+	}
+	if r, _ := d.Run(); r != 1 || d.Street() != 2 {
+		t.Fatalf("expected run 1 street 2, got: %d %d", r, d.Street())
+	}
+	if err := d.Reactivate(3); !errors.Is(err, ErrInvalidStreet) || d.Active[3] {
+		t.Errorf("expected error %v, got: %v", ErrInvalidStreet, err)
+	}
+}
+
+func TestDealerDiscardPocket(t *testing.T) {
+	desc := Manila.Desc()
+	desc.Streets = slices.Clone(desc.Streets)
+	desc.Streets[0].Pocket = 3
+	desc.Apply(WithPocketKeep('d', 2))
+	if s, exp := desc.Streets[1].Desc(), "d: Drop (b: 1, k: 2)"; s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+	var events []Event
+	d := NewDealer(desc, DeckOf(DeckManila.Unshuffled()...), 3, WithEvents(func(e Event) {
+		events = append(events, e)
+	}))
+	d.Next()
+	if err := d.DiscardPocket(0, d.Runs[0].Pockets[0][0]); !errors.Is(err, ErrInvalidStreet) {
+		t.Errorf("expected error %v, got: %v", ErrInvalidStreet, err)
+	}
+	if !d.Next() {
+		t.Fatalf("expected true")
+	}
+	if exp, v := []int{0, 1, 2}, d.PendingDiscards(); !slices.Equal(v, exp) {
+		t.Errorf("expected %v, got: %v", exp, v)
+	}
+	for d.Next() {
+	}
+	if !d.Pending() || d.Id() != 'd' {
+		t.Fatalf("expected pending on street d, got: %c", d.Id())
+	}
+	if ok, err := d.NextErr(); ok || !errors.Is(err, ErrPendingDiscards) || d.Id() != 'd' {
+		t.Fatalf("expected error %v on street d, got: %t %v %c", ErrPendingDiscards, ok, err, d.Id())
+	}
+	pocket := slices.Clone(d.Runs[0].Pockets[0])
+	if err := d.DiscardPocket(0, pocket[0], pocket[1]); !errors.Is(err, ErrInvalidCard) {
+		t.Errorf("expected error %v, got: %v", ErrInvalidCard, err)
+	}
+	if err := d.DiscardPocket(0, d.Runs[0].Pockets[1][0]); !errors.Is(err, ErrInvalidCard) {
+		t.Errorf("expected error %v, got: %v", ErrInvalidCard, err)
+	}
+	if err := d.DiscardPocket(0, pocket[1]); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if exp := []Card{pocket[0], pocket[2]}; !slices.Equal(d.Runs[0].Pockets[0], exp) {
+		t.Errorf("expected %v, got: %v", exp, d.Runs[0].Pockets[0])
+	}
+	if err := d.DiscardPocket(0, pocket[0]); !errors.Is(err, ErrInvalidCard) {
+		t.Errorf("expected error %v, got: %v", ErrInvalidCard, err)
+	}
+	d.Deactivate(2)
+	if err := d.DiscardPocket(2, d.Runs[0].Pockets[2][0]); !errors.Is(err, ErrInvalidPosition) {
+		t.Errorf("expected error %v, got: %v", ErrInvalidPosition, err)
+	}
+	discard := d.Runs[0].Pockets[1][2]
+	if err := d.DiscardPocket(1, discard); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if v := d.PendingDiscards(); len(v) != 0 || d.Pending() {
+		t.Errorf("expected no pending discards, got: %v", v)
+	}
+	if v := d.DiscardedOn('d'); slices.Contains(v, pocket[1]) || slices.Contains(v, discard) {
+		t.Errorf("expected no pocket discards on street d, got: %v", v)
+	}
+	if exp, v := []Card{pocket[1]}, d.PocketDiscarded(0); !slices.Equal(v, exp) {
+		t.Errorf("expected %v, got: %v", exp, v)
+	}
+	if exp, v := []Card{discard}, d.PocketDiscarded(1); !slices.Equal(v, exp) {
+		t.Errorf("expected %v, got: %v", exp, v)
+	}
+	for d.Next() {
+	}
+	if n := len(d.Runs[0].Hi); n != 5 {
+		t.Errorf("expected 5 board cards, got: %d", n)
+	}
+	if evs := d.Runs[0].Eval(d.Type, d.Active, false); evs[0] == nil || evs[0].HiRank == Invalid {
+		t.Errorf("expected valid eval for position 0")
+	}
+	exp := []Event{
+		{EventDiscard, 1, 0, 0},
+		{EventDeactivate, 1, 0, 2},
+		{EventDiscard, 1, 0, 1},
+	}
+	if !slices.Equal(events, exp) {
+		t.Errorf("expected %v, got: %v", exp, events)
+	}
+}
+
+func TestBoardPlays(t *testing.T) {
+	tests := []struct {
+		typ     Type
+		pockets []string
+		board   string
+		exp     []bool
+		res     bool
+	}{
+		{Holdem, []string{"2c 3d", "4c 5d"}, "Ah Kh Qh Jh Th", []bool{true, true}, true},
+		{Holdem, []string{"Ac 2d", "9c 8d"}, "Ah Kd Qc Jh Ts", []bool{true, true}, true},
+		{Holdem, []string{"Ac 2d", "9c Kc"}, "Ah Kd Qc Jh 2s", []bool{false, false}, false},
+		{Holdem, []string{"2c 3d", "Kc 5d"}, "Ah Kd 7c 4h 9s", []bool{true, false}, false},
+		{Holdem, []string{"2c 3d", "4c 5d"}, "Ah Kd 7c 8h 9s", []bool{true, true}, true},
+		{Omaha, []string{"2c 3d 4s 5s", "6c 7d 8c Td"}, "Ah Kh Qh Jh Th", []bool{false, false}, false},
+	}
+	for i, test := range tests {
+		board := Must(test.board)
+		run := NewRun(len(test.pockets))
+		active := make(map[int]bool)
+		for j, s := range test.pockets {
+			run.Pockets[j], active[j] = Must(s), true
+			ev := test.typ.Eval(run.Pockets[j], board)
+			if b := ev.BoardPlays(board); b != test.exp[j] {
+				t.Errorf("test %d position %d expected %t, got: %t", i, j, test.exp[j], b)
+			}
+		}
+		run.Hi = board
+		if res := NewResult(test.typ, run, active, false); res.BoardPlays != test.res {
+			t.Errorf("test %d expected %t, got: %t", i, test.res, res.BoardPlays)
+		}
+	}
+	var ev *Eval
+	if ev.BoardPlays(Must("Ah Kh Qh Jh Th")) {
+		t.Errorf("expected false")
+	}
+}
+
+func TestDealerStudDeadUpcards(t *testing.T) {
+	ctx := context.Background()
+	d := NewDealer(Stud.Desc(), DeckOf(DeckFrench.Unshuffled()...), 3)
+	for d.Next() && d.Id() != '6' {
+	}
+	if d.Id() != '6' {
+		t.Fatalf("expected 6th street, got: %c", d.Id())
+	}
+	up := d.Upcards(2)
+	pocket := d.Runs[0].Pockets[2]
+	if exp := pocket[2:6]; !slices.Equal(up, exp) {
+		t.Errorf("expected %v, got: %v", exp, up)
+	}
+	d.Deactivate(2)
+	hi, _, err := d.CalcErr(ctx, false, WithDeep(true))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	exp, _, _ := Stud.Odds(ctx, d.Runs[0].Pockets[:2], nil, WithDeep(true), WithDeadCards(up))
+	if hi.Total != exp.Total || !slices.Equal(hi.Counts[:2], exp.Counts) {
+		t.Errorf("expected %d %v, got: %d %v", exp.Total, exp.Counts, hi.Total, hi.Counts)
+	}
+	for _, c := range up {
+		if hi.Outs[0][c] || hi.Outs[1][c] {
+			t.Errorf("expected dead upcard %s to not be an out", c)
+		}
+	}
+	if v := d.Upcards(0); len(v) != 4 {
+		t.Errorf("expected 4 upcards, got: %v", v)
+	}
+}
@@ -0,0 +1,388 @@
+package cardrank
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math/bits"
+	"slices"
+	"strings"
+)
+
+// DrawType is a draw type.
+type DrawType uint8
+
+// Draw types.
+const (
+	// DrawFlush is a flush draw.
+	DrawFlush DrawType = iota
+	// DrawOpenEnded is a open-ended (or double gutshot) straight draw.
+	DrawOpenEnded
+	// DrawGutshot is a gutshot (inside) straight draw.
+	DrawGutshot
+	// DrawBoat is a full house or better draw from trips or two pair.
+	DrawBoat
+	// DrawOvercard is a draw to pair a pocket card higher than the board.
+	DrawOvercard
+	// DrawOther is any other out.
+	DrawOther
+)
+
+// Name returns the draw type name.
+func (typ DrawType) Name() string {
+	switch typ {
+	case DrawFlush:
+		return "flush draw"
+	case DrawOpenEnded:
+		return "open-ended straight draw"
+	case DrawGutshot:
+		return "gutshot"
+	case DrawBoat:
+		return "boat draw"
+	case DrawOvercard:
+		return "overcards"
+	case DrawOther:
+		return "other"
+	}
+	return ""
+}
+
+// DrawOuts are the outs for a draw type.
+type DrawOuts struct {
+	Type DrawType
+	Outs []Card
+}
+
+// Format satisfies the [fmt.Formatter] interface.
+func (d DrawOuts) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 's', 'v':
+		n := "outs"
+		if len(d.Outs) == 1 {
+			n = "out"
+		}
+		fmt.Fprintf(f, "%d %s: %s", len(d.Outs), n, d.Type.Name())
+	default:
+		fmt.Fprintf(f, "%%!%c(ERROR=unknown verb, draw: %d)", verb, d.Type)
+	}
+}
+
+// ClassifyOuts classifies the outs for the pocket and board into draw types,
+// using standard (Ace high) poker hand categories. Each out is classified as
+// its strongest draw type, in order of flush, open-ended, gutshot, boat, and
+// overcards. Draws without outs are omitted.
+func ClassifyOuts(pocket, board, outs []Card) []DrawOuts {
+	var ranks [13]int
+	var suits [4]int
+	var mask uint16
+	for _, c := range slices.Concat(pocket, board) {
+		if r := c.Rank(); c != Joker && r <= Ace {
+			ranks[r]++
+			suits[c.SuitIndex()]++
+			mask |= 1 << r
+		}
+	}
+	// determine straight draw ranks
+	made := straightMask(mask)
+	var straights int
+	for r := Two; r <= Ace; r++ {
+		if mask&(1<<r) == 0 && !made && straightMask(mask|1<<r) {
+			straights++
+		}
+	}
+	// determine highest board rank
+	high := InvalidRank
+	for _, c := range board {
+		if r := c.Rank(); r <= Ace && (high == InvalidRank || high < r) {
+			high = r
+		}
+	}
+	flushed := slices.Max(suits[:]) >= 5
+	var draws [DrawOther + 1][]Card
+	for _, c := range outs {
+		r := c.Rank()
+		if c == Joker || Ace < r {
+			continue
+		}
+		typ := DrawOther
+		switch {
+		case !flushed && suits[c.SuitIndex()] == 4:
+			typ = DrawFlush
+		case !made && straightMask(mask|1<<r) && straights > 1:
+			typ = DrawOpenEnded
+		case !made && straightMask(mask|1<<r):
+			typ = DrawGutshot
+		case boatDraw(ranks, r):
+			typ = DrawBoat
+		case high != InvalidRank && high < r && pairsPocket(pocket, r):
+			typ = DrawOvercard
+		}
+		draws[typ] = append(draws[typ], c)
+	}
+	var v []DrawOuts
+	for typ, outs := range draws {
+		if len(outs) != 0 {
+			v = append(v, DrawOuts{Type: DrawType(typ), Outs: outs})
+		}
+	}
+	return v
+}
+
+// Draws classifies the position's outs into draw types for the pocket and
+// board. See [ClassifyOuts].
+func (odds *Odds) Draws(pos int, pocket, board []Card) []DrawOuts {
+	var outs []Card
+	for c := range odds.Outs[pos] {
+		outs = append(outs, c)
+	}
+	slices.SortFunc(outs, func(a, b Card) int {
+		return a.Index() - b.Index()
+	})
+	return ClassifyOuts(pocket, board, outs)
+}
+
+// straightMask returns true when the rank mask contains a straight.
+func straightMask(mask uint16) bool {
+	// duplicate the ace as the low card for the wheel
+	m := mask<<1 | mask>>Ace&1
+	for i := range 10 {
+		if m>>i&0x1f == 0x1f {
+			return true
+		}
+	}
+	return false
+}
+
+// boatDraw returns true when adding r to ranks makes a full house or four of
+// a kind, when not already made.
+func boatDraw(ranks [13]int, r Rank) bool {
+	var trips, pairs int
+	for _, n := range ranks {
+		switch {
+		case n >= 4:
+			return false
+		case n == 3:
+			trips++
+		case n == 2:
+			pairs++
+		}
+	}
+	switch {
+	case trips > 1, trips == 1 && pairs != 0:
+		return false
+	case ranks[r] == 3, trips == 1 && ranks[r] == 1:
+		return true
+	}
+	return 1 < pairs && ranks[r] == 2
+}
+
+// pairsPocket returns true when r pairs a card in the pocket.
+func pairsPocket(pocket []Card, r Rank) bool {
+	for _, c := range pocket {
+		if c.Rank() == r {
+			return true
+		}
+	}
+	return false
+}
+
+// Potential is the distribution of the final hi hand categories for a pocket
+// over each remaining board runout.
+type Potential struct {
+	// Total is the total number of runouts.
+	Total int
+	// Counts is the count of runouts for each Cactus category ([StraightFlush]
+	// through [Nothing]). Ranks without a Cactus category (such as a [Soko]
+	// Four Flush) are counted as [Invalid].
+	Counts map[EvalRank]int
+}
+
+// NewPotential calculates the potential for the pocket and board, by
+// evaluating each runout of the remaining board cards. Returns false when
+// the type's eval is not a Cactus eval, the type does not have a board, or
+// the context is done.
+func NewPotential(ctx context.Context, typ Type, pocket, board []Card) (*Potential, bool) {
+	n := typ.Board() - len(board)
+	if typ.Board() == 0 || n < 0 || !typ.Cactus() {
+		return nil, false
+	}
+	p := &Potential{
+		Counts: make(map[EvalRank]int),
+	}
+	f, ev := calcs[typ], EvalOf(typ)
+	v := make([]Card, len(board), typ.Board())
+	copy(v, board)
+	for g, u := NewCombinGen(unusedCards(typ, pocket, board), n); g.Next(); {
+		if p.Total&0xfff == 0 {
+			select {
+			case <-ctx.Done():
+				return nil, false
+			default:
+			}
+		}
+		ev.HiRank = Invalid
+		f(ev, pocket, append(v[:len(board)], u...))
+		p.Counts[cactusRank(typ, ev.HiRank).Fixed()]++
+		p.Total++
+	}
+	return p, true
+}
+
+// Percent returns the percent of runouts making the category.
+func (p *Potential) Percent(category EvalRank) float64 {
+	if p.Total == 0 {
+		return 0
+	}
+	return 100 * float64(p.Counts[category.Fixed()]) / float64(p.Total)
+}
+
+// Format satisfies the [fmt.Formatter] interface.
+func (p *Potential) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 's', 'v':
+		var v []string
+		for r := range IterCategories() {
+			if p.Counts[r] != 0 {
+				v = append(v, fmt.Sprintf("%0.1f%% %s", p.Percent(r), r))
+			}
+		}
+		if n := p.Counts[Invalid]; n != 0 {
+			v = append(v, fmt.Sprintf("%0.1f%% %s", p.Percent(Invalid), Invalid))
+		}
+		fmt.Fprint(f, strings.Join(v, ", "))
+	default:
+		fmt.Fprintf(f, "%%!%c(ERROR=unknown verb, potential)", verb)
+	}
+}
+
+// DrawDecision is the expected value of discarding pocket cards and drawing
+// their replacements.
+type DrawDecision struct {
+	// Discard is the discard mask, where bit i discards pocket[i].
+	Discard uint8
+	// Keep are the kept pocket cards.
+	Keep []Card
+	// ExpValue is the expected value against a single opponent.
+	ExpValue *ExpValue
+}
+
+// Format satisfies the [fmt.Formatter] interface.
+func (d DrawDecision) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 's', 'v':
+		fmt.Fprintf(f, "keep %v draw %d: %s", d.Keep, bits.OnesCount8(d.Discard), d.ExpValue)
+	default:
+		fmt.Fprintf(f, "%%!%c(ERROR=unknown verb, draw decision)", verb)
+	}
+}
+
+// DrawDecisions calculates the expected value of each possible discard of
+// the pocket cards, given the visible board, against a single opponent
+// pocket, returning the decisions ordered by highest expected value. The
+// discards are limited to the type's largest pocket draw, and the opponent
+// pocket is not drawn.
+//
+// When samples is 0, every draw, board, and opponent pocket is enumerated.
+// Otherwise, the draws, boards, and opponent pockets are sampled for each
+// decision using the shuffler, and the expected values are marked as sampled
+// (see [ExpValue.Sampled]). For a Hi/Lo type, the pot is split between the Hi
+// and Lo the same as [Result.Split].
+//
+// Returns [ErrUnsupportedType] when the type is not registered,
+// [ErrInvalidType] when the type does not allow draws or the pocket count
+// does not match the type, or [ErrInvalidShuffler] when samples is not 0 and
+// the shuffler is nil.
+func (typ Type) DrawDecisions(ctx context.Context, pocket, board []Card, shuffler Shuffler, samples int) ([]DrawDecision, error) {
+	desc, ok := descs[typ]
+	switch {
+	case !ok:
+		return nil, ErrUnsupportedType
+	case !desc.draw, len(pocket) != typ.Pocket(), 8 < len(pocket), typ.Board() < len(board):
+		return nil, fmt.Errorf("%w: %s cannot draw for %d pocket and %d board cards", ErrInvalidType, typ, len(pocket), len(board))
+	case 0 < samples && shuffler == nil:
+		return nil, fmt.Errorf("%w: nil shuffler for %d samples", ErrInvalidShuffler, samples)
+	}
+	var limit int
+	for _, street := range desc.Streets {
+		limit = max(limit, street.PocketDraw)
+	}
+	u := unusedCards(typ, pocket, board)
+	var decisions []DrawDecision
+	for hold := range holds(len(pocket), limit) {
+		d := DrawDecision{
+			Discard:  ^hold & uint8(uint(1)<<len(pocket)-1),
+			Keep:     heldCards(pocket, hold),
+			ExpValue: NewExpValue(1),
+		}
+		n := len(pocket) - len(d.Keep)
+		d.ExpValue.Sampled = 0 < samples
+		if err := drawDecision(ctx, typ, d.ExpValue, d.Keep, board, u, n, shuffler, samples); err != nil {
+			return nil, err
+		}
+		decisions = append(decisions, d)
+	}
+	slices.SortStableFunc(decisions, func(a, b DrawDecision) int {
+		return cmp.Compare(b.ExpValue.Float64(), a.ExpValue.Float64())
+	})
+	return decisions, nil
+}
+
+// drawDecision enumerates or samples the draws, boards, and opponent pockets
+// from the unused cards, adding the results to the expected value.
+func drawDecision(ctx context.Context, typ Type, expv *ExpValue, keep, board, u []Card, n int, shuffler Shuffler, samples int) error {
+	k, p := typ.Board()-len(board), typ.Pocket()
+	if len(u) < n+k+p {
+		return ErrInsufficientCards
+	}
+	f, hero, opp := calcs[typ], EvalOf(typ), EvalOf(typ)
+	pocket, full := make([]Card, len(keep)+n), make([]Card, len(board)+k)
+	copy(pocket, keep)
+	copy(full, board)
+	low := typ.Low() || typ.Double()
+	// add adds the result of the opponent pocket
+	add := func(v []Card) {
+		*opp = Eval{Type: typ, HiRank: Invalid, LoRank: Invalid}
+		f(opp, v, full)
+		expv.addComp(hero, opp, low)
+	}
+	// evalHero evaluates the hero's pocket
+	evalHero := func() {
+		*hero = Eval{Type: typ, HiRank: Invalid, LoRank: Invalid}
+		f(hero, pocket, full)
+	}
+	if 0 < samples {
+		w := slices.Clone(u)
+		for range samples {
+			select {
+			case <-ctx.Done():
+				return cancelled(ctx)
+			default:
+			}
+			shuffler.Shuffle(len(w), func(i, j int) {
+				w[i], w[j] = w[j], w[i]
+			})
+			copy(pocket[len(keep):], w[:n])
+			copy(full[len(board):], w[n:n+k])
+			evalHero()
+			add(w[n+k : n+k+p])
+		}
+		return nil
+	}
+	for g, v := NewCombinGen(u, n); g.Next(); {
+		copy(pocket[len(keep):], v)
+		unused := Exclude(u, v)
+		for h, b := NewCombinGen(unused, k); h.Next(); {
+			select {
+			case <-ctx.Done():
+				return cancelled(ctx)
+			default:
+			}
+			copy(full[len(board):], b)
+			evalHero()
+			for o, w := NewCombinGen(Exclude(unused, b), p); o.Next(); {
+				add(w)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package cardrank
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestClassifyOuts(t *testing.T) {
+	tests := []struct {
+		pocket string
+		board  string
+		outs   string
+		exp    []string
+	}{
+		{"Ah Kh", "2h 7h Qc", "3h 4h 5h 6h 8h 9h Th Jh Qh As Ac Ad Ks Kc Kd", []string{"9 outs: flush draw", "6 outs: overcards"}},
+		{"8c 9d", "6s 7h 2c", "5s 5h 5d 5c Ts Th Td Tc", []string{"8 outs: open-ended straight draw"}},
+		{"8c 9d", "5s 7h Kc", "6s 6h 6d 6c", []string{"4 outs: gutshot"}},
+		{"8c 8d", "6s 6h 2c", "8s 8h 6d 6c", []string{"4 outs: boat draw"}},
+		{"8c 8d", "8s 6h 2c", "8h 6s 6d 6c 2s 2h 2d", []string{"7 outs: boat draw"}},
+		{"Ah 2h", "3s 4h 9c", "5s 5h 5d 5c Kc", []string{"4 outs: gutshot", "1 out: other"}},
+	}
+	for i, test := range tests {
+		v := ClassifyOuts(Must(test.pocket), Must(test.board), Must(test.outs))
+		if len(v) != len(test.exp) {
+			t.Fatalf("test %d expected %d draws, got: %d %v", i, len(test.exp), len(v), v)
+		}
+		for j, exp := range test.exp {
+			if s := fmt.Sprintf("%s", v[j]); s != exp {
+				t.Errorf("test %d draw %d expected %q, got: %q", i, j, exp, s)
+			}
+		}
+	}
+}
+
+func TestOddsDraws(t *testing.T) {
+	pockets := [][]Card{Must("Ah Kh"), Must("Qs Qd")}
+	board := Must("2h 7h Qc 3c")
+	odds, _, _ := Holdem.Odds(context.Background(), pockets, board)
+	v := odds.Draws(0, pockets[0], board)
+	if len(v) != 1 || v[0].Type != DrawFlush || len(v[0].Outs) != 7 {
+		t.Errorf("expected 7 outs: flush draw, got: %v", v)
+	}
+}
+
+func TestPotential(t *testing.T) {
+	p, ok := Holdem.Potential(context.Background(), Must("Ah Kh"), Must("2h 7h Qc"))
+	switch {
+	case !ok:
+		t.Fatalf("expected ok")
+	case p.Total != 1081:
+		t.Errorf("expected %d, got: %d", 1081, p.Total)
+	case p.Counts[Flush] != 378:
+		t.Errorf("expected %d, got: %d", 378, p.Counts[Flush])
+	}
+	var total int
+	for r := range IterCategories() {
+		total += p.Counts[r]
+	}
+	if total != p.Total {
+		t.Errorf("expected %d, got: %d", p.Total, total)
+	}
+	p, ok = Holdem.Potential(context.Background(), Must("Ah Kh"), Must("2h 7h Qc Qd As"))
+	if !ok {
+		t.Fatalf("expected ok")
+	}
+	if s, exp := fmt.Sprintf("%s", p), "100.0% Two Pair"; s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+	p, ok = Short.Potential(context.Background(), Must("Ah Kh"), Must("6h 7h Qh Qd"))
+	if !ok {
+		t.Fatalf("expected ok")
+	}
+	if n, exp := p.Counts[Flush], 30; n != exp {
+		t.Errorf("expected %d, got: %d", exp, n)
+	}
+	if _, ok := Stud.Potential(context.Background(), Must("Ah Kh"), nil); ok {
+		t.Errorf("expected not ok")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, ok := Holdem.Potential(ctx, Must("Ah Kh"), nil); ok {
+		t.Errorf("expected not ok")
+	}
+}
+
+func TestDrawDecisions(t *testing.T) {
+	ctx := context.Background()
+	pocket, board := Must("2c 3d"), Must("Ah Kh Qh 9s 8d")
+	v, err := Swap.DrawDecisions(ctx, pocket, board, nil, 0)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(v) != 4 {
+		t.Fatalf("expected 4 decisions, got: %d", len(v))
+	}
+	if v[0].Discard != 3 || len(v[0].Keep) != 0 || v[0].ExpValue.Total != 893970 {
+		t.Errorf("expected discard 3 with 893970 total, got: %d %v %d", v[0].Discard, v[0].Keep, v[0].ExpValue.Total)
+	}
+	for i := 1; i < len(v); i++ {
+		if v[i-1].ExpValue.Float64() < v[i].ExpValue.Float64() {
+			t.Errorf("test %d expected decreasing expected value, got: %s", i, v[i])
+		}
+	}
+	// standing pat matches the expected value
+	exp, _ := Swap.ExpValue(ctx, pocket, WithBoard(board))
+	for _, d := range v {
+		if d.Discard == 0 && *d.ExpValue != *exp {
+			t.Errorf("expected %v, got: %v", exp, d.ExpValue)
+		}
+	}
+	// sampled
+	v, err = Draw.DrawDecisions(ctx, Must("Ah Ad 9c 7s 2d"), nil, rand.New(rand.NewSource(0)), 2000)
+	switch {
+	case err != nil:
+		t.Fatalf("expected no error, got: %v", err)
+	case len(v) != 32:
+		t.Fatalf("expected 32 decisions, got: %d", len(v))
+	case !v[0].ExpValue.Sampled || v[0].ExpValue.Total != 2000:
+		t.Errorf("expected 2000 sampled, got: %t %d", v[0].ExpValue.Sampled, v[0].ExpValue.Total)
+	case !slices.Contains(v[0].Keep, Must("Ah")[0]) || !slices.Contains(v[0].Keep, Must("Ad")[0]):
+		t.Errorf("expected to keep aces, got: %s", v[0])
+	}
+	// hi/lo counts each half of the pot
+	v, err = DrawHiLo.DrawDecisions(ctx, Must("Ah 2d 3c 4s Kd"), nil, rand.New(rand.NewSource(0)), 500)
+	switch {
+	case err != nil:
+		t.Fatalf("expected no error, got: %v", err)
+	case v[0].ExpValue.Total != 1000:
+		t.Errorf("expected 1000 total, got: %d", v[0].ExpValue.Total)
+	}
+	if _, err := Holdem.DrawDecisions(ctx, pocket, board, nil, 0); !errors.Is(err, ErrInvalidType) {
+		t.Errorf("expected error %v, got: %v", ErrInvalidType, err)
+	}
+	if _, err := Draw.DrawDecisions(ctx, Must("Ah Ad 9c 7s 2d"), nil, nil, 100); !errors.Is(err, ErrInvalidShuffler) {
+		t.Errorf("expected error %v, got: %v", ErrInvalidShuffler, err)
+	}
+}
@@ -0,0 +1,198 @@
+package cardrank
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// ShuffleReport is a report of the dispersion of the decks produced by a
+// shuffler, used to detect a poor pseudo-random number generator. See
+// [NewShuffleReport].
+//
+// Z-scores are approximately standard normal for a fair shuffler, and values
+// consistently outside of ±4 indicate a biased shuffler.
+type ShuffleReport struct {
+	// Deck is the deck type.
+	Deck DeckType
+	// Shuffles is the number of decks shuffled.
+	Shuffles int
+	// Entropy is the mean Shannon entropy (in bits) of the distribution of
+	// cards at each deck position.
+	Entropy float64
+	// MaxEntropy is the maximum possible entropy (in bits) at each deck
+	// position.
+	MaxEntropy float64
+	// PositionChiSquare is the chi-square statistic of the count of each
+	// card at each deck position.
+	PositionChiSquare float64
+	// PositionZ is the z-score of the position chi-square statistic.
+	PositionZ float64
+	// RankChiSquare is the chi-square statistic of the count of each rank at
+	// each deck position.
+	RankChiSquare float64
+	// RankZ is the z-score of the rank chi-square statistic.
+	RankZ float64
+	// MaxBias is the largest relative deviation of a card's count at a deck
+	// position from its expected count.
+	MaxBias float64
+	// Spacings is the count of repeated spacings between the sorted
+	// birthdays, where each deck's first cards (in order) are its birthday.
+	// The number of first cards is chosen so that at most 4 repeated
+	// spacings are expected.
+	Spacings int
+	// ExpectedSpacings is the expected count of repeated spacings.
+	ExpectedSpacings float64
+	// SpacingsZ is the z-score of the repeated spacings count.
+	SpacingsZ float64
+}
+
+// NewShuffleReport creates a shuffle report for the shuffler, by shuffling
+// an unshuffled deck of the deck type the number of shuffles, and measuring
+// the dispersion of the resulting decks. The accuracy of the report is
+// determined by the number of shuffles, with 10,000 or more recommended.
+//
+// Measures the entropy and chi-square statistics of the cards and ranks at
+// each deck position (position bias), and the birthday spacings of the first
+// cards of each deck.
+func NewShuffleReport(typ DeckType, shuffler Shuffler, shuffles int) *ShuffleReport {
+	unshuffled := typ.Unshuffled()
+	n := len(unshuffled)
+	index := make(map[Card]int, n)
+	for i, c := range unshuffled {
+		index[c] = i
+	}
+	ranks := make(map[Rank]int)
+	for _, c := range unshuffled {
+		if _, ok := ranks[c.Rank()]; !ok {
+			ranks[c.Rank()] = len(ranks)
+		}
+	}
+	// birthdays are the ordered first k cards of each deck, with k chosen
+	// so that the expected repeated spacings is at most 4
+	m := float64(shuffles)
+	k, days := 1, float64(n)
+	for ; k < n && days < m*m*m/16 && days*float64(n-k) < 1<<63; k++ {
+		days *= float64(n - k)
+	}
+	counts := make([][]int, n)
+	rankCounts := make([][]int, n)
+	for i := range n {
+		counts[i], rankCounts[i] = make([]int, n), make([]int, len(ranks))
+	}
+	birthdays := make([]uint64, shuffles)
+	v := make([]Card, n)
+	for s := range shuffles {
+		copy(v, unshuffled)
+		shuffler.Shuffle(n, func(i, j int) {
+			v[i], v[j] = v[j], v[i]
+		})
+		for i, c := range v {
+			counts[i][index[c]]++
+			rankCounts[i][ranks[c.Rank()]]++
+		}
+		// birthday is the mixed radix index of the first k cards
+		var day uint64
+		used := make([]bool, n)
+		for i := range k {
+			j := index[v[i]]
+			var d int
+			for l := range j {
+				if !used[l] {
+					d++
+				}
+			}
+			used[j] = true
+			day = day*uint64(n-i) + uint64(d)
+		}
+		birthdays[s] = day
+	}
+	r := &ShuffleReport{
+		Deck:       typ,
+		Shuffles:   shuffles,
+		MaxEntropy: math.Log2(float64(n)),
+	}
+	if shuffles == 0 || n < 2 {
+		return r
+	}
+	// position entropy, bias, and chi-square
+	exp := float64(shuffles) / float64(n)
+	for i := range n {
+		for _, count := range counts[i] {
+			if count != 0 {
+				p := float64(count) / float64(shuffles)
+				r.Entropy -= p * math.Log2(p)
+			}
+			d := float64(count) - exp
+			r.PositionChiSquare += d * d / exp
+			r.MaxBias = max(r.MaxBias, math.Abs(d)/exp)
+		}
+	}
+	r.Entropy /= float64(n)
+	r.PositionZ = chiSquareZ(r.PositionChiSquare, float64((n-1)*(n-1)))
+	// rank chi-square
+	perRank := make([]int, len(ranks))
+	for _, c := range unshuffled {
+		perRank[ranks[c.Rank()]]++
+	}
+	for i := range n {
+		for j, count := range rankCounts[i] {
+			e := float64(shuffles) * float64(perRank[j]) / float64(n)
+			d := float64(count) - e
+			r.RankChiSquare += d * d / e
+		}
+	}
+	r.RankZ = chiSquareZ(r.RankChiSquare, float64((n-1)*(len(ranks)-1)))
+	// birthday spacings
+	slices.Sort(birthdays)
+	spacings := make([]uint64, 0, shuffles)
+	for i := 1; i < shuffles; i++ {
+		spacings = append(spacings, birthdays[i]-birthdays[i-1])
+	}
+	slices.Sort(spacings)
+	for i := 1; i < len(spacings); i++ {
+		if spacings[i] == spacings[i-1] {
+			r.Spacings++
+		}
+	}
+	r.ExpectedSpacings = m * m * m / (4 * days)
+	if 0 < r.ExpectedSpacings {
+		r.SpacingsZ = (float64(r.Spacings) - r.ExpectedSpacings) / math.Sqrt(r.ExpectedSpacings)
+	}
+	return r
+}
+
+// Biased returns true when the absolute value of any of the report's
+// z-scores exceeds the threshold.
+func (r *ShuffleReport) Biased(threshold float64) bool {
+	return threshold < math.Abs(r.PositionZ) ||
+		threshold < math.Abs(r.RankZ) ||
+		threshold < math.Abs(r.SpacingsZ)
+}
+
+// Format satisfies the [fmt.Formatter] interface.
+func (r *ShuffleReport) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 's', 'v':
+		fmt.Fprintf(
+			f,
+			"%s %d shuffles: entropy %0.4f/%0.4f, position z %0.2f, rank z %0.2f, max bias %0.1f%%, spacings %d/%0.1f z %0.2f",
+			r.Deck.Name(), r.Shuffles,
+			r.Entropy, r.MaxEntropy,
+			r.PositionZ, r.RankZ, 100*r.MaxBias,
+			r.Spacings, r.ExpectedSpacings, r.SpacingsZ,
+		)
+	default:
+		fmt.Fprintf(f, "%%!%c(ERROR=unknown verb, shuffle report)", verb)
+	}
+}
+
+// chiSquareZ returns the z-score of the chi-square statistic with the degrees
+// of freedom, using the Wilson-Hilferty approximation.
+func chiSquareZ(x, df float64) float64 {
+	if df <= 0 {
+		return 0
+	}
+	v := 2 / (9 * df)
+	return (math.Cbrt(x/df) - (1 - v)) / math.Sqrt(v)
+}
@@ -0,0 +1,37 @@
+package cardrank
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestShuffleReport(t *testing.T) {
+	r := NewShuffleReport(DeckFrench, rand.New(rand.NewSource(0)), 20000)
+	switch {
+	case r.Shuffles != 20000:
+		t.Errorf("expected %d, got: %d", 20000, r.Shuffles)
+	case r.Biased(5):
+		t.Errorf("expected unbiased report, got: %s", r)
+	case r.MaxEntropy-r.Entropy > 0.01:
+		t.Errorf("expected entropy near %f, got: %f", r.MaxEntropy, r.Entropy)
+	}
+	// partial shuffle
+	b := NewShuffleReport(DeckFrench, partialShuffler{rand.New(rand.NewSource(0))}, 20000)
+	if !b.Biased(5) {
+		t.Errorf("expected biased report, got: %s", b)
+	}
+	if s := fmt.Sprintf("%d", r); s != "%!d(ERROR=unknown verb, shuffle report)" {
+		t.Errorf("expected error, got: %q", s)
+	}
+}
+
+// partialShuffler is a shuffler that only shuffles the first half of a deck.
+type partialShuffler struct {
+	r *rand.Rand
+}
+
+// Shuffle satisfies the [Shuffler] interface.
+func (s partialShuffler) Shuffle(n int, swap func(int, int)) {
+	s.r.Shuffle(n/2, swap)
+}
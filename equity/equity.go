@@ -0,0 +1,240 @@
+// Package equity contains a CSV exporter of enumerated range equities, for
+// producing datasets without orchestrating the calculations.
+package equity
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cardrank/cardrank"
+)
+
+// Error is a error.
+type Error string
+
+// Error satisfies the [error] interface.
+func (err Error) Error() string {
+	return string(err)
+}
+
+// Error values.
+const (
+	// ErrInvalidExport is the invalid export error.
+	ErrInvalidExport Error = "invalid export"
+)
+
+// Matchup is a matchup of pocket ranges on a board (see [Exporter]).
+type Matchup struct {
+	// Name is the unique name of the matchup, used when resuming an export.
+	Name string
+	// Labels are the optional labels for each position's range, such as a
+	// starting pocket hash key (see [cardrank.HashKey]).
+	Labels []string
+	// Ranges are the pocket ranges for each position (see
+	// [cardrank.RangeCalc]).
+	Ranges [][][]cardrank.Card
+	// Board is the board.
+	Board []cardrank.Card
+}
+
+// label returns the label for the position's range.
+func (m Matchup) label(pos int) string {
+	if pos < len(m.Labels) {
+		return m.Labels[pos]
+	}
+	v := make([]string, len(m.Ranges[pos]))
+	for i, pocket := range m.Ranges[pos] {
+		v[i] = joinCards(pocket)
+	}
+	return strings.Join(v, ",")
+}
+
+// GridMatchups returns an iterator over the heads up matchups of the 169
+// starting pockets of the 13x13 starting pocket grid (see
+// [cardrank.GridIterator]), named as "AKs-QQ".
+func GridMatchups() iter.Seq[Matchup] {
+	return func(yield func(Matchup) bool) {
+		var keys []string
+		var ranges [][][]cardrank.Card
+		for it := cardrank.NewGridIterator(); it.Next(); {
+			v, _ := cardrank.HashKeyPockets(it.Key)
+			keys, ranges = append(keys, it.Key), append(ranges, v)
+		}
+		for i := range keys {
+			for j := range keys {
+				if !yield(Matchup{
+					Name:   keys[i] + "-" + keys[j],
+					Labels: []string{keys[i], keys[j]},
+					Ranges: [][][]cardrank.Card{ranges[i], ranges[j]},
+				}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Progress is the progress of a previous export, read from its output (see
+// [ReadProgress]). The zero value is the progress of an empty output.
+type Progress struct {
+	// Header is whether the output has the header.
+	Header bool
+	// Done are the names of the exported matchups.
+	Done map[string]bool
+}
+
+// ReadProgress reads the progress of a previously written export (see
+// [Exporter.Export]). Returns [ErrInvalidExport] when the output is not empty
+// and does not start with the header.
+func ReadProgress(r io.Reader) (Progress, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(header)
+	var p Progress
+	for {
+		row, err := cr.Read()
+		switch {
+		case errors.Is(err, io.EOF):
+			return p, nil
+		case err != nil:
+			return Progress{}, fmt.Errorf("unable to read export: %w", err)
+		case !p.Header:
+			if !slices.Equal(row, header) {
+				return Progress{}, fmt.Errorf("unable to read export: %w: missing header", ErrInvalidExport)
+			}
+			p.Header, p.Done = true, make(map[string]bool)
+		default:
+			p.Done[row[0]] = true
+		}
+	}
+}
+
+// Exporter exports the enumerated equities of matchups as CSV.
+type Exporter struct {
+	typ  cardrank.Type
+	opts []cardrank.CalcOption
+}
+
+// NewExporter creates a new equity exporter for the type. The passed options
+// are used for each matchup's [cardrank.RangeCalc], such as
+// [cardrank.WithSamples] to estimate preflop matchups.
+func NewExporter(typ cardrank.Type, opts ...cardrank.CalcOption) *Exporter {
+	return &Exporter{
+		typ:  typ,
+		opts: opts,
+	}
+}
+
+// Export calculates the equities for the matchups, writing a CSV row to w
+// for each position of each matchup. The header is written unless the
+// output already has it, and the matchups done are skipped, allowing an
+// interrupted export to be resumed by appending to the same output (see
+// [ReadProgress]). Rows are flushed after each matchup.
+//
+// The equity column is the position's share of the pot (see
+// [cardrank.RangeCalc.CalcEquity]), while the count, total and odds columns
+// are the calculated odds (see [cardrank.Odds.Float32]).
+//
+// A matchup without any assignment of pockets that do not share cards is
+// written as a single row having an empty position and a total of 0, marking
+// the matchup as exported for a resumed export. Returns the count of exported
+// matchups.
+func (e *Exporter) Export(ctx context.Context, w io.Writer, matchups iter.Seq[Matchup], p Progress) (int, error) {
+	cw := csv.NewWriter(w)
+	if !p.Header {
+		if err := write(cw, [][]string{header}); err != nil {
+			return 0, err
+		}
+	}
+	var n int
+	for m := range matchups {
+		if p.Done[m.Name] {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return n, fmt.Errorf("%w: %w", cardrank.ErrContextCancelled, err)
+		}
+		hi, lo, equity, err := cardrank.NewRangeCalc(e.typ, m.Ranges, m.Board, e.opts...).CalcEquity(ctx)
+		if err != nil {
+			return n, fmt.Errorf("matchup %s: %w", m.Name, err)
+		}
+		if err := write(cw, rows(m, hi, lo, equity)); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// write writes and flushes the rows.
+func write(cw *csv.Writer, rows [][]string) error {
+	if err := cw.WriteAll(rows); err != nil {
+		return fmt.Errorf("unable to write export: %w", err)
+	}
+	return nil
+}
+
+// rows returns the export rows for the matchup's odds and equities.
+func rows(m Matchup, hi, lo *cardrank.Odds, equity []float64) [][]string {
+	board := joinCards(m.Board)
+	if hi.Total == 0 {
+		// no assignment of pockets without shared cards
+		return [][]string{{m.Name, "", "", board, "0", "", "", "", "false", "", ""}}
+	}
+	v := make([][]string, len(m.Ranges))
+	for pos := range m.Ranges {
+		v[pos] = []string{
+			m.Name,
+			strconv.Itoa(pos),
+			m.label(pos),
+			board,
+			strconv.Itoa(hi.Total),
+			strconv.Itoa(hi.Counts[pos]),
+			formatFloat(float64(hi.Counts[pos]) / float64(hi.Total)),
+			formatFloat(equity[pos]),
+			strconv.FormatBool(hi.Approximate),
+			"",
+			"",
+		}
+		if lo != nil {
+			v[pos][9] = strconv.Itoa(lo.Counts[pos])
+			v[pos][10] = formatFloat(float64(lo.Counts[pos]) / float64(max(lo.Total, 1)))
+		}
+	}
+	return v
+}
+
+// header is the export header.
+var header = []string{
+	"matchup",
+	"position",
+	"range",
+	"board",
+	"total",
+	"count",
+	"odds",
+	"equity",
+	"approximate",
+	"lo_count",
+	"lo_odds",
+}
+
+// formatFloat formats f with 6 decimal places.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', 6, 64)
+}
+
+// joinCards returns the cards joined by a space.
+func joinCards(v []cardrank.Card) string {
+	s := make([]string, len(v))
+	for i, c := range v {
+		s[i] = c.String()
+	}
+	return strings.Join(s, " ")
+}
@@ -0,0 +1,143 @@
+package equity
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/cardrank/cardrank"
+)
+
+func TestGridMatchups(t *testing.T) {
+	var n int
+	for m := range GridMatchups() {
+		if n == 1 && m.Name != "AA-AKs" {
+			t.Errorf("expected AA-AKs, got: %s", m.Name)
+		}
+		n++
+	}
+	if n != 169*169 {
+		t.Errorf("expected %d, got: %d", 169*169, n)
+	}
+}
+
+func TestReadProgress(t *testing.T) {
+	tests := []struct {
+		s      string
+		header bool
+		done   []string
+		err    error
+	}{
+		{"", false, nil, nil},
+		{strings.Join(header, ",") + "\n", true, nil, nil},
+		{strings.Join(header, ",") + "\na,0,,,1,1,1,1,false,,\na,1,,,1,0,0,0,false,,\nb,,,,0,,,,false,,\n", true, []string{"a", "b"}, nil},
+		{"a,0,,,1,1,1,1,false,,\n", false, nil, ErrInvalidExport},
+		{"a,0\n", false, nil, csv.ErrFieldCount},
+	}
+	for i, test := range tests {
+		p, err := ReadProgress(strings.NewReader(test.s))
+		switch {
+		case !errors.Is(err, test.err):
+			t.Errorf("test %d expected error %v, got: %v", i, test.err, err)
+		case p.Header != test.header:
+			t.Errorf("test %d expected header %t, got: %t", i, test.header, p.Header)
+		case len(p.Done) != len(test.done):
+			t.Errorf("test %d expected %v, got: %v", i, test.done, p.Done)
+		}
+		for _, name := range test.done {
+			if !p.Done[name] {
+				t.Errorf("test %d expected %s to be done", i, name)
+			}
+		}
+	}
+}
+
+func TestExporter(t *testing.T) {
+	ctx := context.Background()
+	board := cardrank.Must("Qh 7h 2c")
+	matchups := []Matchup{
+		{Name: "a", Ranges: [][][]cardrank.Card{{cardrank.Must("Ah Kh")}, {cardrank.Must("As Ac"), cardrank.Must("Qd Qc")}}, Board: board},
+		{Name: "b", Labels: []string{"AKs", "JTs"}, Ranges: [][][]cardrank.Card{{cardrank.Must("Ah Kh")}, {cardrank.Must("Jh Th")}}, Board: board},
+		{Name: "c", Ranges: [][][]cardrank.Card{{cardrank.Must("Ah Kh")}, {cardrank.Must("Ah Ks")}}, Board: board},
+	}
+	e := NewExporter(cardrank.Holdem)
+	var buf bytes.Buffer
+	n, err := e.Export(ctx, &buf, slices.Values(matchups[:1]), Progress{})
+	if err != nil || n != 1 {
+		t.Fatalf("expected 1 and no error, got: %d %v", n, err)
+	}
+	p, err := ReadProgress(bytes.NewReader(buf.Bytes()))
+	if err != nil || !p.Header || !p.Done["a"] || len(p.Done) != 1 {
+		t.Fatalf("expected a, got: %v %v", p, err)
+	}
+	// resume
+	if n, err = e.Export(ctx, &buf, slices.Values(matchups), p); err != nil || n != 2 {
+		t.Fatalf("expected 2 and no error, got: %d %v", n, err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 6 {
+		t.Fatalf("expected 6 lines, got: %d\n%s", len(lines), buf.String())
+	}
+	if exp := strings.Join(header, ","); lines[0] != exp {
+		t.Errorf("expected %q, got: %q", exp, lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "a,0,Ah Kh,") {
+		t.Errorf("expected range label, got: %q", lines[1])
+	}
+	hi, _, _ := cardrank.Holdem.Odds(ctx, [][]cardrank.Card{cardrank.Must("Ah Kh"), cardrank.Must("Jh Th")}, board)
+	if exp := "b,1,JTs,Qh 7h 2c," + strconv.Itoa(hi.Total) + "," + strconv.Itoa(hi.Counts[1]) + ","; !strings.HasPrefix(lines[4], exp) {
+		t.Errorf("expected prefix %q, got: %q", exp, lines[4])
+	}
+	// matchup without pocket assignments is marked as exported
+	if exp := "c,,,Qh 7h 2c,0,,,,false,,"; lines[5] != exp {
+		t.Errorf("expected %q, got: %q", exp, lines[5])
+	}
+	p, err = ReadProgress(bytes.NewReader(buf.Bytes()))
+	if err != nil || len(p.Done) != 3 || !p.Done["c"] {
+		t.Fatalf("expected a, b, c, got: %v %v", p, err)
+	}
+	if n, err = e.Export(ctx, &buf, slices.Values(matchups), p); err != nil || n != 0 {
+		t.Fatalf("expected 0 and no error, got: %d %v", n, err)
+	}
+	// resume from a header without rows
+	var hdr bytes.Buffer
+	if _, err := e.Export(ctx, &hdr, slices.Values(matchups[:0]), Progress{}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if p, err = ReadProgress(bytes.NewReader(hdr.Bytes())); err != nil || !p.Header || len(p.Done) != 0 {
+		t.Fatalf("expected header only, got: %v %v", p, err)
+	}
+	if _, err := e.Export(ctx, &hdr, slices.Values(matchups[:1]), p); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if n := strings.Count(hdr.String(), header[0]); n != 1 {
+		t.Errorf("expected 1 header, got: %d", n)
+	}
+	// equity is the pot share
+	pockets, turn := [][]cardrank.Card{cardrank.Must("Qc Jc"), cardrank.Must("Qd Jd"), cardrank.Must("9s 9h")}, cardrank.Must("Ah Kh 7c 2d")
+	_, _, equity, err := cardrank.NewRangeCalc(cardrank.Holdem, [][][]cardrank.Card{{pockets[0]}, {pockets[1]}, {pockets[2]}}, turn).CalcEquity(ctx)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	var split bytes.Buffer
+	chop := Matchup{Name: "d", Ranges: [][][]cardrank.Card{{pockets[0]}, {pockets[1]}, {pockets[2]}}, Board: turn}
+	if _, err := e.Export(ctx, &split, slices.Values([]Matchup{chop}), Progress{}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	lines = strings.Split(strings.TrimSpace(split.String()), "\n")
+	for i, line := range lines[1:] {
+		if s, exp := strings.Split(line, ",")[7], formatFloat(equity[i]); s != exp {
+			t.Errorf("position %d expected equity %s, got: %s", i, exp, s)
+		}
+	}
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := e.Export(cctx, &buf, slices.Values(matchups), Progress{}); !errors.Is(err, cardrank.ErrContextCancelled) {
+		t.Errorf("expected error %v, got: %v", cardrank.ErrContextCancelled, err)
+	}
+}
@@ -1,8 +1,15 @@
 package cardrank
 
 import (
+	"cmp"
+	"context"
 	"fmt"
+	"iter"
+	"runtime"
+	"slices"
 	"sort"
+	"sync"
+	"sync/atomic"
 )
 
 // EvalRank is a eval rank.
@@ -32,9 +39,6 @@ const (
 	flushOver         EvalRank = 1277
 	lowballAceFlush   EvalRank = 811
 	lowballAceNothing EvalRank = 6678
-	sokoFlush         EvalRank = TwoPair + 13*715
-	sokoStraight      EvalRank = sokoFlush + 13*10
-	sokoNothing       EvalRank = sokoStraight + (Nothing - TwoPair)
 	cactusAce         EvalRank = 6678
 	cactusKing        EvalRank = 7007
 	cactusQueen       EvalRank = 7216
@@ -48,6 +52,48 @@ const (
 	cactusFour        EvalRank = 7462
 	cactusThree       EvalRank = 7462
 	cactusTwo         EvalRank = 7462
+	sokoFourFlushes   EvalRank = 13 * 715
+	sokoFourStraights EvalRank = 13 * 10
+)
+
+// Three card eval ranks.
+//
+// See [RankThree].
+const (
+	ThreeCardStraightFlush EvalRank = 12
+	ThreeCardThreeOfAKind  EvalRank = 25
+	ThreeCardStraight      EvalRank = 37
+	ThreeCardFlush         EvalRank = 311
+	ThreeCardPair          EvalRank = 467
+	ThreeCardNothing       EvalRank = 741
+	twoCardPair            EvalRank = 13
+	twoCardNothing         EvalRank = 91
+)
+
+// Partial eval ranks.
+//
+// See [RankPartial].
+const (
+	PartialFourOfAKind  EvalRank = 13
+	PartialThreeOfAKind EvalRank = 182
+	PartialTwoPair      EvalRank = 260
+	PartialPair         EvalRank = 1274
+	PartialNothing      EvalRank = 2275
+)
+
+// Soko eval ranks.
+//
+// [RankSoko] ranks a Four Flush and Four Straight directly over a [Pair], and
+// [RankSokoUnder] ranks them directly under a [Pair]. [RankSoko] leaves the
+// rank directly under the best Pair unused.
+const (
+	SokoFourFlush         EvalRank = TwoPair + sokoFourFlushes
+	SokoFourStraight      EvalRank = SokoFourFlush + sokoFourStraights
+	SokoPair              EvalRank = SokoFourStraight + 1 + (Pair - TwoPair)
+	SokoNothing           EvalRank = SokoFourStraight + 1 + (Nothing - TwoPair)
+	SokoUnderFourFlush    EvalRank = Pair + sokoFourFlushes
+	SokoUnderFourStraight EvalRank = SokoUnderFourFlush + sokoFourStraights
+	SokoUnderNothing      EvalRank = SokoUnderFourStraight + (Nothing - Pair)
 )
 
 // Fixed converts a relative eval rank to a fixed eval rank.
@@ -75,6 +121,251 @@ func (r EvalRank) Fixed() EvalRank {
 	return Invalid
 }
 
+// CategoryBounds returns the best and worst eval ranks of the Cactus category
+// containing the eval rank. Returns [Invalid], [Invalid] for an invalid rank.
+//
+// Examples:
+//
+//	FullHouse.CategoryBounds()      // 167, 322
+//	EvalRank(2000).CategoryBounds() // 1610, 2467
+func (r EvalRank) CategoryBounds() (EvalRank, EvalRank) {
+	switch hi := r.Fixed(); hi {
+	case Invalid:
+		return Invalid, Invalid
+	case StraightFlush:
+		return 1, hi
+	default:
+		return categories[slices.Index(categories, hi)-1] + 1, hi
+	}
+}
+
+// cactusRank converts the type's hi rank to a Cactus rank, returning
+// [Invalid] when the type's eval is not a Cactus eval, or for a [Soko] Four
+// Flush or Four Straight.
+func cactusRank(typ Type, r EvalRank) EvalRank {
+	desc := typ.Desc()
+	switch {
+	case r == Invalid, !desc.Eval.Cactus():
+		return Invalid
+	case desc.Eval.FlushOver():
+		return r.FromFlushOver()
+	case desc.Eval == EvalSoko:
+		return r.FromSoko()
+	case desc.Eval == EvalSokoUnder:
+		return r.FromSokoUnder()
+	}
+	return r
+}
+
+// sokoRank returns [SokoFourFlush] or [SokoFourStraight] for a [Soko] Four
+// Flush or Four Straight rank of the type, otherwise [Invalid].
+func sokoRank(typ Type, r EvalRank) EvalRank {
+	switch eval := typ.Desc().Eval; {
+	case eval == EvalSoko && TwoPair < r && r <= SokoFourFlush,
+		eval == EvalSokoUnder && Pair < r && r <= SokoUnderFourFlush:
+		return SokoFourFlush
+	case eval == EvalSoko && SokoFourFlush < r && r <= SokoFourStraight,
+		eval == EvalSokoUnder && SokoUnderFourFlush < r && r <= SokoUnderFourStraight:
+		return SokoFourStraight
+	}
+	return Invalid
+}
+
+// IterCategories returns an iterator over the fixed Cactus eval rank of each
+// category, from [StraightFlush] to [Nothing]. See [EvalRank.CategoryBounds].
+func IterCategories() iter.Seq[EvalRank] {
+	return func(yield func(EvalRank) bool) {
+		for _, r := range categories {
+			if !yield(r) {
+				return
+			}
+		}
+	}
+}
+
+// categories are the fixed Cactus eval rank categories.
+var categories = []EvalRank{
+	StraightFlush,
+	FourOfAKind,
+	FullHouse,
+	Flush,
+	Straight,
+	ThreeOfAKind,
+	TwoPair,
+	Pair,
+	Nothing,
+}
+
+// Category is a hand category with a stable numeric id across variants and
+// versions, for storing results independently of the eval rank constants.
+// See [VariantCategory]. The hands of a pay table (see [PayHand]) are
+// categorized the same, with the addition of the hands specific to a pay
+// table, such as [PayRoyalFlush] and [PayJacksOrBetter].
+type Category uint8
+
+// Categories.
+//
+// Ids are stable and will not be changed.
+const (
+	CategoryInvalid       Category = 0
+	CategoryStraightFlush Category = 1
+	CategoryFourOfAKind   Category = 2
+	CategoryFullHouse     Category = 3
+	CategoryFlush         Category = 4
+	CategoryStraight      Category = 5
+	CategoryThreeOfAKind  Category = 6
+	CategoryTwoPair       Category = 7
+	CategoryPair          Category = 8
+	CategoryNothing       Category = 9
+	CategoryFourFlush     Category = 10
+	CategoryFourStraight  Category = 11
+	CategoryLow           Category = 12
+	CategoryBadugiFour    Category = 13
+	CategoryBadugiThree   Category = 14
+	CategoryBadugiTwo     Category = 15
+	CategoryBadugiOne     Category = 16
+)
+
+// VariantCategory returns the stable category for the type's Hi eval rank,
+// accounting for the type's rank remapping (ie, [Short]'s Flush over Full
+// House, [Soko]'s Four Flush and Four Straight, and [Lowball] and [Razz]
+// unpaired lows as [CategoryLow]).
+func VariantCategory(typ Type, rank EvalRank) Category {
+	desc, ok := descs[typ]
+	if !ok || rank == 0 || rank == Invalid {
+		return CategoryInvalid
+	}
+	switch sokoRank(typ, rank) {
+	case SokoFourFlush:
+		return CategoryFourFlush
+	case SokoFourStraight:
+		return CategoryFourStraight
+	}
+	switch {
+	case desc.Eval.Cactus():
+		return categoryOf(cactusRank(typ, rank))
+	}
+	switch desc.HiDesc {
+	case DescLowball:
+		switch r := rank.FromLowball(); {
+		case Pair < r && r <= Nothing || r == Straight:
+			return CategoryLow
+		case r == StraightFlush:
+			return CategoryFlush
+		default:
+			return categoryOf(r)
+		}
+	case DescRazz:
+		if rank < aceFiveMax {
+			return CategoryLow
+		}
+		return categoryOf(Invalid - rank)
+	case DescLow:
+		if n := int(rank >> 13); n < 4 {
+			return CategoryBadugiFour + Category(n)
+		}
+	case DescHigh:
+		return CategoryNothing
+	case DescThree:
+		switch {
+		case typ.Pocket() == 2 && rank <= twoCardPair:
+			return CategoryPair
+		case typ.Pocket() == 2:
+			return CategoryNothing
+		case rank <= ThreeCardStraightFlush:
+			return CategoryStraightFlush
+		case rank <= ThreeCardThreeOfAKind:
+			return CategoryThreeOfAKind
+		case rank <= ThreeCardStraight:
+			return CategoryStraight
+		case rank <= ThreeCardFlush:
+			return CategoryFlush
+		case rank <= ThreeCardPair:
+			return CategoryPair
+		}
+		return CategoryNothing
+	default:
+		return categoryOf(rank)
+	}
+	return CategoryInvalid
+}
+
+// categoryOf returns the category of the Cactus rank.
+func categoryOf(r EvalRank) Category {
+	switch r.Fixed() {
+	case StraightFlush:
+		return CategoryStraightFlush
+	case FourOfAKind:
+		return CategoryFourOfAKind
+	case FullHouse:
+		return CategoryFullHouse
+	case Flush:
+		return CategoryFlush
+	case Straight:
+		return CategoryStraight
+	case ThreeOfAKind:
+		return CategoryThreeOfAKind
+	case TwoPair:
+		return CategoryTwoPair
+	case Pair:
+		return CategoryPair
+	case Nothing:
+		return CategoryNothing
+	}
+	return CategoryInvalid
+}
+
+// Name returns the category name.
+func (c Category) Name() string {
+	switch c {
+	case CategoryStraightFlush:
+		return "StraightFlush"
+	case CategoryFourOfAKind:
+		return "FourOfAKind"
+	case CategoryFullHouse:
+		return "FullHouse"
+	case CategoryFlush:
+		return "Flush"
+	case CategoryStraight:
+		return "Straight"
+	case CategoryThreeOfAKind:
+		return "ThreeOfAKind"
+	case CategoryTwoPair:
+		return "TwoPair"
+	case CategoryPair:
+		return "Pair"
+	case CategoryNothing:
+		return "Nothing"
+	case CategoryFourFlush:
+		return "FourFlush"
+	case CategoryFourStraight:
+		return "FourStraight"
+	case CategoryLow:
+		return "Low"
+	case CategoryBadugiFour:
+		return "BadugiFour"
+	case CategoryBadugiThree:
+		return "BadugiThree"
+	case CategoryBadugiTwo:
+		return "BadugiTwo"
+	case CategoryBadugiOne:
+		return "BadugiOne"
+	}
+	return "Invalid"
+}
+
+// Format satisfies the [fmt.Formatter] interface.
+func (c Category) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'd':
+		fmt.Fprint(f, uint8(c))
+	case 'n', 's', 'v':
+		fmt.Fprint(f, c.Name())
+	default:
+		fmt.Fprintf(f, "%%!%c(ERROR=unknown verb, category: %d)", verb, uint8(c))
+	}
+}
+
 // Name returns the eval rank name.
 //
 // Examples:
@@ -174,6 +465,51 @@ func (r EvalRank) FromFlushOver() EvalRank {
 	return r
 }
 
+// FromPartial converts a partial rank (see [RankPartial]) to the Cactus rank
+// of its set (ie, [FourOfAKind] through [Nothing]). Returns [Invalid] for an
+// invalid rank.
+func (r EvalRank) FromPartial() EvalRank {
+	switch {
+	case r == 0:
+		return Invalid
+	case r <= PartialFourOfAKind:
+		return FourOfAKind
+	case r <= PartialThreeOfAKind:
+		return ThreeOfAKind
+	case r <= PartialTwoPair:
+		return TwoPair
+	case r <= PartialPair:
+		return Pair
+	case r <= PartialNothing:
+		return Nothing
+	}
+	return Invalid
+}
+
+// ToSoko converts a Cactus rank to a [Soko] rank (see [RankSoko]).
+func (r EvalRank) ToSoko() EvalRank {
+	return toSoko(r, TwoPair)
+}
+
+// FromSoko converts a [Soko] rank to a Cactus rank. Returns [Invalid] for a
+// Four Flush or Four Straight.
+func (r EvalRank) FromSoko() EvalRank {
+	return fromSoko(r, TwoPair)
+}
+
+// ToSokoUnder converts a Cactus rank to a [Soko] rank, where a Four Flush and
+// Four Straight are under a [Pair] (see [RankSokoUnder]).
+func (r EvalRank) ToSokoUnder() EvalRank {
+	return toSoko(r, Pair)
+}
+
+// FromSokoUnder converts a [Soko] rank, where a Four Flush and Four Straight
+// are under a [Pair], to a Cactus rank. Returns [Invalid] for a Four Flush or
+// Four Straight.
+func (r EvalRank) FromSokoUnder() EvalRank {
+	return fromSoko(r, Pair)
+}
+
 // ToLowball converts a Cactus rank to a [Lowball] rank, by inverting the rank
 // and converting the lowest Straight and Straight Flushes (5-4-3-2-A) to
 // different ranks.
@@ -225,6 +561,137 @@ func (r EvalRank) FromLowball() EvalRank {
 	return r
 }
 
+// RankThree returns the eval rank of 3 cards, where a [Straight] ranks over a
+// [Flush], and [Ace]'s play both high and low in a [Straight] (see
+// [ThreeCardStraightFlush] through [ThreeCardNothing]).
+func RankThree(c0, c1, c2 Card) EvalRank {
+	v := [3]Rank{c0.Rank(), c1.Rank(), c2.Rank()}
+	for _, r := range v {
+		if Ace < r {
+			return Invalid
+		}
+	}
+	slices.SortFunc(v[:], func(a, b Rank) int {
+		return int(b) - int(a)
+	})
+	flush := c0&c1&c2&0xf000 != 0
+	switch {
+	case v[0] == v[2]:
+		return ThreeCardStraightFlush + 1 + EvalRank(Ace-v[0])
+	case v[0] == v[1]:
+		return ThreeCardFlush + 1 + 12*EvalRank(Ace-v[0]) + threeKicker(v[0], v[2])
+	case v[1] == v[2]:
+		return ThreeCardFlush + 1 + 12*EvalRank(Ace-v[1]) + threeKicker(v[1], v[0])
+	}
+	if high, ok := threeStraight(v); ok && flush {
+		return 1 + EvalRank(Ace-high)
+	} else if ok {
+		return ThreeCardThreeOfAKind + 1 + EvalRank(Ace-high)
+	}
+	i := threeNothing[1<<v[0]|1<<v[1]|1<<v[2]]
+	if flush {
+		return ThreeCardStraight + i
+	}
+	return ThreeCardPair + i
+}
+
+// RankPartial returns the eval rank of 3 or 4 cards, such as a flop, ranked
+// by sets only, as [Straight]'s and [Flush]'s are not possible with fewer than
+// 5 cards (see [PartialFourOfAKind] through [PartialNothing]). A 3 card hand
+// ranks under the 4 card hands with the same ranks. Returns [Invalid] for any
+// other count of cards.
+func RankPartial(v []Card) EvalRank {
+	if len(v) != 3 && len(v) != 4 {
+		return Invalid
+	}
+	n := uint32(1)
+	for _, c := range v {
+		if Ace < c.Rank() {
+			return Invalid
+		}
+		n *= uint32(c) & 0xff
+	}
+	return partial4[n]
+}
+
+// PartialEval evaluates the 3 or 4 pocket and board cards (see
+// [RankPartial]), returning the description of the best cards using
+// [DescPartial]. Returns nil for any other count of cards.
+func PartialEval(pocket, board []Card) *EvalDesc {
+	v := partial(pocket, board)
+	if v == nil {
+		return nil
+	}
+	rank := RankPartial(v)
+	if rank == Invalid {
+		return nil
+	}
+	bestSet(v)
+	return &EvalDesc{
+		Type:      DescPartial,
+		Rank:      rank,
+		Best:      v,
+		Qualified: true,
+	}
+}
+
+// rankTwo returns the eval rank of 2 cards, as either a pair or a high card.
+func rankTwo(c0, c1 Card) EvalRank {
+	r0, r1 := c0.Rank(), c1.Rank()
+	switch {
+	case Ace < r0, Ace < r1:
+		return Invalid
+	case r0 == r1:
+		return 1 + EvalRank(Ace-r0)
+	case r0 < r1:
+		r0, r1 = r1, r0
+	}
+	// high cards ordered by the high, then low rank
+	var n EvalRank
+	for r := Ace; r > r0; r-- {
+		n += EvalRank(r)
+	}
+	return twoCardPair + 1 + n + EvalRank(r0-1-r1)
+}
+
+// threeKicker returns the kicker index for a pair.
+func threeKicker(pair, kicker Rank) EvalRank {
+	i := EvalRank(Ace - kicker)
+	if kicker < pair {
+		i--
+	}
+	return i
+}
+
+// threeStraight returns the high rank of a 3 card straight for the descending
+// ordered ranks.
+func threeStraight(v [3]Rank) (Rank, bool) {
+	switch {
+	case v[0] == v[1]+1 && v[1] == v[2]+1:
+		return v[0], true
+	case v[0] == Ace && v[1] == Three && v[2] == Two:
+		return Three, true
+	}
+	return 0, false
+}
+
+// threeNothing are the 1-based indexes of 3 distinct, non-straight ranks,
+// keyed by the rank bit mask.
+var threeNothing = func() []EvalRank {
+	v, i := make([]EvalRank, 1<<13), EvalRank(1)
+	for r0 := Ace; r0 <= Ace; r0-- {
+		for r1 := r0 - 1; r1 < r0; r1-- {
+			for r2 := r1 - 1; r2 < r1; r2-- {
+				if _, ok := threeStraight([3]Rank{r0, r1, r2}); !ok {
+					v[1<<r0|1<<r1|1<<r2] = i
+					i++
+				}
+			}
+		}
+	}
+	return v
+}()
+
 // RankFunc returns the eval rank of 5 cards.
 type RankFunc func(c0, c1, c2, c3, c4 Card) EvalRank
 
@@ -260,6 +727,31 @@ func RankEightOrBetter(c0, c1, c2, c3, c4 Card) EvalRank {
 	return RankAceFiveLow(0xff00, c0, c1, c2, c3, c4)
 }
 
+// RankLowQualifier returns a A-to-5 low rank eval func and the maximum
+// qualifying eval rank for the qualifier rank. Hands containing a card ranked
+// higher than the qualifier do not qualify. [Ace]'s are low, [Straight]'s and
+// [Flush]'s do not count.
+//
+// A qualifier of [Eight] returns [RankEightOrBetter]. A qualifier of [Ace] or
+// [InvalidRank] returns a rank eval func without a qualifier, where any 5
+// unpaired cards qualify.
+func RankLowQualifier(qualifier Rank) (RankFunc, EvalRank) {
+	switch {
+	case qualifier == Eight:
+		return RankEightOrBetter, eightOrBetterMax
+	case qualifier == Ace, Ace < qualifier:
+		return func(c0, c1, c2, c3, c4 Card) EvalRank {
+			return RankAceFiveLow(0, c0, c1, c2, c3, c4)
+		}, aceFiveMax
+	}
+	// ace low index of the qualifier
+	n := qualifier.Index() + 1
+	mask := EvalRank(0xffff) << (n + 1)
+	return func(c0, c1, c2, c3, c4 Card) EvalRank {
+		return RankAceFiveLow(mask, c0, c1, c2, c3, c4)
+	}, 1 << (n + 2)
+}
+
 // RankShort is a [Short] rank eval func.
 func RankShort(c0, c1, c2, c3, c4 Card) EvalRank {
 	r := RankCactus(c0, c1, c2, c3, c4)
@@ -314,6 +806,17 @@ func RankRazz(c0, c1, c2, c3, c4 Card) EvalRank {
 	return Invalid - RankCactus(c0, c1, c2, c3, c4)
 }
 
+// RankCalifornia is a [California] (A-to-5) low rank eval func, where a
+// [Joker] plays as the lowest rank not already in the hand. Otherwise the same
+// as [RankRazz].
+func RankCalifornia(c0, c1, c2, c3, c4 Card) EvalRank {
+	if c0 == Joker || c1 == Joker || c2 == Joker || c3 == Joker || c4 == Joker {
+		v := jokerLow([]Card{c0, c1, c2, c3, c4})
+		c0, c1, c2, c3, c4 = v[0], v[1], v[2], v[3], v[4]
+	}
+	return RankRazz(c0, c1, c2, c3, c4)
+}
+
 // RankLowball is a [Lowball] (2-to-7) low rank eval func. [Ace]'s are high,
 // [Straight]'s and [Flush]'s count.
 //
@@ -325,10 +828,28 @@ func RankLowball(c0, c1, c2, c3, c4 Card) EvalRank {
 	return RankCactus(c0, c1, c2, c3, c4).ToLowball()
 }
 
+// RankAceSixLow is a [LowballAceSix] (A-to-6) low rank eval func. [Ace]'s
+// are low, [Straight]'s and [Flush]'s count.
+//
+// Works by shifting each card's rank up 1 (with [Ace]'s becoming [Two]'s), and
+// ranking the shifted cards as a [Lowball] rank, where the shifted
+// [Ace]-to-[Five] [Straight] does not count.
+//
+// See [EvalRank.ToLowball].
+func RankAceSixLow(c0, c1, c2, c3, c4 Card) EvalRank {
+	return RankCactus(aceSixShift(c0), aceSixShift(c1), aceSixShift(c2), aceSixShift(c3), aceSixShift(c4)).ToLowball()
+}
+
+// aceSixShift shifts the card's rank up 1, with [Ace]'s becoming [Two]'s.
+func aceSixShift(c Card) Card {
+	r := Rank(c.AceRank())
+	return 1<<Card(r)<<16 | c&0xf000 | Card(r)<<8 | Card(primes[r])
+}
+
 // EvalFunc is a eval func.
 type EvalFunc func(*Eval, []Card, []Card)
 
-// NewEval returns a eval func that ranks 5, 6, or 7 cards using f. The
+// NewEval returns a eval func that ranks 5, 6, 7, 8, or 9 cards using f. The
 // returned eval func will store the results on an eval's Hi.
 func NewEval(f RankFunc) EvalFunc {
 	return func(ev *Eval, p, b []Card) {
@@ -343,6 +864,10 @@ func NewEval(f RankFunc) EvalFunc {
 			eval = ev.Hi6
 		case 7:
 			eval = ev.Hi7
+		case 8:
+			eval = ev.Hi8
+		case 9:
+			eval = ev.Hi9
 		}
 		v := make([]Card, np+nb)
 		copy(v, p)
@@ -351,7 +876,8 @@ func NewEval(f RankFunc) EvalFunc {
 	}
 }
 
-// NewMaxEval returns a eval func that ranks 5, 6, or 7 cards using f and max.
+// NewMaxEval returns a eval func that ranks 5, 6, 7, 8, or 9 cards using f
+// and max.
 //
 // The returned eval func will store results on an eval's Hi only when lower
 // than max.
@@ -368,6 +894,10 @@ func NewMaxEval(f RankFunc, maximum EvalRank, low bool) EvalFunc {
 			eval = ev.Max6
 		case 7:
 			eval = ev.Max7
+		case 8:
+			eval = ev.Max8
+		case 9:
+			eval = ev.Max9
 		}
 		v := make([]Card, np+nb)
 		copy(v, p)
@@ -376,8 +906,8 @@ func NewMaxEval(f RankFunc, maximum EvalRank, low bool) EvalFunc {
 	}
 }
 
-// NewSplitEval returns a eval func that ranks 5, 6, or 7 cards using hi, lo
-// and max.
+// NewSplitEval returns a eval func that ranks 5, 6, 7, 8, or 9 cards using
+// hi, lo and max.
 //
 // The returned eval func will store results on an eval's Hi and Lo depending
 // on the result of hi and lo, respectively. Will store the Lo value only when
@@ -395,6 +925,10 @@ func NewSplitEval(hi, lo RankFunc, maximum EvalRank) EvalFunc {
 			eval = ev.HiLo6
 		case 7:
 			eval = ev.HiLo7
+		case 8:
+			eval = ev.HiLo8
+		case 9:
+			eval = ev.HiLo9
 		}
 		v := make([]Card, np+nb)
 		copy(v, p)
@@ -404,42 +938,62 @@ func NewSplitEval(hi, lo RankFunc, maximum EvalRank) EvalFunc {
 }
 
 // NewHybridEval creates a hybrid Cactus and TwoPlusTwo eval func, using
-// [RankCactus] for 5 and 6 cards, and a TwoPlusTwo eval func for 7 cards.
+// [RankCactus] for 5, 6, 8, and 9 cards, and a TwoPlusTwo eval func for 7
+// cards. Uses the TwoPlusTwo eval func for 6 cards when the active backend is
+// [BackendTwoPlusTwo] (see [SetBackend]).
 //
 // Gives optimal performance when evaluating the best-5 of any 5, 6, or 7 cards
 // of a combined pocket and board.
 func NewHybridEval(normalize, low bool) EvalFunc {
+	return NewHybridEvalWith(normalize, low, Eight)
+}
+
+// NewHybridEvalWith creates a hybrid Cactus and TwoPlusTwo eval func (see
+// [NewHybridEval]), evaluating the Lo using the qualifier (see
+// [RankLowQualifier]).
+func NewHybridEvalWith(normalize, low bool, qualifier Rank) EvalFunc {
 	var f EvalFunc
+	lo, maximum := RankLowQualifier(qualifier)
 	if low {
-		f = NewSplitEval(RankCactus, RankEightOrBetter, eightOrBetterMax)
+		f = NewSplitEval(RankCactus, lo, maximum)
 	} else {
 		f = NewEval(RankCactus)
 	}
+	// evaluate 6 cards natively when TwoPlusTwo is used for all cards
+	six := backend == BackendTwoPlusTwo
 	return func(ev *Eval, p, b []Card) {
-		switch np, nb := len(p), len(b); np + nb {
-		case 5, 6:
-			f(ev, p, b)
-			if normalize {
-				bestCactus(ev.HiRank, ev.HiBest, ev.HiUnused, 0, nil)
-				if low && ev.LoRank < eightOrBetterMax {
-					bestAceLow(ev.LoBest)
-					bestAceHigh(ev.LoUnused)
-				}
-			}
-		case 7:
-			v := make([]Card, np+nb)
+		np, nb := len(p), len(b)
+		switch n := np + nb; {
+		case n == 7, n == 6 && six:
+			v := make([]Card, n)
 			copy(v, p)
 			copy(v[np:], b)
 			ev.HiRank = twoPlusTwo(v)
 			if normalize {
 				ev.HiBest, ev.HiUnused = bestCactusSplit(ev.HiRank, v, 0)
+			} else {
+				// keep the unsplit cards for a later normalize
+				ev.HiUnused = v
 			}
 			if low {
-				u := make([]Card, np+nb)
+				u := make([]Card, n)
 				copy(u, p)
 				copy(u[np:], b)
-				ev.Max7(RankEightOrBetter, u, eightOrBetterMax, true)
-				if normalize && ev.LoRank < eightOrBetterMax {
+				if n == 6 {
+					ev.Max6(lo, u, maximum, true)
+				} else {
+					ev.Max7(lo, u, maximum, true)
+				}
+				if normalize && ev.LoRank < maximum {
+					bestAceLow(ev.LoBest)
+					bestAceHigh(ev.LoUnused)
+				}
+			}
+		case 5 <= n && n <= 9:
+			f(ev, p, b)
+			if normalize {
+				bestCactus(ev.HiRank, ev.HiBest, ev.HiUnused, 0, nil)
+				if low && ev.LoRank < maximum {
 					bestAceLow(ev.LoBest)
 					bestAceHigh(ev.LoUnused)
 				}
@@ -448,20 +1002,29 @@ func NewHybridEval(normalize, low bool) EvalFunc {
 	}
 }
 
-// NewCactusEval creates a Cactus eval func.
+// NewCactusEval creates a Cactus eval func. Uses a hybrid eval func (see
+// [NewHybridEval]) when the active backend uses TwoPlusTwo (see [SetBackend]).
 func NewCactusEval(board int, normalize, low bool) EvalFunc {
+	return NewCactusEvalWith(board, normalize, low, Eight)
+}
+
+// NewCactusEvalWith creates a Cactus eval func (see [NewCactusEval]),
+// evaluating the Lo using the qualifier (see [RankLowQualifier]).
+func NewCactusEvalWith(board int, normalize, low bool, qualifier Rank) EvalFunc {
 	var f EvalFunc
+	hybrid := hybridTwoPlusTwo()
 	switch {
-	case twoPlusTwo != nil:
-		f = NewHybridEval(normalize, low)
+	case hybrid:
+		f = NewHybridEvalWith(normalize, low, qualifier)
 	case low:
-		f = NewSplitEval(RankCactus, RankEightOrBetter, eightOrBetterMax)
+		lo, maximum := RankLowQualifier(qualifier)
+		f = NewSplitEval(RankCactus, lo, maximum)
 	default:
 		f = NewEval(RankCactus)
 	}
 	return func(ev *Eval, p, b []Card) {
 		if nb := len(b); len(p) < 3 && nb < 4 {
-			if r := StartingEvalRank(p); r != 0 && r != Invalid {
+			if r := startingEvalRank(ev.Type, p, true); r != 0 && r != Invalid {
 				ev.HiRank, ev.HiBest = r, make([]Card, 5)
 				copy(ev.HiBest, p)
 				if nb == 3 {
@@ -471,8 +1034,15 @@ func NewCactusEval(board int, normalize, low bool) EvalFunc {
 				return
 			}
 		}
+		if v := partial(p, b); v != nil {
+			ev.HiPartial(v)
+			if normalize {
+				bestCactus(ev.HiRank, ev.HiBest, nil, 0, nil)
+			}
+			return
+		}
 		f(ev, p, b)
-		if normalize && twoPlusTwo == nil {
+		if normalize && !hybrid {
 			bestCactus(ev.HiRank, ev.HiBest, ev.HiUnused, 0, nil)
 			if low {
 				bestAceLow(ev.LoBest)
@@ -484,13 +1054,27 @@ func NewCactusEval(board int, normalize, low bool) EvalFunc {
 
 // NewModifiedEval creates a modified Cactus eval.
 func NewModifiedEval(hi RankFunc, base Rank, inv func(EvalRank) EvalRank, normalize, low bool) EvalFunc {
+	return NewModifiedEvalWith(hi, base, inv, normalize, low, Eight)
+}
+
+// NewModifiedEvalWith creates a modified Cactus eval (see [NewModifiedEval]),
+// evaluating the Lo using the qualifier (see [RankLowQualifier]).
+func NewModifiedEvalWith(hi RankFunc, base Rank, inv func(EvalRank) EvalRank, normalize, low bool, qualifier Rank) EvalFunc {
 	var f EvalFunc
 	if low {
-		f = NewSplitEval(hi, RankEightOrBetter, eightOrBetterMax)
+		lo, maximum := RankLowQualifier(qualifier)
+		f = NewSplitEval(hi, lo, maximum)
 	} else {
 		f = NewEval(hi)
 	}
 	return func(ev *Eval, p, b []Card) {
+		if len(p) < 3 && len(b) < 3 {
+			if r := startingEvalRank(ev.Type, p, false); r != 0 && r != Invalid {
+				ev.HiRank, ev.HiBest = r, slices.Clone(p)
+				bestAceHigh(ev.HiBest)
+				return
+			}
+		}
 		f(ev, p, b)
 		if normalize {
 			bestCactus(ev.HiRank, ev.HiBest, ev.HiUnused, base, inv)
@@ -516,20 +1100,32 @@ func NewJacksOrBetterEval(normalize bool) EvalFunc {
 // NewOmahaEval creates a [Omaha] eval func.
 //
 // Uses any 2 from 2, 3, 4, 5, or 6 pocket cards, and any 3 from 3, 4 or 5
-// board cards to make a best-5.
+// board cards to make a best-5. The memory of the eval's best and unused cards
+// is reused when the eval is reused by a odds calc (see [WithReuse]).
 func NewOmahaEval(hi RankFunc, base Rank, inv func(EvalRank) EvalRank, normalize, low bool) EvalFunc {
+	return NewOmahaEvalWith(hi, base, inv, normalize, low, Eight)
+}
+
+// NewOmahaEvalWith creates a [Omaha] eval func (see [NewOmahaEval]),
+// evaluating the Lo using the qualifier (see [RankLowQualifier]).
+func NewOmahaEvalWith(hi RankFunc, base Rank, inv func(EvalRank) EvalRank, normalize, low bool, qualifier Rank) EvalFunc {
+	lo, maximum := RankLowQualifier(qualifier)
 	return func(ev *Eval, p, b []Card) {
 		np, nb := len(p), len(b)
 		switch {
-		case 6 < np:
+		case 6 < np, 5 < nb:
 			return
-		case nb < 3:
-			ev.HiRank, ev.HiBest = StartingEvalRank(p), p
-			return
-		case 5 < nb:
+		case np < 2, nb < 3:
+			// provisional rank for a partial pocket or board, using the best 2
+			// pocket cards
+			ev.HiRank = startingEvalRank(ev.Type, p, true)
+			ev.HiBest, ev.HiUnused = bestStartingPocket(ev.Type, p, b)
+			bestAceHigh(ev.HiBest)
+			bestAceHigh(ev.HiUnused)
+			ev.HiPocket = pocketMask(ev.HiBest, p)
 			return
 		}
-		var fp, fb func([]Card) ([][]Card, int)
+		var fp, fb func(*cardBuf, []Card) ([][]Card, int)
 		switch np {
 		case 2:
 			fp = take2c2
@@ -550,12 +1146,18 @@ func NewOmahaEval(hi RankFunc, base Rank, inv func(EvalRank) EvalRank, normalize
 		case 5:
 			fb = take5c3
 		}
-		vp, ip := fp(p)
-		vb, ib := fb(b)
-		ev.HiUnused = make([]Card, np+nb-5)
+		bp, bb := cardBufPool.Get().(*cardBuf), cardBufPool.Get().(*cardBuf)
+		defer cardBufPool.Put(bp)
+		defer cardBufPool.Put(bb)
+		vp, ip := fp(bp, p)
+		vb, ib := fb(bb, b)
+		// reuse the eval's cards, only when owned by the eval
 		var loBest, loUnused []Card
-		if low {
-			loUnused = make([]Card, np+nb-5)
+		if ev.reuse {
+			ev.HiBest, ev.HiUnused = ev.HiBest[:0], ev.HiUnused[:0]
+			loBest, loUnused = ev.LoBest[:0], ev.LoUnused[:0]
+		} else {
+			ev.HiBest, ev.HiUnused = nil, nil
 		}
 		var c0, c1, c2, c3, c4 Card
 		for i, r := 0, EvalRank(0); i < ip; i++ {
@@ -563,14 +1165,14 @@ func NewOmahaEval(hi RankFunc, base Rank, inv func(EvalRank) EvalRank, normalize
 				c0, c1, c2, c3, c4 = vp[i][0], vp[i][1], vb[j][0], vb[j][1], vb[j][2]
 				if r = hi(c0, c1, c2, c3, c4); r < ev.HiRank {
 					ev.HiRank = r
-					ev.HiBest = []Card{c0, c1, c2, c3, c4}
+					ev.HiBest = append(ev.HiBest[:0], c0, c1, c2, c3, c4)
 					ev.HiUnused = append(ev.HiUnused[:0], vp[i][2:]...)
 					ev.HiUnused = append(ev.HiUnused, vb[j][3:]...)
 				}
 				if low {
-					if r = RankEightOrBetter(c0, c1, c2, c3, c4); r < eightOrBetterMax && r < ev.LoRank {
+					if r = lo(c0, c1, c2, c3, c4); r < maximum && r < ev.LoRank {
 						ev.LoRank = r
-						loBest = []Card{c0, c1, c2, c3, c4}
+						loBest = append(loBest[:0], c0, c1, c2, c3, c4)
 						loUnused = append(loUnused[:0], vp[i][2:]...)
 						loUnused = append(loUnused, vb[j][3:]...)
 					}
@@ -584,28 +1186,125 @@ func NewOmahaEval(hi RankFunc, base Rank, inv func(EvalRank) EvalRank, normalize
 			bestCactus(ev.HiRank, ev.HiBest, nil, base, inv)
 			bestAceHigh(ev.HiUnused)
 			switch {
-			case low && ev.LoRank < eightOrBetterMax:
+			case low && ev.LoRank < maximum:
 				bestAceLow(ev.LoBest)
 				bestAceHigh(ev.LoUnused)
 			case low:
 				ev.LoBest, ev.LoUnused = nil, nil
 			}
 		}
+		ev.HiPocket, ev.LoPocket = pocketMask(ev.HiBest, p), pocketMask(ev.LoBest, p)
+	}
+}
+
+// bestStartingPocket returns the 2 pocket cards having the best starting eval
+// rank (see [StartingEvalRank]), and the unused pocket and board cards.
+func bestStartingPocket(typ Type, p, b []Card) ([]Card, []Card) {
+	if len(p) <= 2 {
+		return slices.Clone(p), slices.Clone(b)
+	}
+	i, j, r := 0, 1, Invalid
+	for k := range len(p) - 1 {
+		for l := k + 1; l < len(p); l++ {
+			if n := startingEvalRank(typ, []Card{p[k], p[l]}, true); n < r {
+				i, j, r = k, l, n
+			}
+		}
+	}
+	unused := make([]Card, 0, len(p)+len(b)-2)
+	for k, c := range p {
+		if k != i && k != j {
+			unused = append(unused, c)
+		}
+	}
+	return []Card{p[i], p[j]}, append(unused, b...)
+}
+
+// NewForcedTwoEval creates a eval func forcing the use of exactly 2 pocket
+// cards and 3 board cards to make a best-5, using hi, and lo when not nil,
+// where a lo rank must be less than maximum. Like [NewOmahaEval] without the
+// limits on the pocket and board counts, allowing custom types resembling
+// [Dallas] or [Houston] with larger boards. See [Eval.HiLo2].
+//
+// The eval does not order the best and unused cards. Use [Eval.Normalize] to
+// order the eval's cards before display.
+func NewForcedTwoEval(hi, lo RankFunc, maximum EvalRank) EvalFunc {
+	return func(ev *Eval, p, b []Card) {
+		np := len(p)
+		switch {
+		case np < 2, len(b) < 3:
+			return
+		case np == 2:
+			ev.HiLo2(hi, lo, p[0], p[1], b, maximum)
+		default:
+			for i := range np - 1 {
+				for j := i + 1; j < np; j++ {
+					e := &Eval{HiRank: Invalid, LoRank: Invalid}
+					e.HiLo2(hi, lo, p[i], p[j], b, maximum)
+					if e.HiRank < ev.HiRank {
+						ev.HiRank, ev.HiBest = e.HiRank, e.HiBest
+						ev.HiUnused = append(forcedUnused(p, i, j), e.HiUnused...)
+					}
+					if e.LoRank < ev.LoRank {
+						ev.LoRank, ev.LoBest = e.LoRank, e.LoBest
+						ev.LoUnused = append(forcedUnused(p, i, j), e.LoUnused...)
+					}
+				}
+			}
+		}
+		ev.HiPocket, ev.LoPocket = pocketMask(ev.HiBest, p), pocketMask(ev.LoBest, p)
+	}
+}
+
+// forcedUnused returns the pocket cards other than the cards at i and j.
+func forcedUnused(p []Card, i, j int) []Card {
+	v := make([]Card, 0, len(p)-2)
+	for k, c := range p {
+		if k != i && k != j {
+			v = append(v, c)
+		}
 	}
+	return v
 }
 
 // NewSokoEval creates a [Soko] eval func.
 func NewSokoEval(normalize, low bool) EvalFunc {
+	return NewSokoEvalWith(normalize, low, Eight)
+}
+
+// NewSokoEvalWith creates a [Soko] eval func, evaluating the Lo using the
+// qualifier (see [RankLowQualifier]).
+func NewSokoEvalWith(normalize, low bool, qualifier Rank) EvalFunc {
+	return newSokoEval(RankSoko, TwoPair, normalize, low, qualifier)
+}
+
+// NewSokoUnderEval creates a [Soko] eval func, where a Four Flush and Four
+// Straight are under a [Pair].
+func NewSokoUnderEval(normalize, low bool) EvalFunc {
+	return NewSokoUnderEvalWith(normalize, low, Eight)
+}
+
+// NewSokoUnderEvalWith creates a [Soko] eval func, where a Four Flush and
+// Four Straight are under a [Pair], evaluating the Lo using the qualifier
+// (see [RankLowQualifier]).
+func NewSokoUnderEvalWith(normalize, low bool, qualifier Rank) EvalFunc {
+	return newSokoEval(RankSokoUnder, Pair, normalize, low, qualifier)
+}
+
+// newSokoEval creates a [Soko] eval func using the rank func, where over is
+// the rank directly over a Four Flush.
+func newSokoEval(hi RankFunc, over EvalRank, normalize, low bool, qualifier Rank) EvalFunc {
 	var f EvalFunc
 	if low {
-		f = NewSplitEval(RankSoko, RankEightOrBetter, eightOrBetterMax)
+		lo, maximum := RankLowQualifier(qualifier)
+		f = NewSplitEval(hi, lo, maximum)
 	} else {
-		f = NewEval(RankSoko)
+		f = NewEval(hi)
 	}
 	return func(ev *Eval, p, b []Card) {
 		f(ev, p, b)
 		if normalize {
-			bestSoko(ev.HiRank, ev.HiBest, ev.HiUnused)
+			bestSoko(ev.HiRank, over, ev.HiBest, ev.HiUnused)
 			if low {
 				bestAceLow(ev.LoBest)
 				bestAceHigh(ev.LoUnused)
@@ -626,20 +1325,41 @@ func NewLowballEval(normalize bool) EvalFunc {
 	}
 }
 
+// NewAceSixEval creates a [LowballAceSix] eval func.
+func NewAceSixEval(normalize bool) EvalFunc {
+	f := NewEval(RankAceSixLow)
+	return func(ev *Eval, p, b []Card) {
+		f(ev, p, b)
+		if normalize {
+			bestAceSix(ev.HiBest)
+			bestAceHigh(ev.HiUnused)
+		}
+	}
+}
+
 // NewRazzEval creates a [Razz] eval func.
 func NewRazzEval(normalize bool) EvalFunc {
 	f := NewEval(RankRazz)
+	return func(ev *Eval, p, b []Card) {
+		if v := partial(p, b); v != nil {
+			ev.HiRank, ev.HiBest, ev.HiUnused = razzPartial(v), v, nil
+		} else {
+			f(ev, p, b)
+		}
+		if normalize {
+			bestRazz(ev.HiRank, ev.HiBest)
+			bestAceHigh(ev.HiUnused)
+		}
+	}
+}
+
+// NewCaliforniaEval creates a [California] eval func.
+func NewCaliforniaEval(normalize bool) EvalFunc {
+	f := NewEval(RankCalifornia)
 	return func(ev *Eval, p, b []Card) {
 		f(ev, p, b)
 		if normalize {
-			if ev.HiRank < aceFiveMax {
-				bestAceLow(ev.HiBest)
-			} else {
-				switch (Invalid - ev.HiRank).Fixed() {
-				case FourOfAKind, FullHouse, ThreeOfAKind, TwoPair, Pair:
-					bestSet(ev.HiBest)
-				}
-			}
+			bestCalifornia(ev.HiRank, ev.HiBest)
 			bestAceHigh(ev.HiUnused)
 		}
 	}
@@ -712,20 +1432,36 @@ func NewHighEval() EvalFunc {
 	}
 }
 
-/*
-// NewThreeEval creates a best-3 eval func.
+// NewThreeEval creates a best-3 eval func, ranking 3 cards using [RankThree],
+// or 2 cards as either a [Pair] or high card (ranked 1 through 91). The
+// ranks for 3 cards are:
 //
 //	Straight Flush
 //	Three of a Kind
 //	Straight
 //	Flush
-//	One Pair
+//	Pair
 //	High Card
-func NewThreeEval() EvalFunc {
+func NewThreeEval(normalize bool) EvalFunc {
 	return func(ev *Eval, p, b []Card) {
+		v := slices.Concat(p, b)
+		switch len(v) {
+		case 2:
+			ev.HiRank = rankTwo(v[0], v[1])
+		case 3:
+			ev.HiRank = RankThree(v[0], v[1], v[2])
+		default:
+			return
+		}
+		if ev.HiRank == Invalid {
+			return
+		}
+		ev.HiBest = v
+		if normalize {
+			bestThree(ev.HiRank, v)
+		}
 	}
 }
-*/
 
 /*
 // NewLeducEval creates a matching high card eval func.
@@ -735,6 +1471,24 @@ func NewLeducEval() EvalFunc {
 }
 */
 
+// Ranker is the interface for ordering evals (see [OrderOf]).
+type Ranker interface {
+	// Rank returns the Hi/Lo eval rank, and false when not evaluated (such as
+	// a folded position). Rankers that were not evaluated are ordered last.
+	Rank(low bool) (EvalRank, bool)
+}
+
+// Describer is the interface for describing winning evals (see [ResultOf]
+// and [Win]).
+type Describer interface {
+	Ranker
+	// Desc returns a descriptor for the Hi/Lo, or nil when not evaluated. The
+	// descriptor's Qualified must be set for a qualifying Hi/Lo, as a win
+	// only describes qualified descriptors (see [Win.Desc] and
+	// [Win.Invalid]).
+	Desc(low bool) *EvalDesc
+}
+
 // Eval contains the eval results of a type's Hi/Lo.
 type Eval struct {
 	Type     Type
@@ -744,20 +1498,242 @@ type Eval struct {
 	LoRank   EvalRank
 	LoBest   []Card
 	LoUnused []Card
+	// HiPocket is a mask of the hi best cards from the pocket, where bit i
+	// is set when HiBest[i] is a pocket card. Only set by Omaha-like evals.
+	HiPocket uint8
+	// LoPocket is a mask of the lo best cards from the pocket, where bit i
+	// is set when LoBest[i] is a pocket card. Only set by Omaha-like evals.
+	LoPocket uint8
+	// reuse is set when the eval's best and unused cards are owned by the
+	// eval, and their memory can be reused (see [WithReuse]).
+	reuse bool
+}
+
+// EvalOf creates a eval for the type.
+func EvalOf(typ Type) *Eval {
+	return &Eval{
+		Type:   typ,
+		HiRank: Invalid,
+		LoRank: Invalid,
+	}
+}
+
+// Eval evaluates the pocket, board.
+func (ev *Eval) Eval(pocket, board []Card) {
+	evals[ev.Type](ev, pocket, board)
+}
+
+// Clone returns a copy of the eval.
+func (ev *Eval) Clone() *Eval {
+	if ev == nil {
+		return nil
+	}
+	c := *ev
+	c.HiBest, c.HiUnused = slices.Clone(ev.HiBest), slices.Clone(ev.HiUnused)
+	c.LoBest, c.LoUnused = slices.Clone(ev.LoBest), slices.Clone(ev.LoUnused)
+	return &c
+}
+
+// SetLoFrom sets the eval's Lo from b's Hi. Used to assemble the eval of a
+// double board, where the Lo is the Hi of the second board.
+func (ev *Eval) SetLoFrom(b *Eval) {
+	ev.LoRank, ev.LoBest, ev.LoUnused, ev.LoPocket = b.HiRank, b.HiBest, b.HiUnused, b.HiPocket
+}
+
+// CheckEval checks the invariants of an eval of the pocket and board, returning
+// a [ErrInvalidEval] error describing the first failed invariant. Checks that
+// Cactus evals (see [Type.Cactus]) have a best-5, that the best and unused
+// cards are the same cards as the pocket and board, and that the ranks match
+// a re-evaluation of the pocket and board. Only ranks are checked for partial
+// pockets and boards.
+func CheckEval(ev *Eval, pocket, board []Card) error {
+	f, ok := evals[ev.Type]
+	if !ok {
+		return ErrUnsupportedType
+	}
+	exp := EvalOf(ev.Type)
+	f(exp, pocket, board)
+	switch {
+	case ev.HiRank != exp.HiRank:
+		return fmt.Errorf("%w: hi rank %d, expected %d", ErrInvalidEval, ev.HiRank, exp.HiRank)
+	case ev.LoRank != exp.LoRank:
+		return fmt.Errorf("%w: lo rank %d, expected %d", ErrInvalidEval, ev.LoRank, exp.LoRank)
+	case ev.HiRank == Invalid,
+		len(pocket) < ev.Type.Pocket(),
+		len(board) < ev.Type.Board():
+		return nil
+	case ev.Type.Cactus() && len(ev.HiBest) != 5:
+		return fmt.Errorf("%w: hi best has %d cards, expected 5", ErrInvalidEval, len(ev.HiBest))
+	case !sameCards(append(slices.Clone(ev.HiBest), ev.HiUnused...), pocket, board):
+		return fmt.Errorf("%w: hi best %v and unused %v are not the pocket %v and board %v", ErrInvalidEval, ev.HiBest, ev.HiUnused, pocket, board)
+	case ev.LoRank != Invalid && ev.LoBest != nil && !sameCards(append(slices.Clone(ev.LoBest), ev.LoUnused...), pocket, board):
+		return fmt.Errorf("%w: lo best %v and unused %v are not the pocket %v and board %v", ErrInvalidEval, ev.LoBest, ev.LoUnused, pocket, board)
+	}
+	return ValidateBest(ev.Type, ev.HiBest, pocket, board)
+}
+
+// ValidateBest validates that the best cards satisfy the type's structural
+// constraints for the pocket and board, returning a [ErrInvalidEval] error
+// describing the first failed constraint. Checks that the best cards are
+// distinct cards from the pocket and board, that Cactus evals (see
+// [Type.Cactus]) have a best-5, and that [Omaha], [Manila], [Spanish] and
+// other Omaha-like evals use exactly 2 pocket cards and 3 board cards.
+//
+// Returns [ErrUnsupportedType] for an unregistered type, as does [CheckEval].
+//
+// Useful for verifying results from other sources, and as a test oracle.
+func ValidateBest(typ Type, best, pocket, board []Card) error {
+	desc, ok := descs[typ]
+	if !ok {
+		return ErrUnsupportedType
+	}
+	var np, nb int
+	for i, c := range best {
+		switch {
+		case slices.Contains(best[:i], c):
+			return fmt.Errorf("%w: best %v has duplicate card %v", ErrInvalidEval, best, c)
+		case slices.Contains(pocket, c):
+			np++
+		case slices.Contains(board, c):
+			nb++
+		default:
+			return fmt.Errorf("%w: best card %v is not in the pocket %v or board %v", ErrInvalidEval, c, pocket, board)
+		}
+	}
+	if desc.Eval.Cactus() && len(best) != 5 {
+		return fmt.Errorf("%w: best has %d cards, expected 5", ErrInvalidEval, len(best))
+	}
+	switch desc.Eval {
+	case EvalManila, EvalSpanish, EvalOmaha:
+		if np != 2 || nb != 3 {
+			return fmt.Errorf("%w: best %v uses %d pocket and %d board cards, expected 2 and 3", ErrInvalidEval, best, np, nb)
+		}
+	}
+	return nil
+}
+
+// sameCards returns true when v contains the same cards as the pocket and
+// board.
+func sameCards(v, pocket, board []Card) bool {
+	if len(v) != len(pocket)+len(board) {
+		return false
+	}
+	m := make(map[Card]int)
+	for _, c := range v {
+		m[c]++
+	}
+	for _, u := range [][]Card{pocket, board} {
+		for _, c := range u {
+			if m[c]--; m[c] < 0 {
+				return false
+			}
+		}
+	}
+	return true
 }
 
-// EvalOf creates a eval for the type.
-func EvalOf(typ Type) *Eval {
-	return &Eval{
-		Type:   typ,
-		HiRank: Invalid,
-		LoRank: Invalid,
+// Normalize orders the eval's best and unused cards as done by the type's
+// eval func. Used to lazily order evals created using the type's
+// unnormalized calc func (see [Type.Calc]), such as when only some of the
+// evals are displayed.
+func (ev *Eval) Normalize() {
+	desc, ok := descs[ev.Type]
+	if !ok || ev.HiRank == Invalid {
+		return
+	}
+	switch desc.Eval {
+	case EvalCactus, EvalJacksOrBetter:
+		switch {
+		case len(ev.HiBest) == 0 && 5 < len(ev.HiUnused):
+			ev.HiBest, ev.HiUnused = bestCactusSplit(ev.HiRank, ev.HiUnused, 0)
+		case complete(ev.HiBest), len(ev.HiBest) == 3, len(ev.HiBest) == 4:
+			bestCactus(ev.HiRank, ev.HiBest, ev.HiUnused, 0, nil)
+		}
+	case EvalShort:
+		if complete(ev.HiBest) {
+			bestCactus(ev.HiRank, ev.HiBest, ev.HiUnused, Rank(DeckShort), EvalRank.FromFlushOver)
+		}
+	case EvalManila, EvalSpanish, EvalOmaha:
+		var inv func(EvalRank) EvalRank
+		base := Rank(DeckFrench)
+		switch desc.Eval {
+		case EvalManila:
+			base, inv = Rank(DeckManila), EvalRank.FromFlushOver
+		case EvalSpanish:
+			base, inv = Rank(DeckSpanish), EvalRank.FromFlushOver
+		}
+		if complete(ev.HiBest) {
+			v := masked(ev.HiBest, ev.HiPocket)
+			bestCactus(ev.HiRank, ev.HiBest, nil, base, inv)
+			bestAceHigh(ev.HiUnused)
+			ev.HiPocket = pocketMask(ev.HiBest, v)
+		}
+	case EvalSoko:
+		bestSoko(ev.HiRank, TwoPair, ev.HiBest, ev.HiUnused)
+	case EvalSokoUnder:
+		bestSoko(ev.HiRank, Pair, ev.HiBest, ev.HiUnused)
+	case EvalLowball:
+		bestAceHigh(ev.HiBest)
+		bestAceHigh(ev.HiUnused)
+	case EvalRazz:
+		bestRazz(ev.HiRank, ev.HiBest)
+		bestAceHigh(ev.HiUnused)
+	case EvalAceSix:
+		bestAceSix(ev.HiBest)
+		bestAceHigh(ev.HiUnused)
+	case EvalCalifornia:
+		bestCalifornia(ev.HiRank, ev.HiBest)
+		bestAceHigh(ev.HiUnused)
+	case EvalBadugi:
+		bestAceLow(ev.HiBest)
+		bestAceHigh(ev.HiUnused)
+	case EvalThree:
+		bestThree(ev.HiRank, ev.HiBest)
+	}
+	if desc.Low && ev.HasLo() {
+		v := masked(ev.LoBest, ev.LoPocket)
+		bestAceLow(ev.LoBest)
+		bestAceHigh(ev.LoUnused)
+		ev.LoPocket = pocketMask(ev.LoBest, v)
+	}
+}
+
+// HiPocketCards returns the hi best cards from the pocket and board.
+func (ev *Eval) HiPocketCards() ([]Card, []Card) {
+	return masked(ev.HiBest, ev.HiPocket), masked(ev.HiBest, ^ev.HiPocket)
+}
+
+// LoPocketCards returns the lo best cards from the pocket and board.
+func (ev *Eval) LoPocketCards() ([]Card, []Card) {
+	return masked(ev.LoBest, ev.LoPocket), masked(ev.LoBest, ^ev.LoPocket)
+}
+
+// Rank returns the eval's Hi/Lo rank. Returns false for a nil eval. Satisfies
+// the [Ranker] interface.
+func (ev *Eval) Rank(low bool) (EvalRank, bool) {
+	switch {
+	case ev == nil:
+		return Invalid, false
+	case low:
+		return ev.LoRank, true
 	}
+	return ev.HiRank, true
 }
 
-// Eval evaluates the pocket, board.
-func (ev *Eval) Eval(pocket, board []Card) {
-	evals[ev.Type](ev, pocket, board)
+// BoardPlays returns true when the eval's Hi rank is made entirely by the
+// board, such as when all remaining positions chop a [Holdem] pot playing the
+// board. Always returns false for types forcing the use of pocket cards (ie,
+// [Omaha]), or for a board of less than 5 cards.
+func (ev *Eval) BoardPlays(board []Card) bool {
+	switch {
+	case ev == nil, ev.HiRank == Invalid, len(board) < 5:
+		return false
+	}
+	switch descs[ev.Type].Eval {
+	case EvalManila, EvalSpanish, EvalOmaha:
+		return false
+	}
+	return ev.Type.Calc(nil, board).HiRank == ev.HiRank
 }
 
 // Comp compares the eval's Hi/Lo to b's Hi/Lo.
@@ -781,6 +1757,15 @@ func (ev *Eval) Comp(b *Eval, low bool) int {
 	return 0
 }
 
+// Payout returns the amount paid per unit bet for the eval's Hi using the
+// pay table.
+func (ev *Eval) Payout(p Payout) float64 {
+	if ev == nil {
+		return 0
+	}
+	return p.Pay(ev.Type, ev.HiRank)
+}
+
 // Desc returns a descriptior for the eval's Hi/Lo.
 func (ev *Eval) Desc(low bool) *EvalDesc {
 	switch {
@@ -788,18 +1773,41 @@ func (ev *Eval) Desc(low bool) *EvalDesc {
 		return nil
 	case !low:
 		return &EvalDesc{
-			Type:   ev.Type.Desc().HiDesc,
-			Rank:   ev.HiRank,
-			Best:   ev.HiBest,
-			Unused: ev.HiUnused,
+			Type:      ev.Type.Desc().HiDesc,
+			Rank:      ev.HiRank,
+			Best:      ev.HiBest,
+			Unused:    ev.HiUnused,
+			Qualified: qualified(ev.HiRank),
 		}
 	}
 	return &EvalDesc{
-		Type:   ev.Type.Desc().LoDesc,
-		Rank:   ev.LoRank,
-		Best:   ev.LoBest,
-		Unused: ev.LoUnused,
+		Type:      ev.Type.Desc().LoDesc,
+		Rank:      ev.LoRank,
+		Best:      ev.LoBest,
+		Unused:    ev.LoUnused,
+		Qualified: ev.HasLo(),
+	}
+}
+
+// ProfileDesc returns a descriptor for the eval's Hi/Lo, using the
+// description profile's overrides. See [DescProfile].
+func (ev *Eval) ProfileDesc(p *DescProfile, low bool) *EvalDesc {
+	desc := ev.Desc(low)
+	if desc != nil {
+		desc.f = p.Func(desc.Type, low)
 	}
+	return desc
+}
+
+// HasLo returns true when the eval has a qualified Lo (ie, the Lo rank is
+// neither 0 nor [Invalid]).
+func (ev *Eval) HasLo() bool {
+	return ev != nil && qualified(ev.LoRank)
+}
+
+// qualified returns true when the rank is neither 0 nor [Invalid].
+func qualified(rank EvalRank) bool {
+	return rank != 0 && rank != Invalid
 }
 
 // Format satisfies the [fmt.Formatter] interface.
@@ -817,6 +1825,8 @@ func (ev *Eval) Format(f fmt.State, verb rune) {
 		fmt.Fprintf(f, "\"%s %s\"", ev.Desc(false), ev.HiBest)
 	case 'S':
 		fmt.Fprintf(f, "%S", ev.Desc(false))
+	case 'u', 'U':
+		ev.Desc(false).Format(f, verb)
 	case 'b':
 		fmt.Fprintf(f, "%s %b", ev.Desc(false), ev.HiBest)
 	case 'h':
@@ -839,6 +1849,52 @@ func (ev *Eval) Hi5(f RankFunc, v []Card) {
 	ev.HiRank, ev.HiBest = f(v[0], v[1], v[2], v[3], v[4]), v
 }
 
+// HiPartial evaluates the 3 or 4 cards in v by their partial rank (see
+// [RankPartial]), as a Cactus rank no better than any 5 cards completed from
+// v (ie, the rank held so far, such as on [Stud]'s 3rd and 4th streets). The
+// Cactus ranks are ordered the same as the partial ranks, with partial ranks
+// sharing a Cactus rank when a better partial rank has a worse completion
+// (ie, 7-3-2 completes to 7-5-4-3-2, which is worse than 6-5-4-3 completed to
+// 8-6-5-4-3). All cards in v are best.
+func (ev *Eval) HiPartial(v []Card) {
+	rank := Invalid
+	if r := RankPartial(v); r != Invalid {
+		rank = partialHi[r]
+	}
+	ev.HiRank, ev.HiBest, ev.HiUnused = rank, v, nil
+}
+
+// razzPartial returns the worst [Razz] rank of the 3 or 4 cards in v
+// completed to 5 cards by cards with ranks not in v (see [Eval.HiPartial]).
+func razzPartial(v []Card) EvalRank {
+	var mask uint16
+	for _, c := range v {
+		mask |= 1 << c.Rank()
+	}
+	var u []Card
+	for _, c := range DeckFrench.Unshuffled() {
+		if mask&(1<<c.Rank()) == 0 {
+			u = append(u, c)
+		}
+	}
+	var rank EvalRank
+	switch len(v) {
+	case 3:
+		for i := range u {
+			for j := i + 1; j < len(u); j++ {
+				if u[i].Rank() != u[j].Rank() {
+					rank = max(rank, RankRazz(v[0], v[1], v[2], u[i], u[j]))
+				}
+			}
+		}
+	case 4:
+		for _, c := range u {
+			rank = max(rank, RankRazz(v[0], v[1], v[2], v[3], c))
+		}
+	}
+	return rank
+}
+
 // HiLo5 evaluates the 5 cards in v, using hi, lo.
 func (ev *Eval) HiLo5(hi, lo RankFunc, v []Card, maximum EvalRank) {
 	ev.HiRank, ev.HiBest = hi(v[0], v[1], v[2], v[3], v[4]), v
@@ -1026,7 +2082,184 @@ func (ev *Eval) HiLo7(hi, lo RankFunc, v []Card, maximum EvalRank) {
 	}
 }
 
+// Hi8 evaluates the 8 cards in v, using f.
+func (ev *Eval) Hi8(f RankFunc, v []Card) {
+	ev.HiRank, ev.HiBest, ev.HiUnused = Invalid, make([]Card, 5), make([]Card, 3)
+	for i, r := 0, EvalRank(0); i < 56; i++ {
+		if r = f(
+			v[t8c5[i][0]],
+			v[t8c5[i][1]],
+			v[t8c5[i][2]],
+			v[t8c5[i][3]],
+			v[t8c5[i][4]],
+		); r < ev.HiRank {
+			ev.HiRank = r
+			ev.HiBest[0], ev.HiBest[1] = v[t8c5[i][0]], v[t8c5[i][1]]
+			ev.HiBest[2], ev.HiBest[3] = v[t8c5[i][2]], v[t8c5[i][3]]
+			ev.HiBest[4] = v[t8c5[i][4]]
+			ev.HiUnused[0], ev.HiUnused[1] = v[t8c5[i][5]], v[t8c5[i][6]]
+			ev.HiUnused[2] = v[t8c5[i][7]]
+		}
+	}
+}
+
+// Max8 evaluates the 8 cards in v, using f, storing only when below max.
+func (ev *Eval) Max8(f RankFunc, v []Card, maximum EvalRank, low bool) {
+	rank, best, unused := Invalid, make([]Card, 5), make([]Card, 3)
+	for i, r := 0, EvalRank(0); i < 56; i++ {
+		if r = f(
+			v[t8c5[i][0]],
+			v[t8c5[i][1]],
+			v[t8c5[i][2]],
+			v[t8c5[i][3]],
+			v[t8c5[i][4]],
+		); r < rank && r < maximum {
+			rank = r
+			best[0], best[1] = v[t8c5[i][0]], v[t8c5[i][1]]
+			best[2], best[3] = v[t8c5[i][2]], v[t8c5[i][3]]
+			best[4] = v[t8c5[i][4]]
+			unused[0], unused[1] = v[t8c5[i][5]], v[t8c5[i][6]]
+			unused[2] = v[t8c5[i][7]]
+		}
+	}
+	if rank < maximum {
+		if !low {
+			ev.HiRank, ev.HiBest, ev.HiUnused = rank, best, unused
+		} else {
+			ev.LoRank, ev.LoBest, ev.LoUnused = rank, best, unused
+		}
+	}
+}
+
+// HiLo8 evaluates the 8 cards in v, using hi, lo.
+func (ev *Eval) HiLo8(hi, lo RankFunc, v []Card, maximum EvalRank) {
+	ev.HiRank, ev.HiBest, ev.HiUnused = Invalid, make([]Card, 5), make([]Card, 3)
+	rank, best, unused := Invalid, make([]Card, 5), make([]Card, 3)
+	for i, r := 0, EvalRank(0); i < 56; i++ {
+		if r = hi(
+			v[t8c5[i][0]],
+			v[t8c5[i][1]],
+			v[t8c5[i][2]],
+			v[t8c5[i][3]],
+			v[t8c5[i][4]],
+		); r < ev.HiRank {
+			ev.HiRank = r
+			ev.HiBest[0], ev.HiBest[1] = v[t8c5[i][0]], v[t8c5[i][1]]
+			ev.HiBest[2], ev.HiBest[3] = v[t8c5[i][2]], v[t8c5[i][3]]
+			ev.HiBest[4] = v[t8c5[i][4]]
+			ev.HiUnused[0], ev.HiUnused[1] = v[t8c5[i][5]], v[t8c5[i][6]]
+			ev.HiUnused[2] = v[t8c5[i][7]]
+		}
+		if r = lo(
+			v[t8c5[i][0]],
+			v[t8c5[i][1]],
+			v[t8c5[i][2]],
+			v[t8c5[i][3]],
+			v[t8c5[i][4]],
+		); r < rank && r < maximum {
+			rank = r
+			best[0], best[1] = v[t8c5[i][0]], v[t8c5[i][1]]
+			best[2], best[3] = v[t8c5[i][2]], v[t8c5[i][3]]
+			best[4] = v[t8c5[i][4]]
+			unused[0], unused[1] = v[t8c5[i][5]], v[t8c5[i][6]]
+			unused[2] = v[t8c5[i][7]]
+		}
+	}
+	if rank < maximum {
+		ev.LoRank, ev.LoBest, ev.LoUnused = rank, best, unused
+	}
+}
+
+// Hi9 evaluates the 9 cards in v, using f.
+func (ev *Eval) Hi9(f RankFunc, v []Card) {
+	ev.HiRank, ev.HiBest, ev.HiUnused = Invalid, make([]Card, 5), make([]Card, 4)
+	for i, r := 0, EvalRank(0); i < 126; i++ {
+		if r = f(
+			v[t9c5[i][0]],
+			v[t9c5[i][1]],
+			v[t9c5[i][2]],
+			v[t9c5[i][3]],
+			v[t9c5[i][4]],
+		); r < ev.HiRank {
+			ev.HiRank = r
+			ev.HiBest[0], ev.HiBest[1] = v[t9c5[i][0]], v[t9c5[i][1]]
+			ev.HiBest[2], ev.HiBest[3] = v[t9c5[i][2]], v[t9c5[i][3]]
+			ev.HiBest[4] = v[t9c5[i][4]]
+			ev.HiUnused[0], ev.HiUnused[1] = v[t9c5[i][5]], v[t9c5[i][6]]
+			ev.HiUnused[2], ev.HiUnused[3] = v[t9c5[i][7]], v[t9c5[i][8]]
+		}
+	}
+}
+
+// Max9 evaluates the 9 cards in v, using f, storing only when below max.
+func (ev *Eval) Max9(f RankFunc, v []Card, maximum EvalRank, low bool) {
+	rank, best, unused := Invalid, make([]Card, 5), make([]Card, 4)
+	for i, r := 0, EvalRank(0); i < 126; i++ {
+		if r = f(
+			v[t9c5[i][0]],
+			v[t9c5[i][1]],
+			v[t9c5[i][2]],
+			v[t9c5[i][3]],
+			v[t9c5[i][4]],
+		); r < rank && r < maximum {
+			rank = r
+			best[0], best[1] = v[t9c5[i][0]], v[t9c5[i][1]]
+			best[2], best[3] = v[t9c5[i][2]], v[t9c5[i][3]]
+			best[4] = v[t9c5[i][4]]
+			unused[0], unused[1] = v[t9c5[i][5]], v[t9c5[i][6]]
+			unused[2], unused[3] = v[t9c5[i][7]], v[t9c5[i][8]]
+		}
+	}
+	if rank < maximum {
+		if !low {
+			ev.HiRank, ev.HiBest, ev.HiUnused = rank, best, unused
+		} else {
+			ev.LoRank, ev.LoBest, ev.LoUnused = rank, best, unused
+		}
+	}
+}
+
+// HiLo9 evaluates the 9 cards in v, using hi, lo.
+func (ev *Eval) HiLo9(hi, lo RankFunc, v []Card, maximum EvalRank) {
+	ev.HiRank, ev.HiBest, ev.HiUnused = Invalid, make([]Card, 5), make([]Card, 4)
+	rank, best, unused := Invalid, make([]Card, 5), make([]Card, 4)
+	for i, r := 0, EvalRank(0); i < 126; i++ {
+		if r = hi(
+			v[t9c5[i][0]],
+			v[t9c5[i][1]],
+			v[t9c5[i][2]],
+			v[t9c5[i][3]],
+			v[t9c5[i][4]],
+		); r < ev.HiRank {
+			ev.HiRank = r
+			ev.HiBest[0], ev.HiBest[1] = v[t9c5[i][0]], v[t9c5[i][1]]
+			ev.HiBest[2], ev.HiBest[3] = v[t9c5[i][2]], v[t9c5[i][3]]
+			ev.HiBest[4] = v[t9c5[i][4]]
+			ev.HiUnused[0], ev.HiUnused[1] = v[t9c5[i][5]], v[t9c5[i][6]]
+			ev.HiUnused[2], ev.HiUnused[3] = v[t9c5[i][7]], v[t9c5[i][8]]
+		}
+		if r = lo(
+			v[t9c5[i][0]],
+			v[t9c5[i][1]],
+			v[t9c5[i][2]],
+			v[t9c5[i][3]],
+			v[t9c5[i][4]],
+		); r < rank && r < maximum {
+			rank = r
+			best[0], best[1] = v[t9c5[i][0]], v[t9c5[i][1]]
+			best[2], best[3] = v[t9c5[i][2]], v[t9c5[i][3]]
+			best[4] = v[t9c5[i][4]]
+			unused[0], unused[1] = v[t9c5[i][5]], v[t9c5[i][6]]
+			unused[2], unused[3] = v[t9c5[i][7]], v[t9c5[i][8]]
+		}
+	}
+	if rank < maximum {
+		ev.LoRank, ev.LoBest, ev.LoUnused = rank, best, unused
+	}
+}
+
 // HiLo23 evaluates the 2 cards c0, c1 and the 3 in b, using hi, lo.
+// The eval's HiRank and LoRank must be [Invalid] (see [EvalOf]).
 func (ev *Eval) HiLo23(hi, lo RankFunc, c0, c1 Card, b []Card, maximum EvalRank) {
 	ev.HiRank, ev.HiBest = hi(c0, c1, b[0], b[1], b[2]), []Card{c0, c1, b[0], b[1], b[2]}
 	if lo != nil {
@@ -1037,6 +2270,7 @@ func (ev *Eval) HiLo23(hi, lo RankFunc, c0, c1 Card, b []Card, maximum EvalRank)
 }
 
 // HiLo24 evaluates the 2 cards c0, c1 and the 4 in b, using hi, lo.
+// The eval's HiRank and LoRank must be [Invalid] (see [EvalOf]).
 func (ev *Eval) HiLo24(hi, lo RankFunc, c0, c1 Card, b []Card, maximum EvalRank) {
 	ev.HiBest, ev.HiUnused = []Card{c0, c1, 0, 0, 0}, make([]Card, 1)
 	if lo != nil {
@@ -1061,6 +2295,7 @@ func (ev *Eval) HiLo24(hi, lo RankFunc, c0, c1 Card, b []Card, maximum EvalRank)
 }
 
 // HiLo25 evaluates the 2 cards c0, c1 and the 5 in b, using hi, lo.
+// The eval's HiRank and LoRank must be [Invalid] (see [EvalOf]).
 func (ev *Eval) HiLo25(hi, lo RankFunc, c0, c1 Card, b []Card, maximum EvalRank) {
 	ev.HiBest, ev.HiUnused = []Card{c0, c1, 0, 0, 0}, make([]Card, 2)
 	if lo != nil {
@@ -1084,17 +2319,218 @@ func (ev *Eval) HiLo25(hi, lo RankFunc, c0, c1 Card, b []Card, maximum EvalRank)
 	}
 }
 
+// HiLo2 evaluates the 2 cards c0, c1 and any 3 of the 3 or more in b, using
+// hi, and lo when not nil. Uses [Eval.HiLo23], [Eval.HiLo24], or
+// [Eval.HiLo25] for boards of 3, 4, or 5 cards, otherwise only sets the
+// eval's Hi or Lo when better than the eval's HiRank or LoRank.
+func (ev *Eval) HiLo2(hi, lo RankFunc, c0, c1 Card, b []Card, maximum EvalRank) {
+	switch len(b) {
+	case 0, 1, 2:
+		return
+	case 3:
+		ev.HiLo23(hi, lo, c0, c1, b, maximum)
+		return
+	case 4:
+		ev.HiLo24(hi, lo, c0, c1, b, maximum)
+		return
+	case 5:
+		ev.HiLo25(hi, lo, c0, c1, b, maximum)
+		return
+	}
+	idx := make([]int, len(b))
+	for i := range idx {
+		idx[i] = i
+	}
+	var hiTake, loTake [3]int
+	hiRank, loRank, r := Invalid, Invalid, EvalRank(0)
+	for g, v := NewCombinGen(idx, 3); g.Next(); {
+		if r = hi(c0, c1, b[v[0]], b[v[1]], b[v[2]]); r < hiRank {
+			hiRank, hiTake = r, [3]int(v)
+		}
+		if lo != nil {
+			if r = lo(c0, c1, b[v[0]], b[v[1]], b[v[2]]); r < loRank && r < maximum {
+				loRank, loTake = r, [3]int(v)
+			}
+		}
+	}
+	if hiRank < ev.HiRank {
+		ev.HiRank = hiRank
+		ev.HiBest, ev.HiUnused = forcedTake(c0, c1, b, hiTake)
+	}
+	if loRank < ev.LoRank {
+		ev.LoRank = loRank
+		ev.LoBest, ev.LoUnused = forcedTake(c0, c1, b, loTake)
+	}
+}
+
+// forcedTake returns the best cards c0, c1 and the board cards at the
+// indexes in take, and the unused board cards.
+func forcedTake(c0, c1 Card, b []Card, take [3]int) ([]Card, []Card) {
+	best := []Card{c0, c1, b[take[0]], b[take[1]], b[take[2]]}
+	unused := make([]Card, 0, len(b)-3)
+	for i, c := range b {
+		if i != take[0] && i != take[1] && i != take[2] {
+			unused = append(unused, c)
+		}
+	}
+	return best, unused
+}
+
+// BoardEval is a board prepared for evaluating multiple pockets, where the
+// board's work is done once and shared by all pockets. Useful for showdowns
+// with many pockets on the same board.
+//
+// Only the evals of 2 card pockets on a 5 card board for Hi-only Cactus
+// types (ie, [Holdem]) share the board's work, by precomputing the board's
+// TwoPlusTwo lookup state when available. Other pockets, boards and types
+// are evaluated the same as [Type.Eval].
+type BoardEval struct {
+	// Type is the type.
+	Type Type
+	// Board is the board.
+	Board     []Card
+	f         EvalFunc
+	hi        func([]Card) EvalRank
+	normalize bool
+}
+
+// NewBoardEval creates a board eval for the type and board.
+func NewBoardEval(typ Type, board []Card) *BoardEval {
+	return newBoardEval(typ, board, true)
+}
+
+// newBoardEval creates a board eval for the type and board, using the type's
+// normalized eval func when normalize is true, and the type's calc func
+// otherwise.
+func newBoardEval(typ Type, board []Card, normalize bool) *BoardEval {
+	b := new(BoardEval)
+	b.init(typ, board, normalize)
+	return b
+}
+
+// init initializes the board eval.
+func (b *BoardEval) init(typ Type, board []Card, normalize bool) {
+	*b = BoardEval{
+		Type:      typ,
+		Board:     board,
+		f:         calcs[typ],
+		normalize: normalize,
+	}
+	if normalize {
+		b.f = evals[typ]
+	}
+	// precompute the TwoPlusTwo lookup state for Hi-only Cactus types
+	if desc, ok := descs[typ]; ok && desc.Eval == EvalCactus && !desc.Low && hybridTwoPlusTwo() && len(board) == 5 {
+		b.hi = twoPlusTwoBoard(board)
+	}
+}
+
+// Eval creates a new eval for the board's type, evaluating the pocket and
+// board.
+func (b *BoardEval) Eval(pocket []Card) *Eval {
+	ev := EvalOf(b.Type)
+	b.eval(ev, pocket)
+	return ev
+}
+
+// eval evaluates the pocket and board, storing the results on ev.
+func (b *BoardEval) eval(ev *Eval, pocket []Card) {
+	if b.hi == nil || len(pocket) != 2 {
+		b.f(ev, pocket, b.Board)
+		return
+	}
+	ev.HiRank = b.hi(pocket)
+	if b.normalize {
+		v := make([]Card, 7)
+		copy(v, pocket)
+		copy(v[2:], b.Board)
+		ev.HiBest, ev.HiUnused = bestCactusSplit(ev.HiRank, v, 0)
+	}
+}
+
+// EvalPockets creates new evals for the board's type, evaluating each of the
+// pockets and board.
+func (b *BoardEval) EvalPockets(pockets [][]Card) []*Eval {
+	evs := make([]*Eval, len(pockets))
+	for i, pocket := range pockets {
+		evs[i] = b.Eval(pocket)
+	}
+	return evs
+}
+
+// EvalPocketsContext creates new evals for the board's type, evaluating each
+// of the pockets and board, checking the context prior to each pocket.
+// Returns the evals completed and [ErrContextCancelled] when the context is
+// done.
+func (b *BoardEval) EvalPocketsContext(ctx context.Context, pockets [][]Card) ([]*Eval, error) {
+	evs := make([]*Eval, 0, len(pockets))
+	for _, pocket := range pockets {
+		select {
+		case <-ctx.Done():
+			return evs, cancelled(ctx)
+		default:
+		}
+		evs = append(evs, b.Eval(pocket))
+	}
+	return evs, nil
+}
+
+// EvalPocketsParallel creates new evals for the board's type, evaluating each
+// of the pockets and board across the specified number of workers (or
+// [runtime.NumCPU] when workers is 0 or less). The returned evals are in the
+// same order as the pockets. Returns [ErrContextCancelled] when the context is
+// done, with the evals of pockets not evaluated being nil.
+func (b *BoardEval) EvalPocketsParallel(ctx context.Context, pockets [][]Card, workers int) ([]*Eval, error) {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	evs := make([]*Eval, len(pockets))
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for range min(workers, len(pockets)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := int(next.Add(1) - 1); i < len(pockets); i = int(next.Add(1) - 1) {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+				}
+				evs[i] = b.Eval(pockets[i])
+			}
+		}()
+	}
+	wg.Wait()
+	select {
+	case <-ctx.Done():
+		return evs, cancelled(ctx)
+	default:
+	}
+	return evs, nil
+}
+
 // EvalDesc describes a Hi/Lo eval.
 type EvalDesc struct {
 	Type   DescType
 	Rank   EvalRank
 	Best   []Card
 	Unused []Card
+	// Qualified is true when the rank is neither 0 nor [Invalid], such as
+	// when a Lo does not qualify.
+	Qualified bool
+	// f is the description profile's description func.
+	f DescFunc
 }
 
 // Format satisfies the [fmt.Stringer] interface.
 func (desc *EvalDesc) Format(f fmt.State, verb rune) {
-	desc.Type.Desc(f, verb, desc.Rank, desc.Best, desc.Unused)
+	switch {
+	case verb == 'd', verb == 'u', verb == 'U', desc.f == nil:
+		desc.Type.Desc(f, verb, desc.Rank, desc.Best, desc.Unused)
+	default:
+		desc.f(f, verb, desc.Rank, desc.Best, desc.Unused)
+	}
 }
 
 // Order builds an ordered slice of indices for the provided evals, ordered by
@@ -1105,35 +2541,108 @@ func (desc *EvalDesc) Format(f fmt.State, verb rune) {
 // Lo's, if there are no valid (ie, qualified) evals, the returned pivot will
 // be 0.
 func Order(evs []*Eval, low bool) ([]int, int) {
+	return order(evs, low, nil)
+}
+
+// OrderOf builds an ordered slice of indices for any [Ranker], such as the
+// evals of an alternate eval backend. See [Order].
+func OrderOf[R Ranker](v []R, low bool) ([]int, int) {
+	return order(v, low, nil)
+}
+
+// OrderGroups orders the provided evals by either Hi or Lo (per [Eval.Comp]),
+// returning the indices grouped into tiers of tied evals, from best to worst.
+// Nil evals, and evals with a [Invalid] rank (ie, unqualified Lo's), are not
+// included.
+//
+// The first group is the same as the winning indices returned by [Order].
+func OrderGroups(evs []*Eval, low bool) [][]int {
+	return orderGroups(evs, low)
+}
+
+// OrderGroupsOf orders any [Ranker] into tiers of tied rankers. See
+// [OrderGroups].
+func OrderGroupsOf[R Ranker](v []R, low bool) [][]int {
+	return orderGroups(v, low)
+}
+
+// orderGroups orders evs into tiers of tied evals.
+func orderGroups[R Ranker](evs []R, low bool) [][]int {
+	v, _ := order(evs, low, nil)
+	var groups [][]int
+	for j, i := range v {
+		r, ok := rankOf(evs[i], low)
+		switch {
+		case !ok, r == 0, r == Invalid:
+			return groups
+		case j == 0, compRank(evs[v[j-1]], evs[i], low) != 0:
+			groups = append(groups, []int{i})
+		default:
+			groups[len(groups)-1] = append(groups[len(groups)-1], i)
+		}
+	}
+	return groups
+}
+
+// order orders evs, reusing v for the returned indices when it has enough
+// capacity.
+func order[R Ranker](evs []R, low bool, v []int) ([]int, int) {
 	if len(evs) == 0 {
 		return nil, 0
 	}
 	n := len(evs)
-	i, m, v := 0, make(map[int]*Eval, n), make([]int, n)
+	if cap(v) < n {
+		v = make([]int, n)
+	}
+	v = v[:n]
 	// set up
+	i := 0
 	for ; i < n; i++ {
-		m[i], v[i] = evs[i], i
+		v[i] = i
 	}
-	// sort v based on mapped evals
-	sort.SliceStable(v, func(j, k int) bool {
-		return m[v[j]].Comp(m[v[k]], low) < 0
+	// sort v based on evals
+	slices.SortStableFunc(v, func(j, k int) int {
+		return compRank(evs[j], evs[k], low)
 	})
-	if !low {
-		// determine hi pivot
-		for i = 1; i < n && m[v[i-1]] != nil && m[v[i]] != nil && m[v[i-1]].HiRank == m[v[i]].HiRank; i++ {
-		}
-	} else {
-		// determine if any qualified low evals
-		if m[v[0]] == nil || m[v[0]].LoRank == 0 || m[v[0]].LoRank == Invalid {
-			return nil, 0
-		}
-		// determine lo pivot
-		for i = 1; i < n && m[v[i-1]] != nil && m[v[i]] != nil && m[v[i-1]].LoRank == m[v[i]].LoRank; i++ {
+	// determine if any qualified low evals
+	if r, ok := rankOf(evs[v[0]], low); low && (!ok || !qualified(r)) {
+		return nil, 0
+	}
+	// determine pivot
+	for i = 1; i < n; i++ {
+		a, aok := rankOf(evs[v[i-1]], low)
+		b, bok := rankOf(evs[v[i]], low)
+		if !aok || !bok || a != b {
+			break
 		}
 	}
 	return v, i
 }
 
+// compRank compares a's Hi/Lo rank to b's Hi/Lo rank (per [Eval.Comp]).
+func compRank(a, b Ranker, low bool) int {
+	ar, aok := rankOf(a, low)
+	br, bok := rankOf(b, low)
+	switch {
+	case !aok && !bok:
+		return -1
+	case !aok:
+		return +1
+	case !bok:
+		return -1
+	}
+	return cmp.Compare(ar, br)
+}
+
+// rankOf returns the ranker's Hi/Lo rank, and false when r is nil or was not
+// evaluated.
+func rankOf(r Ranker, low bool) (EvalRank, bool) {
+	if r == nil {
+		return Invalid, false
+	}
+	return r.Rank(low)
+}
+
 // bestCactus orders the best and unused cards in v and u, with the specified
 // straight base, and inv func to inverse the passed eval rank.
 func bestCactus(rank EvalRank, v, u []Card, base Rank, inv func(EvalRank) EvalRank) {
@@ -1174,33 +2683,122 @@ func bestCactusSplit(rank EvalRank, v []Card, base Rank) ([]Card, []Card) {
 	return v[:5], v[5:]
 }
 
+// partial returns a copy of the pocket and board cards when there are only 3
+// or 4 cards (see [Eval.HiPartial]), otherwise nil.
+func partial(p, b []Card) []Card {
+	n := len(p) + len(b)
+	if n != 3 && n != 4 {
+		return nil
+	}
+	v := make([]Card, n)
+	copy(v, p)
+	copy(v[len(p):], b)
+	return v
+}
+
+// complete returns true when v is a complete best-5, and not a starting
+// pocket padded by a partial eval.
+func complete(v []Card) bool {
+	return len(v) == 5 && !slices.Contains(v, 0)
+}
+
+// pocketMask returns a mask of the cards in v that are in the pocket.
+func pocketMask(v, pocket []Card) uint8 {
+	var mask uint8
+	for i, c := range v {
+		if slices.Contains(pocket, c) {
+			mask |= 1 << i
+		}
+	}
+	return mask
+}
+
+// masked returns the cards in v for the set bits of the mask.
+func masked(v []Card, mask uint8) []Card {
+	var u []Card
+	for i, c := range v {
+		if mask&(1<<i) != 0 {
+			u = append(u, c)
+		}
+	}
+	return u
+}
+
 // bestAceHigh orders v by rank, high to low, Aces are high.
 func bestAceHigh(v []Card) {
-	sort.Slice(v, func(i, j int) bool {
-		m, n := v[i].Rank(), v[j].Rank()
-		if m == n {
-			return v[j].Suit() < v[i].Suit()
-		}
-		return n < m
-	})
+	aceHighSorter.Sort(v)
 }
 
 // bestAceLow orders v by rank, high to low, Aces are low.
 func bestAceLow(v []Card) {
+	aceLowSorter.Sort(v)
+}
+
+// card sorters used for ordering best and unused cards.
+var (
+	aceHighSorter = CardSorter{}
+	aceLowSorter  = CardSorter{AceLow: true, SuitAscending: true}
+)
+
+// bestAceSix orders v by matching sets, and then by rank, high to low, Aces are
+// low.
+func bestAceSix(v []Card) {
+	var counts [13]int
+	for _, c := range v {
+		counts[c.AceRank()]++
+	}
 	sort.Slice(v, func(i, j int) bool {
-		if a, b := v[i].AceRank(), v[j].AceRank(); a != b {
+		a, b := v[i].AceRank(), v[j].AceRank()
+		switch n, m := counts[a], counts[b]; {
+		case n != m:
+			return m < n
+		case a != b:
 			return b < a
 		}
 		return v[i].Suit() < v[j].Suit()
 	})
 }
 
-// bestSoko sets the best Soko in v.
-func bestSoko(rank EvalRank, v, u []Card) {
+// bestRazz orders v by the [Razz] rank.
+func bestRazz(rank EvalRank, v []Card) {
+	if rank < aceFiveMax {
+		bestAceLow(v)
+		return
+	}
+	switch (Invalid - rank).Fixed() {
+	case FourOfAKind, FullHouse, ThreeOfAKind, TwoPair, Pair:
+		bestSet(v)
+	}
+}
+
+// jokerLow returns a copy of v with any [Joker] replaced by a card of the
+// lowest [Ace]-low rank not in v. Returns v when v does not contain a [Joker].
+func jokerLow(v []Card) []Card {
+	i := slices.Index(v, Joker)
+	if i == -1 {
+		return v
+	}
+	var mask int
+	for _, c := range v {
+		if c != Joker {
+			mask |= 1 << c.AceRank()
+		}
+	}
+	var n int
+	for ; mask&(1<<n) != 0; n++ {
+	}
+	w := slices.Clone(v)
+	w[i] = New(Rank((n+12)%13), Spade)
+	return w
+}
+
+// bestSoko sets the best Soko in v, where over is the rank directly over a
+// Four Flush.
+func bestSoko(rank, over EvalRank, v, u []Card) {
 	switch {
-	case rank <= TwoPair:
+	case rank <= over:
 		bestCactus(rank, v, u, 0, nil)
-	case rank <= sokoFlush:
+	case rank <= over+sokoFourFlushes:
 		suit := v[0].Suit()
 		for i := range 4 {
 			if v[i].Suit() != suit {
@@ -1216,7 +2814,7 @@ func bestSoko(rank EvalRank, v, u []Card) {
 			return v[i].Rank() > v[j].Rank()
 		})
 		bestAceHigh(u)
-	case rank <= sokoStraight:
+	case rank <= over+sokoFourFlushes+sokoFourStraights:
 		bestAceHigh(v)
 		if v[0].Rank()-v[1].Rank() != 1 {
 			c := v[0]
@@ -1225,7 +2823,73 @@ func bestSoko(rank EvalRank, v, u []Card) {
 		}
 		bestAceHigh(u)
 	default:
-		bestCactus(rank-sokoStraight+TwoPair, v, u, 0, nil)
+		bestCactus(fromSoko(rank, over), v, u, 0, nil)
+	}
+}
+
+// toSoko converts a Cactus rank to a [Soko] rank, where over is the rank
+// directly over a Four Flush.
+func toSoko(r, over EvalRank) EvalRank {
+	if over < r && r <= Nothing {
+		return r + sokoFourFlushes + sokoFourStraights + sokoSkip(over)
+	}
+	return r
+}
+
+// fromSoko converts a [Soko] rank to a Cactus rank, where over is the rank
+// directly over a Four Flush.
+func fromSoko(r, over EvalRank) EvalRank {
+	switch n := over + sokoFourFlushes + sokoFourStraights + sokoSkip(over); {
+	case r <= over:
+		return r
+	case r <= n:
+		return Invalid
+	case r <= n+Nothing-over:
+		return r - sokoFourFlushes - sokoFourStraights - sokoSkip(over)
+	}
+	return r
+}
+
+// sokoSkip returns the number of unused ranks between a [Soko] Four Straight
+// and the Cactus ranks under it, where over is the rank directly over a Four
+// Flush.
+func sokoSkip(over EvalRank) EvalRank {
+	if over == TwoPair {
+		return 1
+	}
+	return 0
+}
+
+// bestCalifornia orders v using [bestRazz], ordering any [Joker] by its
+// played card.
+func bestCalifornia(rank EvalRank, v []Card) {
+	i := slices.Index(v, Joker)
+	if i == -1 {
+		bestRazz(rank, v)
+		return
+	}
+	// order using the joker's played card, then restore the joker
+	u := jokerLow(v)
+	c := u[i]
+	bestRazz(rank, u)
+	u[slices.Index(u, c)] = Joker
+	copy(v, u)
+}
+
+// bestThree orders the best 2 or 3 cards in v, with a pair first, and the
+// wheel straight (3-2-A) ordered with the [Ace] last.
+func bestThree(rank EvalRank, v []Card) {
+	bestAceHigh(v)
+	switch {
+	case len(v) == 3 && rank <= ThreeCardStraightFlush,
+		len(v) == 3 && ThreeCardThreeOfAKind < rank && rank <= ThreeCardStraight:
+		if v[0].Rank() == Ace && v[1].Rank() == Three {
+			v[0], v[1], v[2] = v[1], v[2], v[0]
+		}
+	case len(v) == 3 && ThreeCardFlush < rank && rank <= ThreeCardPair:
+		if v[1].Rank() == v[2].Rank() {
+			v[0], v[1], v[2] = v[1], v[2], v[0]
+		}
 	}
 }
 
@@ -1321,7 +2985,10 @@ func bestSet(v []Card) {
 		i += len(m[rank])
 	}
 	i = 5
-	j, k := len(m[ranks[0]]), len(m[ranks[1]])
+	j, k := len(m[ranks[0]]), 0
+	if 1 < len(ranks) {
+		k = len(m[ranks[1]])
+	}
 	switch {
 	case j == 4:
 		i = 4
@@ -1331,7 +2998,7 @@ func bestSet(v []Card) {
 	case j == 2:
 		i = 2
 	}
-	bestAceHigh(v[i:])
+	bestAceHigh(v[min(i, len(v)):])
 }
 
 // orderSuits orders v's card suits by count.
@@ -1381,89 +3048,110 @@ func suitNormalize(v, u []Card) {
 	}
 }
 
+// cardBuf is a reusable buffer for card combinations.
+type cardBuf struct {
+	v [][]Card
+	c []Card
+}
+
+// cardBufPool is a pool of card buffers, reducing allocations when taking
+// combinations during evals.
+var cardBufPool = sync.Pool{
+	New: func() any {
+		return new(cardBuf)
+	},
+}
+
+// rows returns n rows of k cards, reusing the buffer's memory. When buf is
+// nil, new memory is allocated.
+func (buf *cardBuf) rows(n, k int) [][]Card {
+	if buf == nil {
+		buf = new(cardBuf)
+	}
+	if cap(buf.c) < n*k {
+		buf.c = make([]Card, n*k)
+	}
+	if cap(buf.v) < n {
+		buf.v = make([][]Card, n)
+	}
+	buf.v = buf.v[:n]
+	for i := range n {
+		buf.v[i] = buf.c[i*k : (i+1)*k : (i+1)*k]
+	}
+	return buf.v
+}
+
 // take2c2 generates the combinations of v.
-func take2c2(v []Card) ([][]Card, int) {
-	return [][]Card{v}, 1
+func take2c2(buf *cardBuf, v []Card) ([][]Card, int) {
+	u := buf.rows(1, len(v))
+	copy(u[0], v)
+	return u, 1
 }
 
 // take3c2 generates the combinations of v.
-func take3c2(v []Card) ([][]Card, int) {
-	u := make([][]Card, 3)
+func take3c2(buf *cardBuf, v []Card) ([][]Card, int) {
+	u := buf.rows(3, 3)
 	for i := range 3 {
-		u[i] = []Card{v[i], v[(i+1)%3], v[(i+2)%3]}
+		u[i][0], u[i][1], u[i][2] = v[i], v[(i+1)%3], v[(i+2)%3]
 	}
 	return u, 3
 }
 
 // take4c2 generates the combinations of v.
-func take4c2(v []Card) ([][]Card, int) {
-	u := make([][]Card, 6)
+func take4c2(buf *cardBuf, v []Card) ([][]Card, int) {
+	u := buf.rows(6, 4)
 	for i := range 6 {
-		u[i] = []Card{
-			v[t4c2[i][0]],
-			v[t4c2[i][1]],
-			v[t4c2[i][2]],
-			v[t4c2[i][3]],
+		for j := range 4 {
+			u[i][j] = v[t4c2[i][j]]
 		}
 	}
 	return u, 6
 }
 
 // take5c2 generates the combinations of v.
-func take5c2(v []Card) ([][]Card, int) {
-	u := make([][]Card, 10)
+func take5c2(buf *cardBuf, v []Card) ([][]Card, int) {
+	u := buf.rows(10, 5)
 	for i := range 10 {
-		u[i] = []Card{
-			v[t5c2[i][0]],
-			v[t5c2[i][1]],
-			v[t5c2[i][2]],
-			v[t5c2[i][3]],
-			v[t5c2[i][4]],
+		for j := range 5 {
+			u[i][j] = v[t5c2[i][j]]
 		}
 	}
 	return u, 10
 }
 
 // take6c2 generates the combinations of v.
-func take6c2(v []Card) ([][]Card, int) {
-	u := make([][]Card, 15)
+func take6c2(buf *cardBuf, v []Card) ([][]Card, int) {
+	u := buf.rows(15, 6)
 	for i := range 15 {
-		u[i] = []Card{
-			v[t6c2[i][0]],
-			v[t6c2[i][1]],
-			v[t6c2[i][2]],
-			v[t6c2[i][3]],
-			v[t6c2[i][4]],
-			v[t6c2[i][5]],
+		for j := range 6 {
+			u[i][j] = v[t6c2[i][j]]
 		}
 	}
 	return u, 15
 }
 
 // take3c3 generates the combinations of v.
-func take3c3(v []Card) ([][]Card, int) {
-	return [][]Card{v}, 1
+func take3c3(buf *cardBuf, v []Card) ([][]Card, int) {
+	u := buf.rows(1, len(v))
+	copy(u[0], v)
+	return u, 1
 }
 
 // take4c3 generates the combinations of v.
-func take4c3(v []Card) ([][]Card, int) {
-	u := make([][]Card, 4)
+func take4c3(buf *cardBuf, v []Card) ([][]Card, int) {
+	u := buf.rows(4, 4)
 	for i := range 4 {
-		u[i] = []Card{v[i], v[(i+1)%4], v[(i+2)%4], v[(i+3)%4]}
+		u[i][0], u[i][1], u[i][2], u[i][3] = v[i], v[(i+1)%4], v[(i+2)%4], v[(i+3)%4]
 	}
 	return u, 4
 }
 
 // take5c3 generates the combinations of v.
-func take5c3(v []Card) ([][]Card, int) {
-	u := make([][]Card, 10)
+func take5c3(buf *cardBuf, v []Card) ([][]Card, int) {
+	u := buf.rows(10, 5)
 	for i := range 10 {
-		u[i] = []Card{
-			v[t5c3[i][0]],
-			v[t5c3[i][1]],
-			v[t5c3[i][2]],
-			v[t5c3[i][3]],
-			v[t5c3[i][4]],
+		for j := range 5 {
+			u[i][j] = v[t5c3[i][j]]
 		}
 	}
 	return u, 10
@@ -1560,3 +3248,193 @@ var t7c5 = [21][7]uint8{
 	{1, 3, 4, 5, 6, 0, 2},
 	{2, 3, 4, 5, 6, 0, 1},
 }
+
+// t8c5 is used for taking 8, choosing 5.
+var t8c5 = [56][8]uint8{
+	{0, 1, 2, 3, 4, 5, 6, 7},
+	{0, 1, 2, 3, 5, 4, 6, 7},
+	{0, 1, 2, 3, 6, 4, 5, 7},
+	{0, 1, 2, 3, 7, 4, 5, 6},
+	{0, 1, 2, 4, 5, 3, 6, 7},
+	{0, 1, 2, 4, 6, 3, 5, 7},
+	{0, 1, 2, 4, 7, 3, 5, 6},
+	{0, 1, 2, 5, 6, 3, 4, 7},
+	{0, 1, 2, 5, 7, 3, 4, 6},
+	{0, 1, 2, 6, 7, 3, 4, 5},
+	{0, 1, 3, 4, 5, 2, 6, 7},
+	{0, 1, 3, 4, 6, 2, 5, 7},
+	{0, 1, 3, 4, 7, 2, 5, 6},
+	{0, 1, 3, 5, 6, 2, 4, 7},
+	{0, 1, 3, 5, 7, 2, 4, 6},
+	{0, 1, 3, 6, 7, 2, 4, 5},
+	{0, 1, 4, 5, 6, 2, 3, 7},
+	{0, 1, 4, 5, 7, 2, 3, 6},
+	{0, 1, 4, 6, 7, 2, 3, 5},
+	{0, 1, 5, 6, 7, 2, 3, 4},
+	{0, 2, 3, 4, 5, 1, 6, 7},
+	{0, 2, 3, 4, 6, 1, 5, 7},
+	{0, 2, 3, 4, 7, 1, 5, 6},
+	{0, 2, 3, 5, 6, 1, 4, 7},
+	{0, 2, 3, 5, 7, 1, 4, 6},
+	{0, 2, 3, 6, 7, 1, 4, 5},
+	{0, 2, 4, 5, 6, 1, 3, 7},
+	{0, 2, 4, 5, 7, 1, 3, 6},
+	{0, 2, 4, 6, 7, 1, 3, 5},
+	{0, 2, 5, 6, 7, 1, 3, 4},
+	{0, 3, 4, 5, 6, 1, 2, 7},
+	{0, 3, 4, 5, 7, 1, 2, 6},
+	{0, 3, 4, 6, 7, 1, 2, 5},
+	{0, 3, 5, 6, 7, 1, 2, 4},
+	{0, 4, 5, 6, 7, 1, 2, 3},
+	{1, 2, 3, 4, 5, 0, 6, 7},
+	{1, 2, 3, 4, 6, 0, 5, 7},
+	{1, 2, 3, 4, 7, 0, 5, 6},
+	{1, 2, 3, 5, 6, 0, 4, 7},
+	{1, 2, 3, 5, 7, 0, 4, 6},
+	{1, 2, 3, 6, 7, 0, 4, 5},
+	{1, 2, 4, 5, 6, 0, 3, 7},
+	{1, 2, 4, 5, 7, 0, 3, 6},
+	{1, 2, 4, 6, 7, 0, 3, 5},
+	{1, 2, 5, 6, 7, 0, 3, 4},
+	{1, 3, 4, 5, 6, 0, 2, 7},
+	{1, 3, 4, 5, 7, 0, 2, 6},
+	{1, 3, 4, 6, 7, 0, 2, 5},
+	{1, 3, 5, 6, 7, 0, 2, 4},
+	{1, 4, 5, 6, 7, 0, 2, 3},
+	{2, 3, 4, 5, 6, 0, 1, 7},
+	{2, 3, 4, 5, 7, 0, 1, 6},
+	{2, 3, 4, 6, 7, 0, 1, 5},
+	{2, 3, 5, 6, 7, 0, 1, 4},
+	{2, 4, 5, 6, 7, 0, 1, 3},
+	{3, 4, 5, 6, 7, 0, 1, 2},
+}
+
+// t9c5 is used for taking 9, choosing 5.
+var t9c5 = [126][9]uint8{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8},
+	{0, 1, 2, 3, 5, 4, 6, 7, 8},
+	{0, 1, 2, 3, 6, 4, 5, 7, 8},
+	{0, 1, 2, 3, 7, 4, 5, 6, 8},
+	{0, 1, 2, 3, 8, 4, 5, 6, 7},
+	{0, 1, 2, 4, 5, 3, 6, 7, 8},
+	{0, 1, 2, 4, 6, 3, 5, 7, 8},
+	{0, 1, 2, 4, 7, 3, 5, 6, 8},
+	{0, 1, 2, 4, 8, 3, 5, 6, 7},
+	{0, 1, 2, 5, 6, 3, 4, 7, 8},
+	{0, 1, 2, 5, 7, 3, 4, 6, 8},
+	{0, 1, 2, 5, 8, 3, 4, 6, 7},
+	{0, 1, 2, 6, 7, 3, 4, 5, 8},
+	{0, 1, 2, 6, 8, 3, 4, 5, 7},
+	{0, 1, 2, 7, 8, 3, 4, 5, 6},
+	{0, 1, 3, 4, 5, 2, 6, 7, 8},
+	{0, 1, 3, 4, 6, 2, 5, 7, 8},
+	{0, 1, 3, 4, 7, 2, 5, 6, 8},
+	{0, 1, 3, 4, 8, 2, 5, 6, 7},
+	{0, 1, 3, 5, 6, 2, 4, 7, 8},
+	{0, 1, 3, 5, 7, 2, 4, 6, 8},
+	{0, 1, 3, 5, 8, 2, 4, 6, 7},
+	{0, 1, 3, 6, 7, 2, 4, 5, 8},
+	{0, 1, 3, 6, 8, 2, 4, 5, 7},
+	{0, 1, 3, 7, 8, 2, 4, 5, 6},
+	{0, 1, 4, 5, 6, 2, 3, 7, 8},
+	{0, 1, 4, 5, 7, 2, 3, 6, 8},
+	{0, 1, 4, 5, 8, 2, 3, 6, 7},
+	{0, 1, 4, 6, 7, 2, 3, 5, 8},
+	{0, 1, 4, 6, 8, 2, 3, 5, 7},
+	{0, 1, 4, 7, 8, 2, 3, 5, 6},
+	{0, 1, 5, 6, 7, 2, 3, 4, 8},
+	{0, 1, 5, 6, 8, 2, 3, 4, 7},
+	{0, 1, 5, 7, 8, 2, 3, 4, 6},
+	{0, 1, 6, 7, 8, 2, 3, 4, 5},
+	{0, 2, 3, 4, 5, 1, 6, 7, 8},
+	{0, 2, 3, 4, 6, 1, 5, 7, 8},
+	{0, 2, 3, 4, 7, 1, 5, 6, 8},
+	{0, 2, 3, 4, 8, 1, 5, 6, 7},
+	{0, 2, 3, 5, 6, 1, 4, 7, 8},
+	{0, 2, 3, 5, 7, 1, 4, 6, 8},
+	{0, 2, 3, 5, 8, 1, 4, 6, 7},
+	{0, 2, 3, 6, 7, 1, 4, 5, 8},
+	{0, 2, 3, 6, 8, 1, 4, 5, 7},
+	{0, 2, 3, 7, 8, 1, 4, 5, 6},
+	{0, 2, 4, 5, 6, 1, 3, 7, 8},
+	{0, 2, 4, 5, 7, 1, 3, 6, 8},
+	{0, 2, 4, 5, 8, 1, 3, 6, 7},
+	{0, 2, 4, 6, 7, 1, 3, 5, 8},
+	{0, 2, 4, 6, 8, 1, 3, 5, 7},
+	{0, 2, 4, 7, 8, 1, 3, 5, 6},
+	{0, 2, 5, 6, 7, 1, 3, 4, 8},
+	{0, 2, 5, 6, 8, 1, 3, 4, 7},
+	{0, 2, 5, 7, 8, 1, 3, 4, 6},
+	{0, 2, 6, 7, 8, 1, 3, 4, 5},
+	{0, 3, 4, 5, 6, 1, 2, 7, 8},
+	{0, 3, 4, 5, 7, 1, 2, 6, 8},
+	{0, 3, 4, 5, 8, 1, 2, 6, 7},
+	{0, 3, 4, 6, 7, 1, 2, 5, 8},
+	{0, 3, 4, 6, 8, 1, 2, 5, 7},
+	{0, 3, 4, 7, 8, 1, 2, 5, 6},
+	{0, 3, 5, 6, 7, 1, 2, 4, 8},
+	{0, 3, 5, 6, 8, 1, 2, 4, 7},
+	{0, 3, 5, 7, 8, 1, 2, 4, 6},
+	{0, 3, 6, 7, 8, 1, 2, 4, 5},
+	{0, 4, 5, 6, 7, 1, 2, 3, 8},
+	{0, 4, 5, 6, 8, 1, 2, 3, 7},
+	{0, 4, 5, 7, 8, 1, 2, 3, 6},
+	{0, 4, 6, 7, 8, 1, 2, 3, 5},
+	{0, 5, 6, 7, 8, 1, 2, 3, 4},
+	{1, 2, 3, 4, 5, 0, 6, 7, 8},
+	{1, 2, 3, 4, 6, 0, 5, 7, 8},
+	{1, 2, 3, 4, 7, 0, 5, 6, 8},
+	{1, 2, 3, 4, 8, 0, 5, 6, 7},
+	{1, 2, 3, 5, 6, 0, 4, 7, 8},
+	{1, 2, 3, 5, 7, 0, 4, 6, 8},
+	{1, 2, 3, 5, 8, 0, 4, 6, 7},
+	{1, 2, 3, 6, 7, 0, 4, 5, 8},
+	{1, 2, 3, 6, 8, 0, 4, 5, 7},
+	{1, 2, 3, 7, 8, 0, 4, 5, 6},
+	{1, 2, 4, 5, 6, 0, 3, 7, 8},
+	{1, 2, 4, 5, 7, 0, 3, 6, 8},
+	{1, 2, 4, 5, 8, 0, 3, 6, 7},
+	{1, 2, 4, 6, 7, 0, 3, 5, 8},
+	{1, 2, 4, 6, 8, 0, 3, 5, 7},
+	{1, 2, 4, 7, 8, 0, 3, 5, 6},
+	{1, 2, 5, 6, 7, 0, 3, 4, 8},
+	{1, 2, 5, 6, 8, 0, 3, 4, 7},
+	{1, 2, 5, 7, 8, 0, 3, 4, 6},
+	{1, 2, 6, 7, 8, 0, 3, 4, 5},
+	{1, 3, 4, 5, 6, 0, 2, 7, 8},
+	{1, 3, 4, 5, 7, 0, 2, 6, 8},
+	{1, 3, 4, 5, 8, 0, 2, 6, 7},
+	{1, 3, 4, 6, 7, 0, 2, 5, 8},
+	{1, 3, 4, 6, 8, 0, 2, 5, 7},
+	{1, 3, 4, 7, 8, 0, 2, 5, 6},
+	{1, 3, 5, 6, 7, 0, 2, 4, 8},
+	{1, 3, 5, 6, 8, 0, 2, 4, 7},
+	{1, 3, 5, 7, 8, 0, 2, 4, 6},
+	{1, 3, 6, 7, 8, 0, 2, 4, 5},
+	{1, 4, 5, 6, 7, 0, 2, 3, 8},
+	{1, 4, 5, 6, 8, 0, 2, 3, 7},
+	{1, 4, 5, 7, 8, 0, 2, 3, 6},
+	{1, 4, 6, 7, 8, 0, 2, 3, 5},
+	{1, 5, 6, 7, 8, 0, 2, 3, 4},
+	{2, 3, 4, 5, 6, 0, 1, 7, 8},
+	{2, 3, 4, 5, 7, 0, 1, 6, 8},
+	{2, 3, 4, 5, 8, 0, 1, 6, 7},
+	{2, 3, 4, 6, 7, 0, 1, 5, 8},
+	{2, 3, 4, 6, 8, 0, 1, 5, 7},
+	{2, 3, 4, 7, 8, 0, 1, 5, 6},
+	{2, 3, 5, 6, 7, 0, 1, 4, 8},
+	{2, 3, 5, 6, 8, 0, 1, 4, 7},
+	{2, 3, 5, 7, 8, 0, 1, 4, 6},
+	{2, 3, 6, 7, 8, 0, 1, 4, 5},
+	{2, 4, 5, 6, 7, 0, 1, 3, 8},
+	{2, 4, 5, 6, 8, 0, 1, 3, 7},
+	{2, 4, 5, 7, 8, 0, 1, 3, 6},
+	{2, 4, 6, 7, 8, 0, 1, 3, 5},
+	{2, 5, 6, 7, 8, 0, 1, 3, 4},
+	{3, 4, 5, 6, 7, 0, 1, 2, 8},
+	{3, 4, 5, 6, 8, 0, 1, 2, 7},
+	{3, 4, 5, 7, 8, 0, 1, 2, 6},
+	{3, 4, 6, 7, 8, 0, 1, 2, 5},
+	{3, 5, 6, 7, 8, 0, 1, 2, 4},
+	{4, 5, 6, 7, 8, 0, 1, 2, 3},
+}
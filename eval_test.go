@@ -1,12 +1,17 @@
 package cardrank
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math/bits"
 	"math/rand"
 	"os"
+	"reflect"
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -72,6 +77,38 @@ func TestOrder(t *testing.T) {
 	}
 }
 
+func TestOrderGroups(t *testing.T) {
+	board := Must("Ah Kh 7c 7d 2s")
+	pockets := [][]Card{
+		Must("Qs Js"),
+		Must("Ac 3d"),
+		Must("As 3c"),
+		Must("Qc Jc"),
+		Must("7s 7h"),
+		Must("9d 8d"),
+	}
+	evs := make([]*Eval, len(pockets)+1)
+	for i, pocket := range pockets {
+		evs[i] = Holdem.Eval(pocket, board)
+	}
+	exp := [][]int{{4}, {1, 2}, {0, 3}, {5}}
+	if groups := OrderGroups(evs, false); !reflect.DeepEqual(groups, exp) {
+		t.Errorf("expected %v, got: %v", exp, groups)
+	}
+	if groups := OrderGroups(evs, true); groups != nil {
+		t.Errorf("expected nil, got: %v", groups)
+	}
+	evs = []*Eval{
+		OmahaHiLo.Eval(Must("Ah 2h Kc Kd"), Must("3c 4d 8s Qh Jc")),
+		OmahaHiLo.Eval(Must("As 2c Qc Qd"), Must("3c 4d 8s Qh Jc")),
+		OmahaHiLo.Eval(Must("Kh Ks Tc Td"), Must("3c 4d 8s Qh Jc")),
+	}
+	exp = [][]int{{0, 1}}
+	if groups := OrderGroups(evs, true); !reflect.DeepEqual(groups, exp) {
+		t.Errorf("expected %v, got: %v", exp, groups)
+	}
+}
+
 func TestEvalComp(t *testing.T) {
 	tests := []struct {
 		typ Type
@@ -157,6 +194,404 @@ func TestEval(t *testing.T) {
 	}
 }
 
+func TestBoardEval(t *testing.T) {
+	for _, typ := range Types() {
+		t.Run(typ.Name(), func(t *testing.T) {
+			p, n := typ.Pocket(), typ.Board()
+			for range 100 {
+				v := shuffled(typ.DeckType())
+				board := v[:n]
+				var pockets [][]Card
+				for i := n; i+p <= len(v) && len(pockets) < 6; i += p {
+					pockets = append(pockets, v[i:i+p])
+				}
+				evs := typ.EvalPockets(pockets, board)
+				for i, pocket := range pockets {
+					if exp := typ.Eval(pocket, board); !reflect.DeepEqual(evs[i], exp) {
+						t.Fatalf("pocket %d %v %v expected %v, got: %v", i, pocket, board, exp, evs[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestEvalPocketsContext(t *testing.T) {
+	pockets, board := [][]Card{Must("Ah Kh 9s Jd 2c 3c"), Must("7c 7d 6s 5h 4d 3h")}, Must("2d 8h Td Qc Ks")
+	evs, err := OmahaSix.EvalPocketsContext(context.Background(), pockets, board)
+	if err != nil || len(evs) != 2 {
+		t.Fatalf("expected 2 evals, got: %d %v", len(evs), err)
+	}
+	for i, ev := range evs {
+		if exp := OmahaSix.Eval(pockets[i], board); ev.HiRank != exp.HiRank {
+			t.Errorf("test %d expected %d, got: %d", i, exp.HiRank, ev.HiRank)
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if evs, err := OmahaSix.EvalPocketsContext(ctx, pockets, board); !errors.Is(err, context.Canceled) || len(evs) != 0 {
+		t.Errorf("expected %v, got: %d %v", context.Canceled, len(evs), err)
+	}
+}
+
+func TestEvalPocketsParallel(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	for _, typ := range []Type{Holdem, Omaha, Video} {
+		d := typ.DeckType().Shoe(40)
+		d.Shuffle(r, 1)
+		board := d.Draw(typ.Board())
+		var pockets [][]Card
+		for range 200 {
+			pockets = append(pockets, d.Draw(typ.Pocket()))
+		}
+		evs, err := typ.EvalPocketsParallel(context.Background(), pockets, board)
+		if err != nil {
+			t.Fatalf("%s expected no error, got: %v", typ, err)
+		}
+		if exp := typ.EvalPockets(pockets, board); !reflect.DeepEqual(evs, exp) {
+			t.Errorf("%s expected %v, got: %v", typ, exp, evs)
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Holdem.EvalPocketsParallel(ctx, [][]Card{Must("Ah Kh")}, Must("2c 3c 4c")); !errors.Is(err, ErrContextCancelled) {
+		t.Errorf("expected %v, got: %v", ErrContextCancelled, err)
+	}
+}
+
+func TestEvalNormalize(t *testing.T) {
+	for _, typ := range Types() {
+		t.Run(typ.Name(), func(t *testing.T) {
+			p, n := typ.Pocket(), typ.Board()
+			for i := range 100 {
+				v := shuffled(typ.DeckType())
+				pocket, board := v[:p], v[p:p+n]
+				exp := typ.Eval(pocket, board)
+				ev := typ.Calc(pocket, board)
+				if ev.HiRank != exp.HiRank || ev.LoRank != exp.LoRank {
+					t.Fatalf("test %d %v %v expected %d/%d, got: %d/%d", i, pocket, board, exp.HiRank, exp.LoRank, ev.HiRank, ev.LoRank)
+				}
+				ev.Normalize()
+				if !reflect.DeepEqual(ev, exp) {
+					t.Fatalf("test %d %v %v expected %v, got: %v", i, pocket, board, exp, ev)
+				}
+			}
+		})
+	}
+}
+
+func TestOmahaPartial(t *testing.T) {
+	for _, typ := range Types() {
+		if typ.Desc().Eval != EvalOmaha {
+			continue
+		}
+		t.Run(typ.Name(), func(t *testing.T) {
+			for np := range typ.Pocket() + 1 {
+				for nb := range typ.Board() + 1 {
+					v := shuffled(typ.DeckType())
+					pocket, board := v[:np], v[np:np+nb]
+					ev := typ.Eval(pocket, board)
+					if ev.HiRank == 0 || ev.HiRank == Invalid {
+						t.Errorf("%d/%d %v %v expected valid rank, got: %d", np, nb, pocket, board, ev.HiRank)
+					}
+					if n := len(ev.HiBest); nb < 3 && n != min(np, 2) {
+						t.Errorf("%d/%d %v %v expected %d best, got: %d", np, nb, pocket, board, min(np, 2), n)
+					}
+					if s := fmt.Sprintf("%s %b", ev, ev); s == "" {
+						t.Errorf("%d/%d %v %v expected non-empty", np, nb, pocket, board)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestStudPartial(t *testing.T) {
+	tests := []struct {
+		typ  Type
+		v    string
+		best string
+		exp  string
+	}{
+		{Stud, "Kh Ad Ah", "Ad Ah Kh", "Pair, Aces, kicker King"},
+		{Stud, "Kh Ad Ah 7c", "Ad Ah Kh 7c", "Pair, Aces, kickers King, Seven"},
+		{Stud, "9c 9d 9h", "9c 9d 9h", "Three of a Kind, Nines"},
+		{Stud, "9c 9d 9h 9s", "9c 9d 9h 9s", "Four of a Kind, Nines"},
+		{Stud, "9c 9d 2h 2s", "9c 9d 2h 2s", "Two Pair, Nines over Twos"},
+		{Stud, "2c 3c 4c 5c", "5c 4c 3c 2c", "Five-high, kickers Four, Three, Two"},
+		{StudHiLo, "Jc 3c Kd", "Kd Jc 3c", "King-high, kickers Jack, Three"},
+		{Razz, "3c Ah 2d", "3c 2d Ah", "Three, Two, Ace-low"},
+		{Razz, "Kh Ad Ah", "Ad Ah Kh", "Pair, Aces, kicker King"},
+	}
+	for i, test := range tests {
+		v := Must(test.v)
+		ev := test.typ.Eval(v, nil)
+		if ev.HiRank == 0 || ev.HiRank == Invalid {
+			t.Fatalf("test %d expected valid rank, got: %d", i, ev.HiRank)
+		}
+		// additional cards never worsen the rank held so far
+		for n := 1; len(v)+n <= 7; n++ {
+			if r := test.typ.Eval(append(slices.Clone(v), Must("Td Jh Qs")[:n]...), nil).HiRank; ev.HiRank < r {
+				t.Errorf("test %d expected %d <= %d, got: %d", i, r, ev.HiRank, r)
+			}
+		}
+		if s, exp := fmt.Sprintf("%s", ev.HiBest), fmt.Sprintf("%s", Must(test.best)); s != exp {
+			t.Errorf("test %d expected best %s, got: %s", i, exp, s)
+		}
+		if len(ev.HiUnused) != 0 {
+			t.Errorf("test %d expected no unused, got: %s", i, ev.HiUnused)
+		}
+		if s := fmt.Sprintf("%s", ev.Desc(false)); s != test.exp {
+			t.Errorf("test %d expected %q, got: %q", i, test.exp, s)
+		}
+	}
+	// ranks are ordered the same as the descriptions
+	for _, v := range [][2]string{
+		{"7c 3d 2h", "6c 5d 4h 3s"},
+		{"Ah Ad Kh", "Ah Ad Qh Jc"},
+		{"9c 9d 9h", "Ah Ad Kc Ks"},
+	} {
+		a, b := Stud.Eval(Must(v[0]), nil), Stud.Eval(Must(v[1]), nil)
+		if b.HiRank < a.HiRank {
+			t.Errorf("expected %s to be no worse than %s, got: %d %d", v[0], v[1], a.HiRank, b.HiRank)
+		}
+	}
+}
+
+func TestCheckEval(t *testing.T) {
+	for _, typ := range Types() {
+		p, n := typ.Pocket(), typ.Board()
+		for i := range 100 {
+			v := shuffled(typ.DeckType())
+			pocket, board := v[:p], v[p:p+n]
+			if err := CheckEval(typ.Eval(pocket, board), pocket, board); err != nil {
+				t.Fatalf("%s test %d expected no error, got: %v", typ, i, err)
+			}
+		}
+	}
+	pocket, board := Must("Ah Kh"), Must("Qh Jh Th 2c 3d")
+	ev := Holdem.Eval(pocket, board)
+	ev.HiUnused = nil
+	if err := CheckEval(ev, pocket, board); !errors.Is(err, ErrInvalidEval) {
+		t.Errorf("expected %v, got: %v", ErrInvalidEval, err)
+	}
+	ev = Holdem.Eval(pocket, board)
+	ev.HiBest = ev.HiBest[:4]
+	if err := CheckEval(ev, pocket, board); !errors.Is(err, ErrInvalidEval) {
+		t.Errorf("expected %v, got: %v", ErrInvalidEval, err)
+	}
+	ev = Holdem.Eval(pocket, board)
+	ev.HiRank++
+	if err := CheckEval(ev, pocket, board); !errors.Is(err, ErrInvalidEval) {
+		t.Errorf("expected %v, got: %v", ErrInvalidEval, err)
+	}
+}
+
+func TestValidateBest(t *testing.T) {
+	tests := []struct {
+		typ    Type
+		best   string
+		pocket string
+		board  string
+		exp    error
+	}{
+		{Omaha, "Ah Kh Qh Jh Th", "Ah Kh 2c 3c", "Qh Jh Th 4d 5d", nil},
+		{Omaha, "Ah Qh Jh Th 4d", "Ah Kh 2c 3c", "Qh Jh Th 4d 5d", ErrInvalidEval},
+		{Omaha, "Ah Kh 2c Qh Jh", "Ah Kh 2c 3c", "Qh Jh Th 4d 5d", ErrInvalidEval},
+		{Omaha, "Ah Kh Qh Jh", "Ah Kh 2c 3c", "Qh Jh Th 4d 5d", ErrInvalidEval},
+		{Dallas, "Ah Kh Qh Jh Th", "Ah Kh", "Qh Jh Th 4d 5d", nil},
+		{Dallas, "Ah Qh Jh Th 4d", "Ah Kh", "Qh Jh Th 4d 5d", ErrInvalidEval},
+		{Houston, "Ah Kh Qh Jh Th", "Ah Kh 2c", "Qh Jh Th", nil},
+		{Holdem, "Qh Jh Th 4d 5d", "Ah Kh", "Qh Jh Th 4d 5d", nil},
+		{Holdem, "Ah Kh Qh Jh Jh", "Ah Kh", "Qh Jh Th 4d 5d", ErrInvalidEval},
+		{Holdem, "Ah Kh Qh Jh 9h", "Ah Kh", "Qh Jh Th 4d 5d", ErrInvalidEval},
+		{Razz, "Ah 2c 3d 4s 5h", "Ah 2c 3d 4s 5h Kh Kd", "", nil},
+		{Type(0), "", "", "", ErrUnsupportedType},
+	}
+	for i, test := range tests {
+		best, pocket, board := Must(test.best), Must(test.pocket), Must(test.board)
+		if err := ValidateBest(test.typ, best, pocket, board); !errors.Is(err, test.exp) {
+			t.Errorf("test %d expected %v, got: %v", i, test.exp, err)
+		}
+	}
+}
+
+func TestEvalPocketCards(t *testing.T) {
+	tests := []struct {
+		typ    Type
+		pocket string
+		board  string
+		hi     string
+		lo     string
+	}{
+		{Omaha, "Ah Kh 7c 7d", "Ac Kc Qh Jh Th", "Ah Kh", ""},
+		{Omaha, "Ah Ad 2c 3c", "As Ks 2s 2d 3h", "Ah Ad", ""},
+		{OmahaHiLo, "Ah 2h Kc Kd", "3c 4d 5s Kh 8c", "Ah 2h", "Ah 2h"},
+		{Dallas, "9c 9d", "9h 9s 2c 2d 3h", "9c 9d", ""},
+	}
+	for i, test := range tests {
+		for _, calc := range []bool{false, true} {
+			pocket, board := Must(test.pocket), Must(test.board)
+			ev := test.typ.Eval(pocket, board)
+			if calc {
+				ev = test.typ.Calc(pocket, board)
+			}
+			hi, hiBoard := ev.HiPocketCards()
+			if exp := Must(test.hi); !sameCards(hi, exp, nil) {
+				t.Errorf("test %d expected %v, got: %v", i, exp, hi)
+			}
+			if !sameCards(slices.Concat(hi, hiBoard), ev.HiBest, nil) {
+				t.Errorf("test %d expected %v, got: %v %v", i, ev.HiBest, hi, hiBoard)
+			}
+			ev.Normalize()
+			if hi, _ := ev.HiPocketCards(); !sameCards(hi, Must(test.hi), nil) {
+				t.Errorf("test %d expected %s, got: %v", i, test.hi, hi)
+			}
+			if lo, _ := ev.LoPocketCards(); !sameCards(lo, Must(test.lo), nil) {
+				t.Errorf("test %d expected %s, got: %v", i, test.lo, lo)
+			}
+		}
+	}
+}
+
+func TestEvalClone(t *testing.T) {
+	pocket := Must("Ah 2h Kc Kd")
+	ev := OmahaHiLo.Eval(pocket, Must("3c 4d 8s Qh Jc"))
+	c := ev.Clone()
+	if !reflect.DeepEqual(c, ev) {
+		t.Fatalf("expected %v, got: %v", ev, c)
+	}
+	c.HiBest[0] = InvalidCard
+	if ev.HiBest[0] == InvalidCard {
+		t.Errorf("expected clone to not share best")
+	}
+	if c := (*Eval)(nil).Clone(); c != nil {
+		t.Errorf("expected nil, got: %v", c)
+	}
+	hi, lo := Omaha.Eval(pocket, Must("3c 4d 8s Qh Jc")), Omaha.Eval(pocket, Must("Ks 9d 8d 2c 2s"))
+	exp := hi.Clone()
+	hi.SetLoFrom(lo)
+	switch {
+	case hi.HiRank != exp.HiRank:
+		t.Errorf("expected %d, got: %d", exp.HiRank, hi.HiRank)
+	case hi.LoRank != lo.HiRank:
+		t.Errorf("expected %d, got: %d", lo.HiRank, hi.LoRank)
+	case !slices.Equal(hi.LoBest, lo.HiBest), !slices.Equal(hi.LoUnused, lo.HiUnused), hi.LoPocket != lo.HiPocket:
+		t.Errorf("expected %v %v, got: %v %v", lo.HiBest, lo.HiUnused, hi.LoBest, hi.LoUnused)
+	}
+}
+
+func TestOmahaPartialBest(t *testing.T) {
+	ev := OmahaSix.Eval(Must("9h Th Jh Qh Ah Kh"), Must("2c"))
+	if exp := Must("Ah Kh"); !slices.Equal(ev.HiBest, exp) {
+		t.Errorf("expected %v, got: %v", exp, ev.HiBest)
+	}
+	if exp := Must("Qh Jh Th 9h 2c"); !slices.Equal(ev.HiUnused, exp) {
+		t.Errorf("expected %v, got: %v", exp, ev.HiUnused)
+	}
+	if r := Omaha.Eval(Must("Ah Kh"), nil).HiRank; ev.HiRank != r {
+		t.Errorf("expected %d, got: %d", r, ev.HiRank)
+	}
+}
+
+func TestSetBackend(t *testing.T) {
+	active := ActiveBackend()
+	defer func() {
+		if err := SetBackend(active); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+	}()
+	if !active.Available() {
+		t.Fatalf("expected %s to be available", active.Name())
+	}
+	if err := SetBackend(Backend(100)); !errors.Is(err, ErrInvalidBackend) {
+		t.Errorf("expected error %v, got: %v", ErrInvalidBackend, err)
+	}
+	// build expected
+	type hand struct {
+		typ           Type
+		pocket, board []Card
+		exp           *Eval
+	}
+	var hands []hand
+	for _, typ := range Types() {
+		p, n := typ.Pocket(), typ.Board()
+		for range 50 {
+			v := shuffled(typ.DeckType())
+			hands = append(hands, hand{typ, v[:p], v[p : p+n], typ.Eval(v[:p], v[p:p+n])})
+			if p+n == 7 && n != 0 {
+				// 6 cards, before the river
+				hands = append(hands, hand{typ, v[:p], v[p : p+n-1], typ.Eval(v[:p], v[p:p+n-1])})
+			}
+		}
+	}
+	for _, backend := range Backends() {
+		t.Run(backend.Name(), func(t *testing.T) {
+			if err := SetBackend(backend); err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if b := ActiveBackend(); b != backend {
+				t.Fatalf("expected %s, got: %s", backend.Name(), b.Name())
+			}
+			for i, h := range hands {
+				if ev := h.typ.Eval(h.pocket, h.board); ev.HiRank != h.exp.HiRank || ev.LoRank != h.exp.LoRank {
+					t.Errorf("test %d %s %v %v expected %d/%d, got: %d/%d", i, h.typ, h.pocket, h.board, h.exp.HiRank, h.exp.LoRank, ev.HiRank, ev.LoRank)
+				}
+			}
+		})
+	}
+}
+
+// TestSetBackendConcurrent checks that evals after setting the backend, and
+// eval funcs created before setting the backend, are safe for concurrent use.
+// Run with -race.
+func TestSetBackendConcurrent(t *testing.T) {
+	if !BackendTwoPlusTwo.Available() {
+		t.Skip("skipping: two-plus-two is not available")
+	}
+	active := ActiveBackend()
+	defer func() {
+		if err := SetBackend(active); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+	}()
+	var hands [][]Card
+	for range 200 {
+		hands = append(hands, shuffled(DeckFrench)[:7])
+	}
+	exp := make([]EvalRank, len(hands))
+	for i, v := range hands {
+		exp[i] = Holdem.Eval(v[:2], v[2:]).HiRank
+	}
+	prev := NewHybridEvalWith(true, false, Eight)
+	for _, backend := range Backends() {
+		if err := SetBackend(backend); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		var wg sync.WaitGroup
+		errs := make(chan error, 8)
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i, v := range hands {
+					ev := EvalOf(Holdem)
+					prev(ev, v[:2], v[2:])
+					if r := Holdem.Eval(v[:2], v[2:]).HiRank; r != exp[i] || ev.HiRank != exp[i] {
+						errs <- fmt.Errorf("%s %d expected %d, got: %d/%d", backend.Name(), i, exp[i], r, ev.HiRank)
+						return
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			t.Error(err)
+		}
+	}
+}
+
 func TestNewSplitEval(t *testing.T) {
 	tests := []struct {
 		f   RankFunc
@@ -190,6 +625,38 @@ func TestNewSplitEval(t *testing.T) {
 	}
 }
 
+func TestRankLowQualifier(t *testing.T) {
+	tests := []struct {
+		q  Rank
+		v  string
+		hi EvalRank
+		lo EvalRank
+	}{
+		{Eight, "8h 7h 6h 5h 4h", 7, 248},
+		{Eight, "9h 7h 6h 5h 4h", 1567, Invalid},
+		{Nine, "9h 7h 6h 5h 4h", 1567, 376},
+		{Nine, "Th 7h 6h 5h 4h", 1533, Invalid},
+		{Nine, "9h Th 8h 7h 6h 5h 4h", 5, 248},
+		{Seven, "8h 7h 6h 5h 4h", 7, Invalid},
+		{Seven, "7h 6h 5h 4h 2c", 7459, 122},
+		{Ace, "Kh Qh Jh 9h 7h", 824, 7488},
+		{Ace, "Kh Kc Jh 9h 7h", 3655, Invalid},
+		{InvalidRank, "Ah Kh Qh Jh Th", 1, 7681},
+	}
+	for i, test := range tests {
+		lo, maximum := RankLowQualifier(test.q)
+		p, f := Must(test.v), NewSplitEval(RankCactus, lo, maximum)
+		ev := EvalOf(0)
+		f(ev, p, nil)
+		if r, exp := ev.HiRank, test.hi; r != exp {
+			t.Errorf("test %d expected rank %d, got: %d", i, exp, r)
+		}
+		if r, exp := ev.LoRank, test.lo; r != exp {
+			t.Errorf("test %d expected rank %d, got: %d", i, exp, r)
+		}
+	}
+}
+
 func TestRankEightOrBetter(t *testing.T) {
 	p0 := Must("Ah 2h 3h 4h 5h 6h 7h 8h")
 	for i := Nine; i <= King; i++ {
@@ -213,6 +680,161 @@ func TestRankEightOrBetter(t *testing.T) {
 	}
 }
 
+func TestRankThree(t *testing.T) {
+	d := NewDeck()
+	counts, ranks := make(map[EvalRank]int), make(map[EvalRank]bool)
+	for i := range 52 {
+		for j := i + 1; j < 52; j++ {
+			for k := j + 1; k < 52; k++ {
+				r := RankThree(d.v[i], d.v[j], d.v[k])
+				ranks[r] = true
+				switch {
+				case r <= ThreeCardStraightFlush:
+					counts[ThreeCardStraightFlush]++
+				case r <= ThreeCardThreeOfAKind:
+					counts[ThreeCardThreeOfAKind]++
+				case r <= ThreeCardStraight:
+					counts[ThreeCardStraight]++
+				case r <= ThreeCardFlush:
+					counts[ThreeCardFlush]++
+				case r <= ThreeCardPair:
+					counts[ThreeCardPair]++
+				case r <= ThreeCardNothing:
+					counts[ThreeCardNothing]++
+				default:
+					t.Fatalf("%v %v %v has invalid rank %d", d.v[i], d.v[j], d.v[k], r)
+				}
+			}
+		}
+	}
+	exp := map[EvalRank]int{
+		ThreeCardStraightFlush: 48,
+		ThreeCardThreeOfAKind:  52,
+		ThreeCardStraight:      720,
+		ThreeCardFlush:         1096,
+		ThreeCardPair:          3744,
+		ThreeCardNothing:       16440,
+	}
+	if !reflect.DeepEqual(counts, exp) {
+		t.Errorf("expected %v, got: %v", exp, counts)
+	}
+	if n, exp := len(ranks), int(ThreeCardNothing); n != exp {
+		t.Errorf("expected %d ranks, got: %d", exp, n)
+	}
+}
+
+func TestRankPartial(t *testing.T) {
+	d := NewDeck()
+	counts, ranks := make(map[EvalRank]int), make(map[EvalRank]bool)
+	for _, n := range []int{3, 4} {
+		for g, v := NewCombinGen(d.v, n); g.Next(); {
+			r := RankPartial(v)
+			ranks[r] = true
+			switch r.FromPartial() {
+			case FourOfAKind:
+				counts[PartialFourOfAKind]++
+			case ThreeOfAKind:
+				counts[PartialThreeOfAKind]++
+			case TwoPair:
+				counts[PartialTwoPair]++
+			case Pair:
+				counts[PartialPair]++
+			case Nothing:
+				counts[PartialNothing]++
+			default:
+				t.Fatalf("%v has invalid rank %d", v, r)
+			}
+		}
+	}
+	exp := map[EvalRank]int{
+		PartialFourOfAKind:  13,
+		PartialThreeOfAKind: 2548,
+		PartialTwoPair:      2808,
+		PartialPair:         86112,
+		PartialNothing:      201344,
+	}
+	if !reflect.DeepEqual(counts, exp) {
+		t.Errorf("expected %v, got: %v", exp, counts)
+	}
+	if n, exp := len(ranks), int(PartialNothing); n != exp {
+		t.Errorf("expected %d ranks, got: %d", exp, n)
+	}
+	// partial Hi ranks are ordered by the partial ranks, in the same set
+	for r := EvalRank(1); r <= PartialNothing; r++ {
+		if hi := partialHi[r]; hi < partialHi[r-1] || hi.Fixed() != r.FromPartial() {
+			t.Errorf("partial rank %d has Hi rank %d", r, hi)
+		}
+	}
+	// no completion is worse than the partial Hi rank
+	for _, s := range []string{"Ah 3c 2d", "Ah 6c 2d", "7h 3c 2d", "9c 9d 9h", "Kh Ad Ah 7c", "6c 5c 4c 3c"} {
+		v := Must(s)
+		ev := EvalOf(Stud)
+		ev.HiPartial(v)
+		for g, u := NewCombinGen(DeckFrench.Exclude(v), 5-len(v)); g.Next(); {
+			w := append(slices.Clone(v), u...)
+			if r := RankCactus(w[0], w[1], w[2], w[3], w[4]); ev.HiRank < r {
+				t.Errorf("%s expected %v to be no worse than %d, got: %d", s, w, ev.HiRank, r)
+				break
+			}
+		}
+	}
+	tests := []struct {
+		a   string
+		b   string
+		exp string
+	}{
+		{"Ah Ad Kh 2c", "Ah Ad Kh", "Pair, Aces, kickers King, Two"},
+		{"Ah Ad Kh", "Ah Ad Qh Jc", "Pair, Aces, kicker King"},
+		{"7h 7d 7c", "Ah Ad Kh Kc", "Three of a Kind, Sevens"},
+		{"7h 7d 7c Ks", "7h 7d 7c 2s", "Three of a Kind, Sevens, kicker King"},
+		{"2h 2d 2c 2s", "Ah Ad Ac Ks", "Four of a Kind, Twos"},
+		{"Ah Kd 3c", "Ah Qd Jc 9s", "Ace-high, kickers King, Three"},
+		{"3h 3d 2c 2s", "Ah Ad Kc", "Two Pair, Threes over Twos"},
+	}
+	for i, test := range tests {
+		a, b := Must(test.a), Must(test.b)
+		ra, rb := RankPartial(a), RankPartial(b)
+		if rb <= ra {
+			t.Errorf("test %d expected %d < %d", i, ra, rb)
+		}
+		desc := PartialEval(a[:2], a[2:])
+		if desc == nil || desc.Rank != ra {
+			t.Fatalf("test %d expected rank %d, got: %v", i, ra, desc)
+		}
+		if s := fmt.Sprintf("%s", desc); s != test.exp {
+			t.Errorf("test %d expected %q, got: %q", i, test.exp, s)
+		}
+	}
+	if desc := PartialEval(Must("Ah Kh"), nil); desc != nil {
+		t.Errorf("expected nil, got: %v", desc)
+	}
+}
+
+func TestEvalRankCategoryBounds(t *testing.T) {
+	var prev EvalRank
+	for r := range IterCategories() {
+		lo, hi := r.CategoryBounds()
+		if lo != prev+1 || hi != r {
+			t.Errorf("%s expected %d, %d, got: %d, %d", r, prev+1, r, lo, hi)
+		}
+		for i := lo; i <= hi; i++ {
+			if a, b := i.CategoryBounds(); a != lo || b != hi {
+				t.Fatalf("%d expected %d, %d, got: %d, %d", i, lo, hi, a, b)
+			}
+			if i.Fixed() != r {
+				t.Fatalf("%d expected %s, got: %s", i, r, i.Fixed())
+			}
+		}
+		prev = hi
+	}
+	if prev != Nothing {
+		t.Errorf("expected %d, got: %d", Nothing, prev)
+	}
+	if lo, hi := Invalid.CategoryBounds(); lo != Invalid || hi != Invalid {
+		t.Errorf("expected invalid, got: %d, %d", lo, hi)
+	}
+}
+
 func TestEvalRankToFrom(t *testing.T) {
 	for i := EvalRank(1); i <= Nothing; i++ {
 		a := i.ToFlushOver()
@@ -426,3 +1048,208 @@ func sevenCardTests() []cardTest {
 		{"2d 3d As Ks Qs Js Ts", 0x0001, StraightFlush, "Straight Flush, Ace-high, Royal [A♠ K♠ Q♠ J♠ T♠] [3♦ 2♦]"},
 	}
 }
+
+func TestEvalHasLo(t *testing.T) {
+	tests := []struct {
+		typ    Type
+		pocket string
+		board  string
+		exp    bool
+	}{
+		{OmahaHiLo, "Ah 2h Kc Kd", "3c 4d 8s Ts Js", true},
+		{OmahaHiLo, "Ah 2h Kc Kd", "9c 9d Qs Ts Js", false},
+		{StudHiLo, "Ah 2h 3c 4d 8s Ts Js", "", true},
+		{StudHiLo, "Ah Kh 3c Qd 9s Ts Js", "", false},
+		{Holdem, "Ah 2h", "3c 4d 8s Ts Js", false},
+	}
+	for i, test := range tests {
+		ev := test.typ.Eval(Must(test.pocket), Must(test.board))
+		if b := ev.HasLo(); b != test.exp {
+			t.Errorf("test %d expected %t, got: %t", i, test.exp, b)
+		}
+		if d := ev.Desc(true); d.Qualified != test.exp {
+			t.Errorf("test %d expected %t, got: %t", i, test.exp, d.Qualified)
+		}
+		if d := ev.Desc(false); !d.Qualified {
+			t.Errorf("test %d expected qualified hi", i)
+		}
+	}
+	var ev *Eval
+	if ev.HasLo() {
+		t.Errorf("expected false")
+	}
+	if ev := Video.Eval(Must("Th Tc 9s 8d 2h"), nil); ev.Desc(false).Qualified {
+		t.Errorf("expected unqualified hi")
+	}
+}
+
+func TestForcedTwoEval(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	f := NewForcedTwoEval(RankCactus, RankEightOrBetter, eightOrBetterMax)
+	for i := range 200 {
+		np, nb := 2+i%3, 3+i%5
+		d := DeckFrench.New()
+		d.Shuffle(r, 1)
+		p, b := d.Draw(np), d.Draw(nb)
+		pairs := [][]Card{p}
+		if np != 2 {
+			pairs = nil
+			for g, u := NewCombinGen(p, 2); g.Next(); {
+				pairs = append(pairs, slices.Clone(u))
+			}
+		}
+		boards := [][]Card{b}
+		if nb != 3 {
+			boards = nil
+			for g, v := NewCombinGen(b, 3); g.Next(); {
+				boards = append(boards, slices.Clone(v))
+			}
+		}
+		exphi, explo := Invalid, Invalid
+		for _, u := range pairs {
+			for _, v := range boards {
+				exphi = min(exphi, RankCactus(u[0], u[1], v[0], v[1], v[2]))
+				if lo := RankEightOrBetter(u[0], u[1], v[0], v[1], v[2]); lo < eightOrBetterMax {
+					explo = min(explo, lo)
+				}
+			}
+		}
+		ev := EvalOf(0)
+		f(ev, p, b)
+		if ev.HiRank != exphi || ev.LoRank != explo {
+			t.Errorf("test %d expected %d/%d, got: %d/%d", i, exphi, explo, ev.HiRank, ev.LoRank)
+		}
+		if len(ev.HiBest) != 5 || len(ev.HiUnused) != np+nb-5 {
+			t.Fatalf("test %d expected 5 best and %d unused, got: %v %v", i, np+nb-5, ev.HiBest, ev.HiUnused)
+		}
+		if r := RankCactus(ev.HiBest[0], ev.HiBest[1], ev.HiBest[2], ev.HiBest[3], ev.HiBest[4]); r != exphi {
+			t.Errorf("test %d expected best %d, got: %d", i, exphi, r)
+		}
+		if n := bits.OnesCount8(ev.HiPocket); n != 2 {
+			t.Errorf("test %d expected 2 pocket cards, got: %d", i, n)
+		}
+		if explo != Invalid && len(ev.LoBest) != 5 {
+			t.Errorf("test %d expected lo best, got: %v", i, ev.LoBest)
+		}
+		if np == 4 && nb == 5 {
+			exp := Omaha.Eval(p, b)
+			if ev.HiRank != exp.HiRank {
+				t.Errorf("test %d expected %d, got: %d", i, exp.HiRank, ev.HiRank)
+			}
+		}
+	}
+	// a eval already having a better hi is kept
+	ev := EvalOf(0)
+	ev.HiRank, ev.HiBest = 1, Must("Ah Kh Qh Jh Th")
+	ev.HiLo2(RankCactus, nil, Must("2c")[0], Must("3d")[0], Must("7s 8h 9d Jc Ks Qd"), Invalid)
+	if exp := Must("Ah Kh Qh Jh Th"); ev.HiRank != 1 || !slices.Equal(ev.HiBest, exp) {
+		t.Errorf("expected %d %v, got: %d %v", 1, exp, ev.HiRank, ev.HiBest)
+	}
+}
+
+func TestEvalEightNine(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	for i := range 200 {
+		n := 8 + i%2
+		d := DeckFrench.New()
+		d.Shuffle(r, 1)
+		v := d.Draw(n)
+		exphi, explo := Invalid, Invalid
+		for g, c := NewCombinGen(v, 5); g.Next(); {
+			exphi = min(exphi, RankCactus(c[0], c[1], c[2], c[3], c[4]))
+			if lo := RankEightOrBetter(c[0], c[1], c[2], c[3], c[4]); lo < eightOrBetterMax {
+				explo = min(explo, lo)
+			}
+		}
+		ev := EvalOf(0)
+		NewEval(RankCactus)(ev, v[:3], v[3:])
+		if ev.HiRank != exphi || len(ev.HiBest) != 5 || len(ev.HiUnused) != n-5 {
+			t.Errorf("test %d expected %d, got: %d", i, exphi, ev.HiRank)
+		}
+		if r := RankCactus(ev.HiBest[0], ev.HiBest[1], ev.HiBest[2], ev.HiBest[3], ev.HiBest[4]); r != exphi {
+			t.Errorf("test %d expected best %d, got: %d", i, exphi, r)
+		}
+		ev = EvalOf(0)
+		NewSplitEval(RankCactus, RankEightOrBetter, eightOrBetterMax)(ev, v, nil)
+		if ev.HiRank != exphi {
+			t.Errorf("test %d expected %d, got: %d", i, exphi, ev.HiRank)
+		}
+		if ev.LoRank != explo {
+			t.Errorf("test %d expected lo %d, got: %d", i, explo, ev.LoRank)
+		}
+		ev = EvalOf(0)
+		NewMaxEval(RankCactus, Flush, false)(ev, v, nil)
+		exp := exphi
+		if exp >= Flush {
+			exp = Invalid
+		}
+		if ev.HiRank != exp {
+			t.Errorf("test %d expected max %d, got: %d", i, exp, ev.HiRank)
+		}
+	}
+}
+
+func TestVariantCategory(t *testing.T) {
+	tests := []struct {
+		typ    Type
+		pocket string
+		board  string
+		exp    Category
+	}{
+		{Holdem, "Ah Kh", "Qh Jh Th 2c 3d", CategoryStraightFlush},
+		{Holdem, "Ah Ac", "Qh Jd 9h 2c 3d", CategoryPair},
+		{Short, "Ah Kh", "Qh Jh 9h 6c 7d", CategoryFlush},
+		{Short, "Ah Ac", "Ad Jh Jc 6c 7d", CategoryFullHouse},
+		{Soko, "Ah 7h Qh Jh Tc", "", CategoryFourFlush},
+		{Soko, "9h 8c 7s 6d 2h", "", CategoryFourStraight},
+		{Soko, "Ah Ac Qd Jh Tc", "", CategoryPair},
+		{Lowball, "7c 5d 4h 3s 2c", "", CategoryLow},
+		{Lowball, "7c 7d 4h 3s 2c", "", CategoryPair},
+		{Lowball, "7c 5c 4c 3c 2c", "", CategoryFlush},
+		{Razz, "Ah 2c 3d 4h 5c Kd Kc", "", CategoryLow},
+		{Razz, "Ah Ac 2d 2h 3c 3d 3s", "", CategoryTwoPair},
+		{Razz, "Ah Ac 2d 2h 3c 3d Kc", "", CategoryPair},
+		{Badugi, "Ah 2c 3d 4s", "", CategoryBadugiFour},
+		{Badugi, "Ah 2h 3d 4s", "", CategoryBadugiThree},
+		{Guts, "Ah Ad Ac", "", CategoryThreeOfAKind},
+		{GutsTwo, "Ah Ad", "", CategoryPair},
+		{GutsTwo, "Ah Kd", "", CategoryNothing},
+	}
+	for i, test := range tests {
+		var board []Card
+		if test.board != "" {
+			board = Must(test.board)
+		}
+		ev := test.typ.Eval(Must(test.pocket), board)
+		if c := VariantCategory(test.typ, ev.HiRank); c != test.exp {
+			t.Errorf("test %d %s expected %s, got: %s", i, test.typ, test.exp.Name(), c.Name())
+		}
+	}
+	if c := VariantCategory(Holdem, Invalid); c != CategoryInvalid {
+		t.Errorf("expected %s, got: %s", CategoryInvalid.Name(), c.Name())
+	}
+	if s := fmt.Sprintf("%s %d", CategoryFourFlush, CategoryFourFlush); s != "FourFlush 10" {
+		t.Errorf("expected FourFlush 10, got: %s", s)
+	}
+	// pay hands are categorized the same
+	for _, v := range []string{"Ah 7h Qh Jh Tc", "9h 8c 7s 6d 2h", "Ah Ac Qd Jh Tc", "Ah 3c Qd Jh Tc"} {
+		ev := Soko.Eval(Must(v), nil)
+		c, h := VariantCategory(Soko, ev.HiRank), Payout{}.Hand(Soko, ev.HiRank)
+		if c.Name() != strings.ReplaceAll(h.Name(), " ", "") && !(c == CategoryPair && h == PayJacksOrBetter) {
+			t.Errorf("%s expected %s, got: %s", v, c, h)
+		}
+	}
+}
+
+func TestTakeCopies(t *testing.T) {
+	buf := new(cardBuf)
+	for i, f := range []func(*cardBuf, []Card) ([][]Card, int){take2c2, take3c3} {
+		v := Must("Ah Kh Qh")[:2+i]
+		exp := slices.Clone(v)
+		u, n := f(buf, v)
+		v[0] = New(Two, Club)
+		if n != 1 || !slices.Equal(u[0], exp) {
+			t.Errorf("test %d expected %v, got: %v", i, exp, u[0])
+		}
+	}
+}
@@ -0,0 +1,21 @@
+//go:build !tiny
+
+package cardrank_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cardrank/cardrank"
+)
+
+func Example_computerHand() {
+	pocket := cardrank.Must("Qh 7s")
+	expv, ok := cardrank.Holdem.ExpValue(context.Background(), pocket)
+	if !ok {
+		panic("unable to calculate expected value")
+	}
+	fmt.Println("expected value:", expv)
+	// Output:
+	// expected value: 51.8% (1046780178,78084287/2097572400)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"math/rand"
+	"os"
 	"strconv"
 	"strings"
 
@@ -221,8 +222,8 @@ func Example() {
 	//        [7d 6h 5c 4h As] [Ac Ks 7s 5d] Seven, Six, Five, Four, Ace-low
 	//     1: [Ac As Ks Qs 9s] [8d 7d 6h 4c] Pair, Aces, kickers King, Queen, Nine
 	//        [8d 7d 6h 4c As] [Ac Ks Qs 9s] Eight, Seven, Six, Four, Ace-low
-	//     Result: Alice wins with Two Pair, Aces over Sevens, kicker Five
-	//             Alice wins with Seven, Six, Five, Four, Ace-low
+	//     Result: Alice scoops with Two Pair, Aces over Sevens, kicker Five
+	//             Alice scoops with Seven, Six, Five, Four, Ace-low
 	//   Run 2:
 	//     0: [Ac As 5c 5d Ks] [8s 7s 6h 4h] Two Pair, Aces over Fives, kicker King
 	//        [8s 6h 5c 4h As] [Ac Ks 7s 5d] Eight, Six, Five, Four, Ace-low
@@ -363,8 +364,8 @@ func Example() {
 	//     4: [Jc Jh Kc Tc 4h] [7c 7s 3s 2d] Pair, Jacks, kickers King, Ten, Four
 	//        [] [] None
 	//     5: inactive
-	//     Result: Carl wins with Straight, Six-high
-	//             Carl wins with Six, Five, Four, Three, Two-low
+	//     Result: Carl scoops with Straight, Six-high
+	//             Carl scoops with Six, Five, Four, Three, Two-low
 	// ------ FusionHiLo 4 ------
 	// Deck:
 	//   [Qc 4h 2c 7c Kc 5c 9d 5h]
@@ -1369,17 +1370,6 @@ func ExampleExpValueCalc() {
 	// expected value: 75.6% (802371,13659/1070190)
 }
 
-func Example_computerHand() {
-	pocket := cardrank.Must("Qh 7s")
-	expv, ok := cardrank.Holdem.ExpValue(context.Background(), pocket)
-	if !ok {
-		panic("unable to calculate expected value")
-	}
-	fmt.Println("expected value:", expv)
-	// Output:
-	// expected value: 51.8% (1046780178,78084287/2097572400)
-}
-
 func Example_holdemPreflop() {
 	pocket := cardrank.Must("2h 2d")
 	ev := cardrank.EvalOf(cardrank.Holdem)
@@ -1419,3 +1409,43 @@ func ExampleType_calc() {
 	// 2: 21.4% (9/42) - any ♥
 }
 */
+
+func ExampleDealer_WriteSummary() {
+	// note: use a real random source
+	r := rand.New(rand.NewSource(1234))
+	d := cardrank.Holdem.Dealer(r, 1, 3)
+	for d.Next() {
+	}
+	if err := d.WriteSummary(os.Stdout, "Alice", "Bob", "Carl"); err != nil {
+		panic(err)
+	}
+	// Output:
+	// Deck:
+	//   [7h Ac 7d Js 4h 5h 9s Qh]
+	//   [2h Ah 2c 5s 6s Kh 4d 3c]
+	//   [Qs 6c 9h Jc Jd 2s 3s 6h]
+	//   [Ts Kd As 8h Qd 2d 5c Kc]
+	//   [6d 9d Jh Th 4c Ad 9c 8s]
+	//   [3d Qc 7s 4s Tc 8c 3h 5d]
+	//   [7c 8d Td Ks]
+	// Run 0:
+	//   p: Pre-Flop (p: 2)
+	//     0 Alice: [7h Js]
+	//     1 Bob: [Ac 4h]
+	//     2 Carl: [7d 5h]
+	//   f: Flop (d: 1, b: 3)
+	//     Discard: [9s]
+	//     Board: [Qh 2h Ah]
+	//   t: Turn (d: 1, b: 1)
+	//     Discard: [9s 2c]
+	//     Board: [Qh 2h Ah 5s]
+	//   r: River (d: 1, b: 1)
+	//     Discard: [9s 2c 6s]
+	//     Board: [Qh 2h Ah 5s Kh]
+	// Showdown:
+	//   Run 0:
+	//     0: [Ah Kh Qh 7h 2h] [Js 5s] Flush, Ace-high, kickers King, Queen, Seven, Two
+	//     1: [Ah Kh Qh 4h 2h] [Ac 5s] Flush, Ace-high, kickers King, Queen, Four, Two
+	//     2: [Ah Kh Qh 5h 2h] [7d 5s] Flush, Ace-high, kickers King, Queen, Five, Two
+	//     Result: Alice wins with Flush, Ace-high, kickers King, Queen, Seven, Two
+}
@@ -0,0 +1,99 @@
+package cardrank
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Insurance is all-in insurance for a position at risk of losing, where the
+// insured position pays a premium, and receives the premium multiplied by the
+// payout ratio when the position loses.
+type Insurance struct {
+	// Position is the insured position.
+	Position int
+	// Total is the total number of runouts for the position.
+	Total int
+	// Losses is the count of runouts where the position neither wins nor
+	// splits.
+	Losses int
+	// Outs are the cards that make another position win or split, and that
+	// the position does not win or split with.
+	Outs []Card
+	// Markup is the markup applied to the fair payout ratio (for example,
+	// 0.1 for a 10% markup).
+	Markup float64
+}
+
+// NewInsurance creates insurance for the position from the calculated odds,
+// applying the markup to the fair payout ratio. Each runout is counted once,
+// as either a win or split for the position (see [Odds.Counts]), or as a loss
+// (see [Odds.Losses]).
+func NewInsurance(odds *Odds, pos int, markup float64) *Insurance {
+	ins := &Insurance{
+		Position: pos,
+		Total:    odds.Counts[pos] + odds.Losses[pos],
+		Losses:   odds.Losses[pos],
+		Markup:   markup,
+	}
+	for i, outs := range odds.Outs {
+		if i == pos {
+			continue
+		}
+		for c := range outs {
+			if !odds.Outs[pos][c] && !slices.Contains(ins.Outs, c) {
+				ins.Outs = append(ins.Outs, c)
+			}
+		}
+	}
+	slices.SortFunc(ins.Outs, func(a, b Card) int {
+		return a.Index() - b.Index()
+	})
+	return ins
+}
+
+// Lose returns the probability of the position losing.
+func (ins *Insurance) Lose() float64 {
+	if ins.Total == 0 {
+		return 0
+	}
+	return float64(ins.Losses) / float64(ins.Total)
+}
+
+// FairRatio returns the fair payout ratio, without markup. Returns 0 when the
+// position cannot lose.
+func (ins *Insurance) FairRatio() float64 {
+	if ins.Losses == 0 {
+		return 0
+	}
+	return float64(ins.Total-ins.Losses) / float64(ins.Losses)
+}
+
+// Ratio returns the payout ratio, with markup. Returns 0 when the position
+// cannot lose.
+func (ins *Insurance) Ratio() float64 {
+	return ins.FairRatio() / (1 + ins.Markup)
+}
+
+// Premium returns the premium to insure amount, such that the premium
+// multiplied by the payout ratio equals the amount. Returns 0 when the
+// position cannot lose.
+func (ins *Insurance) Premium(amount float64) float64 {
+	if r := ins.Ratio(); r != 0 {
+		return amount / r
+	}
+	return 0
+}
+
+// Format satisfies the [fmt.Formatter] interface.
+func (ins *Insurance) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 's', 'v':
+		n := "outs"
+		if len(ins.Outs) == 1 {
+			n = "out"
+		}
+		fmt.Fprintf(f, "%d %s, %0.1f%% lose, %0.2f : 1", len(ins.Outs), n, 100*ins.Lose(), ins.Ratio())
+	default:
+		fmt.Fprintf(f, "%%!%c(ERROR=unknown verb, insurance)", verb)
+	}
+}
@@ -0,0 +1,51 @@
+package cardrank
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestInsurance(t *testing.T) {
+	tests := []struct {
+		pockets []string
+		board   string
+		markup  float64
+		outs    string
+		ratio   float64
+		premium float64
+		s       string
+	}{
+		{[]string{"Ah Ad", "Kh Kd"}, "2c 7s 9d 3h", 0, "Ks Kc", 21, 100.0 / 21, "2 outs, 4.5% lose, 21.00 : 1"},
+		{[]string{"Ah Ad", "Kh Kd"}, "2c 7s 9d 3h", 0.05, "Ks Kc", 20, 5, "2 outs, 4.5% lose, 20.00 : 1"},
+		{[]string{"Ah Kd", "Qh Jh"}, "2h 7h 9d 3c", 0.1, "Js Qs 3h 4h 5h 6h 8h 9h Th Kh Jd Qd Jc Qc", 30.0 / 14 / 1.1, 100 / (30.0 / 14 / 1.1), "14 outs, 31.8% lose, 1.95 : 1"},
+		{[]string{"Ah Ad", "Kh Kd"}, "2c 7s 9d Ac", 0, "", 0, 0, "0 outs, 0.0% lose, 0.00 : 1"},
+		{[]string{"Ah Ad", "Ac As"}, "2c 7d 9h 3s", 0, "", 0, 0, "0 outs, 0.0% lose, 0.00 : 1"},
+		{[]string{"Qh Jh", "Ah Kd", "Ad Kc"}, "Ts 9s 2c 3d", 0, "2s 3s 4s 5s 6s 7s As 2h 3h 4h 5h 6h 7h 9h Th 2d 4d 5d 6d 7d 9d Td 3c 4c 5c 6c 7c 9c Tc Ac", 0.4, 250, "30 outs, 71.4% lose, 0.40 : 1"},
+	}
+	for i, test := range tests {
+		var pockets [][]Card
+		for _, s := range test.pockets {
+			pockets = append(pockets, Must(s))
+		}
+		odds, _, ok := Holdem.Odds(context.Background(), pockets, Must(test.board))
+		if !ok {
+			t.Fatalf("test %d expected ok", i)
+		}
+		ins := NewInsurance(odds, 0, test.markup)
+		if exp := Must(test.outs); !slices.Equal(ins.Outs, exp) {
+			t.Errorf("test %d expected %v, got: %v", i, exp, ins.Outs)
+		}
+		if r := ins.Ratio(); math.Abs(r-test.ratio) > 1e-9 {
+			t.Errorf("test %d expected %f, got: %f", i, test.ratio, r)
+		}
+		if p := ins.Premium(100); math.Abs(p-test.premium) > 1e-9 {
+			t.Errorf("test %d expected %f, got: %f", i, test.premium, p)
+		}
+		if s := fmt.Sprintf("%s", ins); s != test.s {
+			t.Errorf("test %d expected %q, got: %q", i, test.s, s)
+		}
+	}
+}
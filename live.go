@@ -0,0 +1,104 @@
+package cardrank
+
+// Live are the live card counts for a pocket, where live cards are those not
+// in the pocket and not visible (such as the upcards of all other positions in
+// [Stud] or [Razz]). Useful for determining the strength of a pocket's draws.
+type Live struct {
+	// Cards are the live cards.
+	Cards []Card
+	// Ranks are the live rank counts, indexed by [Rank].
+	Ranks [13]int
+	// Suits are the live suit counts, indexed by [Suit.Index].
+	Suits  [4]int
+	pocket []Card
+}
+
+// NewLive creates the live card counts for the deck type, pocket, and visible
+// cards.
+func NewLive(typ DeckType, pocket []Card, visible ...[]Card) *Live {
+	l := &Live{
+		Cards:  typ.Exclude(append([][]Card{pocket}, visible...)...),
+		pocket: pocket,
+	}
+	for _, c := range l.Cards {
+		if c == Joker {
+			continue
+		}
+		l.Ranks[c.Rank()]++
+		l.Suits[c.Suit().Index()]++
+	}
+	return l
+}
+
+// Rank returns the live count for the rank.
+func (l *Live) Rank(rank Rank) int {
+	if Ace < rank {
+		return 0
+	}
+	return l.Ranks[rank]
+}
+
+// Suit returns the live count for the suit.
+func (l *Live) Suit(suit Suit) int {
+	return l.Suits[suit.Index()]
+}
+
+// Pairs returns the live count of cards pairing a card in the pocket.
+func (l *Live) Pairs() int {
+	var n int
+	var seen [13]bool
+	for _, c := range l.pocket {
+		if r := c.Rank(); r <= Ace && !seen[r] {
+			n, seen[r] = n+l.Ranks[r], true
+		}
+	}
+	return n
+}
+
+// Flush returns the pocket's best flush draw suit, the live count for the
+// suit, and the number of cards missing to make a [Flush]. When multiple suits
+// are missing the same number of cards, the suit having the most live cards
+// is returned.
+func (l *Live) Flush() (Suit, int, int) {
+	var counts [4]int
+	for _, c := range l.pocket {
+		if c != Joker {
+			counts[c.Suit().Index()]++
+		}
+	}
+	best := 0
+	for i := 1; i < 4; i++ {
+		if counts[best] < counts[i] || (counts[best] == counts[i] && l.Suits[best] < l.Suits[i]) {
+			best = i
+		}
+	}
+	return SuitFromIndex(best), l.Suits[best], max(5-counts[best], 0)
+}
+
+// Straight returns the pocket's best straight draw high rank, the live count
+// for the ranks missing from the straight, and the number of ranks missing to
+// make a [Straight]. [Ace]'s play both high and low. When multiple straights
+// are missing the same number of ranks, the straight having the most live
+// cards is returned.
+func (l *Live) Straight() (Rank, int, int) {
+	var have [13]bool
+	for _, c := range l.pocket {
+		if r := c.Rank(); r <= Ace {
+			have[r] = true
+		}
+	}
+	high, live, missing := InvalidRank, 0, 6
+	for h := Ace; Five <= h; h-- {
+		var n, m int
+		for i := range Rank(5) {
+			// wraps to ace for the five-high straight
+			if r := (h + 13 - i) % 13; !have[r] {
+				n, m = n+l.Ranks[r], m+1
+			}
+		}
+		if m < missing || (m == missing && live < n) {
+			high, live, missing = h, n, m
+		}
+	}
+	return high, live, missing
+}
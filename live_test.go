@@ -0,0 +1,49 @@
+package cardrank
+
+import (
+	"testing"
+)
+
+func TestLive(t *testing.T) {
+	pocket := Must("5h 6h 7h 9c")
+	visible := [][]Card{Must("8h 8c Kh"), Must("4d 2h As")}
+	l := NewLive(DeckFrench, pocket, visible...)
+	if n, exp := len(l.Cards), 52-10; n != exp {
+		t.Errorf("expected %d, got: %d", exp, n)
+	}
+	tests := []struct {
+		rank Rank
+		exp  int
+	}{
+		{Eight, 2},
+		{Five, 3},
+		{King, 3},
+		{Queen, 4},
+		{InvalidRank, 0},
+	}
+	for i, test := range tests {
+		if n := l.Rank(test.rank); n != test.exp {
+			t.Errorf("test %d expected %d, got: %d", i, test.exp, n)
+		}
+	}
+	if n, exp := l.Suit(Heart), 13-6; n != exp {
+		t.Errorf("expected %d, got: %d", exp, n)
+	}
+	if n, exp := l.Pairs(), 12; n != exp {
+		t.Errorf("expected %d, got: %d", exp, n)
+	}
+	suit, live, missing := l.Flush()
+	if suit != Heart || live != 7 || missing != 2 {
+		t.Errorf("expected %s 7 2, got: %s %d %d", Heart, suit, live, missing)
+	}
+	high, live, missing := l.Straight()
+	if high != Nine || live != 2 || missing != 1 {
+		t.Errorf("expected %s 2 1, got: %s %d %d", Nine, high, live, missing)
+	}
+	// five-high straight draw
+	l = NewLive(DeckFrench, Must("Ac 2c 3d 4s"), Must("5c 5d"))
+	high, live, missing = l.Straight()
+	if high != Five || live != 2 || missing != 1 {
+		t.Errorf("expected %s 2 1, got: %s %d %d", Five, high, live, missing)
+	}
+}
@@ -0,0 +1,62 @@
+package paycalc
+
+import "strings"
+
+// Flight is a starting flight of a multi-flight tournament, where the
+// entrants advancing from each flight merge on a later day.
+type Flight struct {
+	// Name is the flight name.
+	Name string
+	// Entries are the flight entries, including re-entries.
+	Entries int
+	// Unique are the flight's unique entrants.
+	Unique int
+	// Advanced are the entrants advancing from the flight.
+	Advanced int
+}
+
+// Advancement returns the proportion of the flight's entries advancing.
+func (f Flight) Advancement() float64 {
+	if f.Entries <= 0 {
+		return 0.0
+	}
+	return float64(f.Advanced) / float64(f.Entries)
+}
+
+// MergeFlights merges the entries, unique entrants, and advanced entrants of
+// the flights. The unique entrants are summed, and as such assumes no entrant
+// played more than one flight. See [UniqueEntrants] for determining unique
+// entrants across flights.
+func MergeFlights(flights ...Flight) Flight {
+	names := make([]string, len(flights))
+	var f Flight
+	for i, flight := range flights {
+		names[i] = flight.Name
+		f.Entries += flight.Entries
+		f.Unique += flight.Unique
+		f.Advanced += flight.Advanced
+	}
+	f.Name = strings.Join(names, "+")
+	return f
+}
+
+// UniqueEntrants returns the count of unique entrants across the entrants of
+// each flight.
+func UniqueEntrants(flights ...[]string) int {
+	m := make(map[string]bool)
+	for _, v := range flights {
+		for _, id := range v {
+			m[id] = true
+		}
+	}
+	return len(m)
+}
+
+// Advancement returns the proportion of each flight's entries advancing.
+func Advancement(flights ...Flight) []float64 {
+	v := make([]float64, len(flights))
+	for i, f := range flights {
+		v[i] = f.Advancement()
+	}
+	return v
+}
@@ -0,0 +1,105 @@
+package paycalc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Generate generates a tournament payout table for the entries columns
+// (which must be ordered high to low), paying the top proportion of entries. Each paid
+// ranking n is weighted as 1/n^decay, with larger decay values producing
+// steeper payouts (0 pays each ranking equally), and every paid ranking
+// receives at least the minimum cash as a multiple of the buyin.
+//
+// Paid rankings are grouped into levels using [GenerateLevels].
+func Generate(name string, top, decay, minCash float64, entries ...int) (*Table, error) {
+	switch {
+	case len(entries) == 0:
+		return nil, errors.New("invalid table")
+	case top <= 0.0 || 1.0 < top:
+		return nil, fmt.Errorf("invalid top %f", top)
+	case decay < 0.0:
+		return nil, fmt.Errorf("invalid decay %f", decay)
+	case minCash < 0.0:
+		return nil, fmt.Errorf("invalid min cash %f", minCash)
+	}
+	// check entries
+	for i := range entries {
+		switch {
+		case entries[i] <= 0:
+			return nil, fmt.Errorf("invalid entries: col %d value %d must be > 0", i, entries[i])
+		case 0 < i && entries[i-1] <= entries[i]:
+			return nil, fmt.Errorf("entries are not ordered high to low: col %d value %d must be < %d", i, entries[i], entries[i-1])
+		}
+	}
+	levels := GenerateLevels(Paid(top, entries[0]))
+	amounts := make([][]float64, len(levels))
+	for i := range levels {
+		amounts[i] = make([]float64, len(entries))
+	}
+	for j, n := range entries {
+		paid := Paid(top, n)
+		v, err := generateAmounts(paid, n, decay, minCash)
+		if err != nil {
+			return nil, fmt.Errorf("entries %d: %w", n, err)
+		}
+		// each ranking of a level is paid the level's average, with the
+		// unpaid rankings of the last paid level being unallocated
+		for i, last := 0, 0; i < len(levels) && last < paid; i++ {
+			var sum float64
+			for _, f := range v[last:min(levels[i], paid)] {
+				sum += f
+			}
+			amounts[i][j] = sum / float64(levels[i]-last)
+			last = levels[i]
+		}
+	}
+	return New(name, top, levels, entries, amounts)
+}
+
+// GenerateLevels generates the levels for the paid rankings. The 1st through
+// 9th rankings are individual levels, followed by levels of 5 rankings up to
+// the 50th, 25 rankings up to the 300th, 50 rankings up to the 1000th, and
+// 100 rankings after.
+func GenerateLevels(paid int) []int {
+	var levels []int
+	for level := 1; ; {
+		levels = append(levels, level)
+		if paid <= level {
+			return levels
+		}
+		switch {
+		case level < 10:
+			level++
+		case level < 50:
+			level += 5
+		case level < 300:
+			level += 25
+		case level < 1000:
+			level += 50
+		default:
+			level += 100
+		}
+	}
+}
+
+// generateAmounts generates the proportion of the prize pool paid to each of
+// the paid rankings for the entries.
+func generateAmounts(paid, entries int, decay, minCash float64) ([]float64, error) {
+	floor := minCash / float64(entries)
+	rem := 1.0 - floor*float64(paid)
+	if rem < 0.0 {
+		return nil, fmt.Errorf("min cash %f exceeds prize pool for %d paid", minCash, paid)
+	}
+	v := make([]float64, paid)
+	var sum float64
+	for i := range paid {
+		v[i] = math.Pow(float64(i+1), -decay)
+		sum += v[i]
+	}
+	for i := range paid {
+		v[i] = floor + rem*v[i]/sum
+	}
+	return v, nil
+}
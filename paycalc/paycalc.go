@@ -6,6 +6,7 @@ import (
 	_ "embed"
 	"fmt"
 	"io"
+	"iter"
 	"math"
 	"strconv"
 	"strings"
@@ -215,6 +216,25 @@ func (typ Type) Payouts(entries int, buyin, guaranteed int64, rake float64) ([]i
 	return nil, 0
 }
 
+// OverlayPayouts returns the overlay calculation (see [CalcOverlay]) and the
+// tournament payouts and total paid for the entries, buyin, fee, and
+// guaranteed prize pool.
+func (typ Type) OverlayPayouts(entries int, buyin, fee, guaranteed int64) (Overlay, []int64, int64) {
+	if t, ok := tables[typ]; ok {
+		return t.OverlayPayouts(entries, buyin, fee, guaranteed)
+	}
+	return CalcOverlay(entries, buyin, fee, guaranteed), nil, 0
+}
+
+// FlightPayouts returns the tournament payouts for a multi-flight tournament,
+// where the flight is the merged flights (see [MergeFlights]).
+func (typ Type) FlightPayouts(flight Flight, buyin, guaranteed int64, rake float64) ([]int64, int64) {
+	if t, ok := tables[typ]; ok {
+		return t.FlightPayouts(flight, buyin, guaranteed, rake)
+	}
+	return nil, 0
+}
+
 // Amount returns the amount for the level and entries from the tournament
 // payout table.
 func (typ Type) Amount(level, entries int) float64 {
@@ -224,6 +244,24 @@ func (typ Type) Amount(level, entries int) float64 {
 	return 0.0
 }
 
+// AmountFor returns the amount of the prize pool paid to the place (1-based)
+// for the entries, scaled by the unallocated amount.
+func (typ Type) AmountFor(place, entries int, prizePool float64) float64 {
+	if t, ok := tables[typ]; ok {
+		return t.AmountFor(place, entries, prizePool)
+	}
+	return 0.0
+}
+
+// Rows returns an iterator over the paid levels as [low, high) and the amount
+// of the prize pool paid to each place of the level for the entries.
+func (typ Type) Rows(entries int, prizePool float64) iter.Seq2[[2]int, float64] {
+	if t, ok := tables[typ]; ok {
+		return t.Rows(entries, prizePool)
+	}
+	return func(func([2]int, float64) bool) {}
+}
+
 // At returns the amount at row, col of the tournament payout table.
 func (typ Type) At(row, col int) float64 {
 	if t, ok := tables[typ]; ok {
@@ -293,6 +331,46 @@ func Prize(entries int, buyin, guaranteed int64, rake float64) int64 {
 	return max(guaranteed, amt-int64(Round(rake*float64(amt))))
 }
 
+// Overlay is a guaranteed prize pool calculation, where the buyin of each
+// entry is added to the prize pool and the fee is retained by the house (ie,
+// a $100+$10 tournament has a buyin of 100 and a fee of 10).
+type Overlay struct {
+	// Entries are the entries.
+	Entries int
+	// Guaranteed is the guaranteed prize pool.
+	Guaranteed int64
+	// Collected is the total of the buyins collected.
+	Collected int64
+	// Fees is the total of the fees collected.
+	Fees int64
+	// Prize is the prize pool, the greater of the guaranteed and collected.
+	Prize int64
+	// Overlay is the amount of the guaranteed prize pool not covered by the
+	// collected buyins.
+	Overlay int64
+	// Needed is the entries needed for the collected buyins to cover the
+	// guaranteed prize pool.
+	Needed int
+}
+
+// CalcOverlay calculates the prize pool and overlay for the entries, buyin,
+// fee, and guaranteed prize pool.
+func CalcOverlay(entries int, buyin, fee, guaranteed int64) Overlay {
+	collected := int64(entries) * buyin
+	o := Overlay{
+		Entries:    entries,
+		Guaranteed: guaranteed,
+		Collected:  collected,
+		Fees:       int64(entries) * fee,
+		Prize:      max(guaranteed, collected),
+		Overlay:    max(guaranteed-collected, 0),
+	}
+	if 0 < buyin {
+		o.Needed = int((guaranteed + buyin - 1) / buyin)
+	}
+	return o
+}
+
 // Calc calculates the amount scaled by unallocated. Uses [Round] to round to
 // [Precision].
 func Calc(f float64, total int64, unallocated float64) int64 {
@@ -149,3 +149,124 @@ func init() {
 
 //go:embed simple.csv
 var simpl []byte
+
+func TestTracker(t *testing.T) {
+	tbl, err := LoadBytes("top15", 0.15, top15)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	tr := NewTracker(tbl, 100, 0, 0.0, 5)
+	tests := []struct {
+		entries int
+		changed bool
+		paid    int
+		column  bool
+	}{
+		{98, true, 15, true},
+		{98, false, 15, false},
+		{100, true, 15, false},
+		{102, true, 16, true},
+		{98, false, 16, false},
+		{97, true, 15, true},
+		{1, false, 15, false},
+	}
+	for i, test := range tests {
+		diff := tr.Update(test.entries)
+		switch {
+		case (diff != nil) != test.changed:
+			t.Fatalf("test %d expected changed %t, got: %v", i, test.changed, diff)
+		case diff != nil && diff.ColumnChanged != test.column:
+			t.Errorf("test %d expected column changed %t, got: %t", i, test.column, diff.ColumnChanged)
+		case diff != nil && len(diff.Changes) == 0:
+			t.Errorf("test %d expected changes", i)
+		}
+		if payouts, total := tr.Payouts(); len(payouts) != test.paid || !EqualEpsilon(total, tr.Entries()*100, tr.Entries()) {
+			t.Errorf("test %d expected %d paid, got: %d (%d)", i, test.paid, len(payouts), total)
+		}
+	}
+}
+
+func TestAmountForRows(t *testing.T) {
+	for _, typ := range []Type{Top10, Top15, Top20} {
+		for _, entries := range []int{2, 14, 158, 1234} {
+			prize := float64(entries * 100)
+			payouts, _ := typ.Payouts(entries, 100, 0, 0.0)
+			for i, exp := range payouts {
+				if amt := typ.AmountFor(i+1, entries, prize); !EqualEpsilon(amt, exp, 1) {
+					t.Errorf("%s %d place %d expected %d, got: %f", typ.Name(), entries, i+1, exp, amt)
+				}
+			}
+			if amt := typ.AmountFor(len(payouts)+1, entries, prize); amt != 0.0 {
+				t.Errorf("%s %d expected 0, got: %f", typ.Name(), entries, amt)
+			}
+			var sum float64
+			var last int
+			for r, amt := range typ.Rows(entries, prize) {
+				if r[0] != last {
+					t.Errorf("%s %d expected %d, got: %d", typ.Name(), entries, last, r[0])
+				}
+				sum, last = sum+float64(r[1]-r[0])*amt, r[1]
+			}
+			if last != len(payouts) || !EqualEpsilon(sum, prize, 0.0001) {
+				t.Errorf("%s %d expected %d/%f, got: %d/%f", typ.Name(), entries, len(payouts), prize, last, sum)
+			}
+		}
+	}
+}
+
+func TestOverlay(t *testing.T) {
+	tests := []struct {
+		entries    int
+		guaranteed int64
+		prize      int64
+		overlay    int64
+		needed     int
+	}{
+		{150, 20000, 20000, 5000, 200},
+		{200, 20000, 20000, 0, 200},
+		{250, 20000, 25000, 0, 200},
+		{150, 0, 15000, 0, 0},
+	}
+	for i, test := range tests {
+		o, payouts, total := Top15.OverlayPayouts(test.entries, 100, 10, test.guaranteed)
+		switch {
+		case o.Prize != test.prize:
+			t.Errorf("test %d expected prize %d, got: %d", i, test.prize, o.Prize)
+		case o.Overlay != test.overlay:
+			t.Errorf("test %d expected overlay %d, got: %d", i, test.overlay, o.Overlay)
+		case o.Needed != test.needed:
+			t.Errorf("test %d expected needed %d, got: %d", i, test.needed, o.Needed)
+		case o.Fees != int64(test.entries*10):
+			t.Errorf("test %d expected fees %d, got: %d", i, test.entries*10, o.Fees)
+		case len(payouts) == 0 || !EqualEpsilon(total, o.Prize, len(payouts)):
+			t.Errorf("test %d expected total %d, got: %d", i, o.Prize, total)
+		}
+	}
+}
+
+func TestFlights(t *testing.T) {
+	a := Flight{Name: "1A", Entries: 120, Unique: 100, Advanced: 18}
+	b := Flight{Name: "1B", Entries: 90, Unique: 80, Advanced: 12}
+	f := MergeFlights(a, b)
+	if f.Name != "1A+1B" || f.Entries != 210 || f.Unique != 180 || f.Advanced != 30 {
+		t.Fatalf("expected 1A+1B 210 180 30, got: %s %d %d %d", f.Name, f.Entries, f.Unique, f.Advanced)
+	}
+	if v := Advancement(a, b); !Equal(v[0], 0.15) || !Equal(v[1], 12.0/90.0) {
+		t.Errorf("expected [0.15 %f], got: %v", 12.0/90.0, v)
+	}
+	if n := UniqueEntrants([]string{"a", "b", "c", "a"}, []string{"c", "d"}); n != 4 {
+		t.Errorf("expected 4, got: %d", n)
+	}
+	payouts, total := Top15.FlightPayouts(f, 100, 0, 0.0)
+	if paid, _, _ := Top15.Paid(f.Unique); len(payouts) != paid {
+		t.Errorf("expected %d, got: %d", paid, len(payouts))
+	}
+	if !EqualEpsilon(total, 21000, len(payouts)) {
+		t.Errorf("expected %d, got: %d", 21000, total)
+	}
+	for _, f := range []Flight{{}, {Entries: 5, Unique: 1}} {
+		if payouts, total := Top15.FlightPayouts(f, 100, 0, 0.0); len(payouts) != 0 || total != 0 {
+			t.Errorf("expected no payouts, got: %v %d", payouts, total)
+		}
+	}
+}
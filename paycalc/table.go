@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"iter"
 	"math"
 	"strconv"
 	"strings"
@@ -349,8 +350,18 @@ func (t *Table) Unallocated(paid, row, col int) float64 {
 // Stakes returns the paid levels as [low, high), the tournament table value
 // per level, the calculated payouts per level, and the total amount paid.
 func (t *Table) Stakes(entries int, buyin, guaranteed int64, rake float64) ([][2]int, []float64, []int64, int64) {
+	return t.stakes(entries, Prize(entries, buyin, guaranteed, rake))
+}
+
+// stakes returns the paid levels for the entries, the tournament table value
+// per level, the calculated payouts per level of the prize, and the total
+// amount paid. Returns no levels when no rankings are paid.
+func (t *Table) stakes(entries int, prize int64) ([][2]int, []float64, []int64, int64) {
 	paid, row, col := t.Paid(entries)
-	prize, unallocated := Prize(entries, buyin, guaranteed, rake), t.Unallocated(paid, row, col)
+	if paid <= 0 || row < 0 || col < 0 {
+		return nil, nil, nil, 0
+	}
+	unallocated := t.Unallocated(paid, row, col)
 	levels, amounts, payouts, total := make([][2]int, row+1), make([]float64, row+1), make([]int64, row+1), int64(0)
 	for i, last := 0, 0; i <= row; i++ {
 		level := min(t.levels[i], paid)
@@ -366,13 +377,25 @@ func (t *Table) Stakes(entries int, buyin, guaranteed int64, rake float64) ([][2
 // on the number of entries.
 func (t *Table) Payouts(entries int, buyin, guaranteed int64, rake float64) ([]int64, int64) {
 	levels, _, amounts, total := t.Stakes(entries, buyin, guaranteed, rake)
-	payouts := make([]int64, 0, levels[len(levels)-1][1])
-	for i, level := range levels {
-		for j := level[0]; j < level[1]; j++ {
-			payouts = append(payouts, amounts[i])
-		}
-	}
-	return payouts, total
+	return expand(levels, amounts), total
+}
+
+// FlightPayouts returns the tournament payouts for a multi-flight tournament,
+// where the flight is the merged flights (see [MergeFlights]). The prize is
+// based on the flight's entries, and the paid rankings are based on the
+// flight's unique entrants.
+func (t *Table) FlightPayouts(flight Flight, buyin, guaranteed int64, rake float64) ([]int64, int64) {
+	levels, _, amounts, total := t.stakes(flight.Unique, Prize(flight.Entries, buyin, guaranteed, rake))
+	return expand(levels, amounts), total
+}
+
+// OverlayPayouts returns the overlay calculation (see [CalcOverlay]) and the
+// tournament payouts and total paid for the entries, buyin, fee, and
+// guaranteed prize pool.
+func (t *Table) OverlayPayouts(entries int, buyin, fee, guaranteed int64) (Overlay, []int64, int64) {
+	o := CalcOverlay(entries, buyin, fee, guaranteed)
+	payouts, total := t.Payouts(entries, buyin, guaranteed, 0.0)
+	return o, payouts, total
 }
 
 // Amount returns the amount for the level and entries from the tournament
@@ -389,6 +412,51 @@ func (t *Table) At(row, col int) float64 {
 	return 0.0
 }
 
+// AmountFor returns the amount of the prize pool paid to the place (1-based)
+// for the entries, scaled by the unallocated amount. Returns 0 when the place
+// is not paid.
+func (t *Table) AmountFor(place, entries int, prizePool float64) float64 {
+	paid, row, col := t.Paid(entries)
+	if place < 1 || paid < place {
+		return 0.0
+	}
+	return t.At(t.Level(place-1), col) * prizePool / (1.0 - t.Unallocated(paid, row, col))
+}
+
+// Rows returns an iterator over the paid levels as [low, high) and the amount
+// of the prize pool paid to each place of the level for the entries (see
+// [Table.AmountFor]).
+func (t *Table) Rows(entries int, prizePool float64) iter.Seq2[[2]int, float64] {
+	return func(yield func([2]int, float64) bool) {
+		paid, row, col := t.Paid(entries)
+		if row < 0 || col < 0 {
+			return
+		}
+		scale := prizePool / (1.0 - t.Unallocated(paid, row, col))
+		for i, last := 0, 0; i <= row; i++ {
+			level := min(t.levels[i], paid)
+			if !yield([2]int{last, level}, t.amounts[i][col]*scale) {
+				return
+			}
+			last = level
+		}
+	}
+}
+
+// expand expands the payouts for each of the levels.
+func expand(levels [][2]int, amounts []int64) []int64 {
+	if len(levels) == 0 {
+		return nil
+	}
+	payouts := make([]int64, 0, levels[len(levels)-1][1])
+	for i, level := range levels {
+		for j := level[0]; j < level[1]; j++ {
+			payouts = append(payouts, amounts[i])
+		}
+	}
+	return payouts
+}
+
 // tableFormat formats v in table format.
 func tableFormat(v any, n int, last bool) string {
 	var s string
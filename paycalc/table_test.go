@@ -100,3 +100,37 @@ func TestPaidUnallocated(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerate(t *testing.T) {
+	tbl, err := Generate("gen", 0.15, 1.0, 1.5, 1000, 500, 100, 10)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	for _, entries := range []int{1000, 700, 100, 60, 10} {
+		payouts, total := tbl.Payouts(entries, 100, 0, 0.0)
+		if exp := int64(entries * 100); !EqualEpsilon(total, exp, len(payouts)) {
+			t.Errorf("entries %d expected total %d, got: %d", entries, exp, total)
+		}
+		for i, payout := range payouts {
+			switch {
+			case payout < 150:
+				t.Errorf("entries %d ranking %d expected at least %d, got: %d", entries, i+1, 150, payout)
+			case 0 < i && payouts[i-1] < payout:
+				t.Errorf("entries %d ranking %d expected at most %d, got: %d", entries, i+1, payouts[i-1], payout)
+			}
+		}
+	}
+	if _, err := Generate("gen", 0.5, 1.0, 3.0, 100); err == nil {
+		t.Errorf("expected error")
+	}
+	for i, entries := range [][]int{
+		{100, 500},
+		{500, 500},
+		{500, 100, 200},
+		{100, 0},
+	} {
+		if _, err := Generate("gen", 0.15, 1.0, 1.5, entries...); err == nil {
+			t.Errorf("test %d expected error", i)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package paycalc
+
+// Tracker tracks the published payouts of a tournament payout table as the
+// entries change, such as when re-entries arrive during late registration.
+type Tracker struct {
+	t          *Table
+	buyin      int64
+	guaranteed int64
+	rake       float64
+	hysteresis int
+	entries    int
+	col        int
+	payouts    []int64
+	total      int64
+}
+
+// NewTracker creates a new payout tracker for the tournament payout table.
+//
+// Increases in entries are always published. Decreases in entries are only
+// published once the entries drop by at least the hysteresis from the
+// published entries, so that published payouts do not bounce when entries
+// are removed and re-added.
+func NewTracker(t *Table, buyin, guaranteed int64, rake float64, hysteresis int) *Tracker {
+	return &Tracker{
+		t:          t,
+		buyin:      buyin,
+		guaranteed: guaranteed,
+		rake:       rake,
+		hysteresis: max(hysteresis, 0),
+		col:        -1,
+	}
+}
+
+// Entries returns the published entries.
+func (tr *Tracker) Entries() int {
+	return tr.entries
+}
+
+// Payouts returns the published payouts for each paid ranking, and the total
+// amount paid.
+func (tr *Tracker) Payouts() ([]int64, int64) {
+	return tr.payouts, tr.total
+}
+
+// Update updates the entries, returning the diff of the published payouts.
+// Returns nil when the published payouts are not changed.
+func (tr *Tracker) Update(entries int) *Diff {
+	switch {
+	case entries < 2 || entries == tr.entries,
+		entries < tr.entries && tr.entries-entries < tr.hysteresis:
+		return nil
+	}
+	payouts, total := tr.t.Payouts(entries, tr.buyin, tr.guaranteed, tr.rake)
+	col := tr.t.Entries(entries)
+	diff := &Diff{
+		OldEntries:    tr.entries,
+		NewEntries:    entries,
+		OldPaid:       len(tr.payouts),
+		NewPaid:       len(payouts),
+		OldTotal:      tr.total,
+		NewTotal:      total,
+		ColumnChanged: col != tr.col,
+	}
+	for i := range max(len(payouts), len(tr.payouts)) {
+		var prev, next int64
+		if i < len(tr.payouts) {
+			prev = tr.payouts[i]
+		}
+		if i < len(payouts) {
+			next = payouts[i]
+		}
+		if prev != next {
+			diff.Changes = append(diff.Changes, Change{
+				Ranking: i + 1,
+				Old:     prev,
+				New:     next,
+			})
+		}
+	}
+	tr.entries, tr.col, tr.payouts, tr.total = entries, col, payouts, total
+	return diff
+}
+
+// Diff is the difference between published payouts.
+type Diff struct {
+	// OldEntries are the previously published entries.
+	OldEntries int
+	// NewEntries are the published entries.
+	NewEntries int
+	// OldPaid is the previously published count of paid rankings.
+	OldPaid int
+	// NewPaid is the published count of paid rankings.
+	NewPaid int
+	// OldTotal is the previously published total amount paid.
+	OldTotal int64
+	// NewTotal is the published total amount paid.
+	NewTotal int64
+	// ColumnChanged is true when the entries crossed a column boundary of the
+	// tournament payout table.
+	ColumnChanged bool
+	// Changes are the changed payouts.
+	Changes []Change
+}
+
+// Change is a changed payout for a paid ranking.
+type Change struct {
+	// Ranking is the ranking (1-based).
+	Ranking int
+	// Old is the previously published payout, 0 when not previously paid.
+	Old int64
+	// New is the published payout, 0 when no longer paid.
+	New int64
+}
@@ -0,0 +1,139 @@
+package cardrank
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rotation is a mixed game rotation, where each game of an ordered mix is dealt
+// for a number of hands before rotating to the next game.
+type Rotation struct {
+	// Name is the rotation name.
+	Name string
+	// Games are the ordered games.
+	Games []RotationGame
+	// Orbit is the count of hands in an orbit (ie, the count of players),
+	// used for games without a hand count.
+	Orbit int
+
+	game int
+	hand int
+}
+
+// RotationGame is a game in a rotation.
+type RotationGame struct {
+	// Type is the game type.
+	Type Type
+	// Hands is the count of hands dealt before rotating to the next game.
+	// When 0, the game is dealt for an orbit (see [Rotation.Orbit]).
+	Hands int
+}
+
+// NewRotation creates a new rotation of the games.
+func NewRotation(name string, games ...RotationGame) *Rotation {
+	return &Rotation{
+		Name:  name,
+		Games: games,
+	}
+}
+
+// NewRotationOf creates a new rotation of the types, with each type dealt for
+// the same count of hands. When hands is 0, each type is dealt for an orbit.
+func NewRotationOf(name string, hands int, types ...Type) *Rotation {
+	games := make([]RotationGame, len(types))
+	for i, typ := range types {
+		games[i] = RotationGame{
+			Type:  typ,
+			Hands: hands,
+		}
+	}
+	return NewRotation(name, games...)
+}
+
+// HORSE creates a HORSE rotation of [Holdem], [OmahaHiLo], [Razz], [Stud],
+// and [StudHiLo], with each type dealt for the count of hands.
+func HORSE(hands int) *Rotation {
+	return NewRotationOf("HORSE", hands, Holdem, OmahaHiLo, Razz, Stud, StudHiLo)
+}
+
+// EightGame creates a 8-Game rotation of [LowballTriple], [Holdem],
+// [OmahaHiLo], [Razz], [Stud], [StudHiLo], [Holdem], and [Omaha], with each
+// type dealt for the count of hands.
+//
+// The limit and no-limit [Holdem], and the pot-limit [Omaha] are
+// distinguished only by betting structure, which is not tracked by the
+// rotation.
+func EightGame(hands int) *Rotation {
+	return NewRotationOf("8-Game", hands, LowballTriple, Holdem, OmahaHiLo, Razz, Stud, StudHiLo, Holdem, Omaha)
+}
+
+// Next advances the rotation to the next hand, returning the type to deal.
+// Returns 0 when the rotation has no games.
+func (r *Rotation) Next() Type {
+	if len(r.Games) == 0 {
+		return 0
+	}
+	if r.limit(r.game) <= r.hand {
+		r.game, r.hand = (r.game+1)%len(r.Games), 0
+	}
+	r.hand++
+	return r.Games[r.game].Type
+}
+
+// Current returns the current type, or 0 when the rotation has no games.
+func (r *Rotation) Current() Type {
+	if len(r.Games) == 0 {
+		return 0
+	}
+	return r.Games[r.game].Type
+}
+
+// Game returns the index of the current game.
+func (r *Rotation) Game() int {
+	return r.game
+}
+
+// Hand returns the count of hands dealt for the current game.
+func (r *Rotation) Hand() int {
+	return r.hand
+}
+
+// Remaining returns the count of hands remaining for the current game.
+func (r *Rotation) Remaining() int {
+	if len(r.Games) == 0 {
+		return 0
+	}
+	return r.limit(r.game) - r.hand
+}
+
+// Reset resets the rotation to the first game.
+func (r *Rotation) Reset() {
+	r.game, r.hand = 0, 0
+}
+
+// limit returns the count of hands for the game.
+func (r *Rotation) limit(game int) int {
+	switch {
+	case 0 < r.Games[game].Hands:
+		return r.Games[game].Hands
+	case 0 < r.Orbit:
+		return r.Orbit
+	}
+	return 1
+}
+
+// Format satisfies the [fmt.Formatter] interface.
+func (r *Rotation) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 's':
+		fmt.Fprint(f, r.Name)
+	case 'v':
+		v := make([]string, len(r.Games))
+		for i, g := range r.Games {
+			v[i] = g.Type.Name()
+		}
+		fmt.Fprintf(f, "%s (%s)", r.Name, strings.Join(v, ", "))
+	default:
+		fmt.Fprintf(f, "%%!%c(ERROR=unknown verb, rotation)", verb)
+	}
+}
@@ -0,0 +1,48 @@
+package cardrank
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRotation(t *testing.T) {
+	r := HORSE(2)
+	exp := []Type{Holdem, Holdem, OmahaHiLo, OmahaHiLo, Razz, Razz, Stud, Stud, StudHiLo, StudHiLo, Holdem}
+	for i, typ := range exp {
+		if next := r.Next(); next != typ {
+			t.Errorf("test %d expected %s, got: %s", i, typ, next)
+		}
+	}
+	if n := r.Remaining(); n != 1 {
+		t.Errorf("expected %d, got: %d", 1, n)
+	}
+	r.Reset()
+	if typ := r.Next(); typ != Holdem || r.Game() != 0 || r.Hand() != 1 {
+		t.Errorf("expected %s 0 1, got: %s %d %d", Holdem, typ, r.Game(), r.Hand())
+	}
+	// orbit
+	r = EightGame(0)
+	r.Orbit = 3
+	for i := range 3 {
+		if typ := r.Next(); typ != LowballTriple {
+			t.Errorf("test %d expected %s, got: %s", i, LowballTriple, typ)
+		}
+	}
+	if typ := r.Next(); typ != Holdem || r.Current() != Holdem {
+		t.Errorf("expected %s, got: %s", Holdem, typ)
+	}
+	// mixed hand counts
+	r = NewRotation("Mix", RotationGame{Type: Badugi, Hands: 1}, RotationGame{Type: Razz, Hands: 3})
+	exp = []Type{Badugi, Razz, Razz, Razz, Badugi}
+	for i, typ := range exp {
+		if next := r.Next(); next != typ {
+			t.Errorf("test %d expected %s, got: %s", i, typ, next)
+		}
+	}
+	if s, exp := fmt.Sprintf("%v", r), "Mix (Badugi, Razz)"; s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+	if typ := NewRotation("Empty").Next(); typ != 0 {
+		t.Errorf("expected 0, got: %d", typ)
+	}
+}
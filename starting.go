@@ -0,0 +1,81 @@
+//go:build !tiny
+
+package cardrank
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// starting is the embedded starting pocket data.
+//
+//go:embed starting.csv
+var starting []byte
+
+func init() {
+	var err error
+	if startingExpValue, startingCactus, err = holdemStarting(); err != nil {
+		panic(err)
+	}
+}
+
+// startingCactusRank returns the starting cactus rank for the 2 card pocket.
+func startingCactusRank(c0, c1 Card) EvalRank {
+	return startingCactus[HashKey(c0, c1)]
+}
+
+// HoldemStarting returns the starting Holdem pockets.
+func HoldemStarting() (map[string]ExpValue, map[string]EvalRank) {
+	m, v, err := holdemStarting()
+	if err != nil {
+		panic(fmt.Sprintf("unable to load starting pockets: %v", err))
+	}
+	return m, v
+}
+
+// holdemStarting returns the starting Holdem pockets.
+func holdemStarting() (map[string]ExpValue, map[string]EvalRank, error) {
+	r := csv.NewReader(bytes.NewReader(starting))
+	r.FieldsPerRecord = 6
+	lines, err := r.ReadAll()
+	switch {
+	case err != nil:
+		return nil, nil, fmt.Errorf("unable to read starting pockets: %w", err)
+	case len(lines) != 170:
+		return nil, nil, fmt.Errorf("invalid starting pocket length %d", len(lines))
+	}
+	re := regexp.MustCompile(`^[2-9AKQJT]{2}[os]?$`)
+	m, v := make(map[string]ExpValue), make(map[string]EvalRank)
+	for i, line := range lines[1:] {
+		if !re.MatchString(line[0]) {
+			return nil, nil, fmt.Errorf("line %d: invalid key %q", i+1, line[0])
+		}
+		w, _ := strconv.ParseUint(line[1], 10, 64)
+		s, _ := strconv.ParseUint(line[2], 10, 64)
+		l, _ := strconv.ParseUint(line[3], 10, 64)
+		if w+s+l != startingTotal {
+			return nil, nil, fmt.Errorf("line %d: wins, splits, losses do not total %d: %d + %d + %d", i+1, startingTotal, w, s, l)
+		}
+		expv := ExpValue{
+			Opponents: 1,
+			Wins:      w,
+			Splits:    s,
+			Losses:    l,
+			Total:     startingTotal,
+		}
+		if fmt.Sprintf("%f", expv.Float64()) != line[4] {
+			return nil, nil, fmt.Errorf("line %d: calculated %f does not equal %s", i+1, expv.Float64(), line[4])
+		}
+		// parse cactus
+		r, _ := strconv.ParseUint(line[5], 10, 64)
+		if r == 0 || uint64(Nothing) < r {
+			return nil, nil, fmt.Errorf("line %d: invalid cactus rank parsed from %q", i+1, line[5])
+		}
+		m[line[0]], v[line[0]] = expv, EvalRank(r)
+	}
+	return m, v, nil
+}
@@ -0,0 +1,64 @@
+//go:build !tiny
+
+package cardrank
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCalcStartingCactus(t *testing.T) {
+	for r0 := Ace; r0 != InvalidRank; r0-- {
+		for r1 := r0; r1 != InvalidRank; r1-- {
+			for _, s1 := range []Suit{Spade, Heart} {
+				c0, c1 := New(r0, Spade), New(r1, s1)
+				if c0 == c1 {
+					continue
+				}
+				exp := startingCactus[HashKey(c0, c1)]
+				if r := calcStartingCactus(c0, c1); r != exp {
+					t.Errorf("%s %s expected %d, got: %d", c0, c1, exp, r)
+				}
+			}
+		}
+	}
+}
+
+func TestStartingExpValueOmaha(t *testing.T) {
+	expv, ok := Omaha.ExpValue(context.Background(), Must("Ah Kh 9s Jd"))
+	if !ok {
+		t.Fatalf("expected ok")
+	}
+	t.Logf("%v", expv)
+}
+
+func TestRunCalcStart(t *testing.T) {
+	tests := []struct {
+		pockets []string
+	}{
+		{[]string{"Ah Kh", "7c 2d"}},
+		{[]string{"Ah Kh 9s Jd", "7c 2d 3s 8h"}},
+		{[]string{"Ah Kh 9s Jd Tc", "7c 2d 3s 8h 4d"}},
+		{[]string{"Ah Kh 9s Jd Tc Qs", "7c 2d 3s 8h 4d 5c"}},
+	}
+	for i, test := range tests {
+		run := NewRun(len(test.pockets))
+		for j, s := range test.pockets {
+			run.Pockets[j] = Must(s)
+		}
+		hi, lo := run.CalcStart(true)
+		if hi == nil || lo == nil {
+			t.Fatalf("test %d expected non-nil odds", i)
+		}
+		if !hi.Estimate || !lo.Estimate {
+			t.Errorf("test %d expected estimate", i)
+		}
+		for j, pocket := range run.Pockets {
+			expv := StartingExpValue(pocket)
+			exp := float64(expv.Wins+expv.Losses) / float64(expv.Total) * 100
+			if p := float64(hi.Percent(j)); p < exp-0.01 || exp+0.01 < p || 100 < p {
+				t.Errorf("test %d %d expected %f, got: %f", i, j, exp, p)
+			}
+		}
+	}
+}
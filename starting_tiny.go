@@ -0,0 +1,12 @@
+//go:build tiny
+
+package cardrank
+
+// startingCactusRank returns the starting cactus rank for the 2 card pocket,
+// calculated on demand as the starting pocket data is not embedded when built
+// with the [tiny] build tag.
+//
+// [tiny]: https://pkg.go.dev/github.com/cardrank/cardrank#readme-tiny
+func startingCactusRank(c0, c1 Card) EvalRank {
+	return calcStartingCactus(c0, c1)
+}
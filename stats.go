@@ -0,0 +1,202 @@
+package cardrank
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+)
+
+// Stats accumulates statistics for evals and results of a type, such as the
+// frequency of each hand category, the best hand encountered, and coolers
+// (ie, when a strong hand loses to a stronger hand).
+//
+// Hand categories are only accumulated for types with a Cactus eval (see
+// [Type.Cactus]), and are the category title of the eval's hi rank (see
+// [EvalRank.Title]), or "Four Flush" and "Four Straight" for [Soko].
+type Stats struct {
+	// Type is the type.
+	Type Type
+	// Results is the count of added results.
+	Results int
+	// Evals is the count of added evals.
+	Evals int
+	// Hi is the count of hi evals by category.
+	Hi map[string]int
+	// Wins is the count of winning hi evals by category.
+	Wins map[string]int
+	// Lo is the count of qualified lo evals.
+	Lo int
+	// Best is the best hi eval encountered.
+	Best *Eval
+	// Coolers are the encountered coolers.
+	Coolers []Cooler
+	// CoolerRank is the weakest Cactus rank of a losing hand that is
+	// considered a cooler. Defaults to [FullHouse].
+	CoolerRank EvalRank
+	// order is the best rank encountered for each category.
+	order map[string]EvalRank
+}
+
+// Cooler is a cooler, where a strong hand lost to a stronger hand.
+type Cooler struct {
+	// Result is the result index.
+	Result int
+	// Winner is the winning position.
+	Winner int
+	// Loser is the losing position.
+	Loser int
+	// Win is the description of the winning hand.
+	Win string
+	// Lose is the description of the losing hand.
+	Lose string
+}
+
+// NewStats creates a new stats accumulator for the type.
+func NewStats(typ Type) *Stats {
+	return &Stats{
+		Type:       typ,
+		Hi:         make(map[string]int),
+		Wins:       make(map[string]int),
+		CoolerRank: FullHouse,
+		order:      make(map[string]EvalRank),
+	}
+}
+
+// AddEvals adds the evals to the stats. Nil evals are ignored.
+func (s *Stats) AddEvals(evs ...*Eval) {
+	for _, ev := range evs {
+		if ev == nil {
+			continue
+		}
+		s.Evals++
+		if s.Type.Low() && ev.HasLo() {
+			s.Lo++
+		}
+		if ev.HiRank == Invalid {
+			continue
+		}
+		if name := s.category(ev.HiRank); name != "" {
+			s.Hi[name]++
+		}
+		if s.Best == nil || ev.Comp(s.Best, false) < 0 {
+			s.Best = ev.Clone()
+		}
+	}
+}
+
+// AddResult adds the result's evals to the stats, accumulating the winning
+// hi categories, and any cooler between the first winner and the best losing
+// hand. Only the result's evals are added as evals (see [Result.Describers]).
+func (s *Stats) AddResult(res *Result) {
+	s.AddEvals(res.Evals...)
+	i := s.Results
+	s.Results++
+	if res.HiPivot == 0 || len(res.HiOrder) == 0 || res.Describer(res.HiOrder[0]) == nil {
+		return
+	}
+	for _, pos := range res.HiOrder[:res.HiPivot] {
+		r, _ := rankOf(res.Describer(pos), false)
+		if name := s.category(r); name != "" {
+			s.Wins[name]++
+		}
+	}
+	if len(res.HiOrder) <= res.HiPivot {
+		return
+	}
+	winner, loser := res.HiOrder[0], res.HiOrder[res.HiPivot]
+	w, l := res.Describer(winner), res.Describer(loser)
+	if l == nil {
+		return
+	}
+	r, _ := l.Rank(false)
+	if r = cactusRank(s.Type, r); r == Invalid || s.CoolerRank < r {
+		return
+	}
+	s.Coolers = append(s.Coolers, Cooler{
+		Result: i,
+		Winner: winner,
+		Loser:  loser,
+		Win:    fmt.Sprintf("%s", w.Desc(false)),
+		Lose:   fmt.Sprintf("%s", l.Desc(false)),
+	})
+}
+
+// Categories returns the encountered hi categories, ordered best to worst.
+func (s *Stats) Categories() []string {
+	v := make([]string, 0, len(s.order))
+	for name := range s.order {
+		v = append(v, name)
+	}
+	slices.SortFunc(v, func(a, b string) int {
+		return int(s.order[a]) - int(s.order[b])
+	})
+	return v
+}
+
+// WriteJSON writes the stats as JSON to w.
+func (s *Stats) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(s); err != nil {
+		return fmt.Errorf("unable to encode stats: %w", err)
+	}
+	return nil
+}
+
+// WriteCSV writes the hi category frequencies as CSV to w, with a header and
+// a row for each category with its count, frequency, wins, and win
+// frequency.
+func (s *Stats) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"category", "count", "freq", "wins", "win_freq"}); err != nil {
+		return fmt.Errorf("unable to write stats: %w", err)
+	}
+	for _, name := range s.Categories() {
+		count, wins := s.Hi[name], s.Wins[name]
+		if err := cw.Write([]string{
+			name,
+			strconv.Itoa(count),
+			strconv.FormatFloat(ratio(count, s.Evals), 'f', 6, 64),
+			strconv.Itoa(wins),
+			strconv.FormatFloat(ratio(wins, s.Results), 'f', 6, 64),
+		}); err != nil {
+			return fmt.Errorf("unable to write stats: %w", err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("unable to write stats: %w", err)
+	}
+	return nil
+}
+
+// category returns the category title for the hi rank, recording the
+// category's order.
+func (s *Stats) category(r EvalRank) string {
+	var name string
+	switch c := cactusRank(s.Type, r); {
+	case c != Invalid:
+		name = c.Title()
+	case !s.Type.Cactus():
+		return ""
+	case sokoRank(s.Type, r) == SokoFourFlush:
+		name = "Four Flush"
+	default:
+		name = "Four Straight"
+	}
+	if o, ok := s.order[name]; !ok || r < o {
+		s.order[name] = r
+	}
+	return name
+}
+
+// ratio returns n / total, or 0 when total is 0.
+func ratio(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total)
+}
@@ -0,0 +1,93 @@
+package cardrank
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	tests := []struct {
+		pockets []string
+		board   string
+	}{
+		{[]string{"Ah Ad", "Kh Kd", "7c 2s"}, "As Kc 9h 9d 3c"},
+		{[]string{"Qh Jh", "Jc 7d", "2c 2s"}, "Th 9h 8h 3c 4d"},
+		{[]string{"Qh Jd", "Qc Jc", "7c 6s"}, "Ts 9d 8h 2c 2d"},
+	}
+	stats := NewStats(Holdem)
+	for _, test := range tests {
+		run := NewRun(len(test.pockets))
+		for i, s := range test.pockets {
+			run.Pockets[i] = Must(s)
+		}
+		run.Hi = Must(test.board)
+		stats.AddResult(NewResult(Holdem, run, nil, false))
+	}
+	if stats.Results != 3 {
+		t.Errorf("expected %d, got: %d", 3, stats.Results)
+	}
+	if stats.Evals != 9 {
+		t.Errorf("expected %d, got: %d", 9, stats.Evals)
+	}
+	if exp, s := []string{"Straight Flush", "Full House", "Straight", "Pair"}, stats.Categories(); !slices.Equal(s, exp) {
+		t.Errorf("expected %v, got: %v", exp, s)
+	}
+	if exp := map[string]int{"Straight Flush": 1, "Full House": 2, "Straight": 4, "Pair": 2}; !reflect.DeepEqual(stats.Hi, exp) {
+		t.Errorf("expected %v, got: %v", exp, stats.Hi)
+	}
+	if exp := map[string]int{"Straight Flush": 1, "Full House": 1, "Straight": 2}; !reflect.DeepEqual(stats.Wins, exp) {
+		t.Errorf("expected %v, got: %v", exp, stats.Wins)
+	}
+	if stats.Best == nil || stats.Best.HiRank != 3 {
+		t.Errorf("expected best rank %d, got: %v", 3, stats.Best)
+	}
+	exp := []Cooler{{
+		Result: 0,
+		Winner: 0,
+		Loser:  1,
+		Win:    "Full House, Aces full of Nines",
+		Lose:   "Full House, Kings full of Nines",
+	}}
+	if !reflect.DeepEqual(stats.Coolers, exp) {
+		t.Errorf("expected %v, got: %v", exp, stats.Coolers)
+	}
+	buf := new(bytes.Buffer)
+	if err := stats.WriteCSV(buf); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	csv := `category,count,freq,wins,win_freq
+Straight Flush,1,0.111111,1,0.333333
+Full House,2,0.222222,1,0.333333
+Straight,4,0.444444,2,0.666667
+Pair,2,0.222222,0,0.000000
+`
+	if s := buf.String(); s != csv {
+		t.Errorf("expected:\n%s\ngot:\n%s", csv, s)
+	}
+	buf.Reset()
+	if err := stats.WriteJSON(buf); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &m); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if s, ok := m["Type"].(string); !ok || s != "Hh" {
+		t.Errorf("expected %q, got: %v", "Hh", m["Type"])
+	}
+}
+
+func TestStatsSoko(t *testing.T) {
+	stats := NewStats(Soko)
+	stats.AddEvals(
+		Soko.Eval(Must("Ah Qh 9h 3h 8c"), nil),
+		Soko.Eval(Must("9c 8d 7h 6c Ks"), nil),
+		Soko.Eval(Must("Ac Ad 9h 6c Ks"), nil),
+	)
+	if exp, s := []string{"Four Flush", "Four Straight", "Pair"}, stats.Categories(); !slices.Equal(s, exp) {
+		t.Errorf("expected %v, got: %v", exp, s)
+	}
+}
@@ -1,4 +1,4 @@
-//go:build forcefat || (!portable && !embedded)
+//go:build forcefat || (!portable && !embedded && !tiny)
 
 package cardrank
 
@@ -10,7 +10,7 @@ import (
 
 func init() {
 	if twoplustwo01Dat != nil {
-		twoPlusTwo = NewTwoPlusTwoEval()
+		twoPlusTwo, twoPlusTwo5, twoPlusTwoBoard = newTwoPlusTwo()
 	}
 }
 
@@ -28,6 +28,14 @@ func init() {
 //
 // [TwoPlusTwoHandEvaluator]: https://github.com/tangentforks/TwoPlusTwoHandEvaluator
 func NewTwoPlusTwoEval() func([]Card) EvalRank {
+	f, _, _ := newTwoPlusTwo()
+	return f
+}
+
+// newTwoPlusTwo creates a Two-Plus-Two rank eval func, a 5 card rank func,
+// and a func that precomputes the lookup state of a board, returning a rank
+// eval func for pockets.
+func newTwoPlusTwo() (func([]Card) EvalRank, RankFunc, func([]Card) func([]Card) EvalRank) {
 	const total, chunk, last = 32487834, 2621440, 1030554
 	tbl, pos := make([]uint32, total), 0
 	for i, buf := range [][]byte{
@@ -80,14 +88,29 @@ func NewTwoPlusTwoEval() func([]Card) EvalRank {
 		uint32(FourOfAKind),
 		uint32(StraightFlush),
 	}
-	return func(v []Card) EvalRank {
-		i := uint32(53)
+	next := func(i uint32, v []Card) uint32 {
 		for _, c := range v {
 			i = tbl[i+m[c]]
 		}
-		if len(v) < 7 {
+		return i
+	}
+	rank := func(i uint32, n int) EvalRank {
+		if n < 7 {
 			i = tbl[i]
 		}
 		return EvalRank(ranks[i>>12] - i&0xfff + 1)
 	}
+	f := func(v []Card) EvalRank {
+		return rank(next(53, v), len(v))
+	}
+	f5 := func(c0, c1, c2, c3, c4 Card) EvalRank {
+		return rank(tbl[tbl[tbl[tbl[tbl[53+m[c0]]+m[c1]]+m[c2]]+m[c3]]+m[c4]], 5)
+	}
+	g := func(board []Card) func([]Card) EvalRank {
+		i, n := next(53, board), len(board)
+		return func(v []Card) EvalRank {
+			return rank(next(i, v), n+len(v))
+		}
+	}
+	return f, f5, g
 }
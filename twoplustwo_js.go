@@ -1,4 +1,4 @@
-//go:build js && !portable && !embedded && !forcefat
+//go:build js && !portable && !embedded && !tiny && !forcefat
 
 package cardrank
 
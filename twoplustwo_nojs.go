@@ -1,4 +1,4 @@
-//go:build !portable && !embedded && (!js || forcefat)
+//go:build !portable && !embedded && !tiny && (!js || forcefat)
 
 package cardrank
 
@@ -1,8 +1,10 @@
 package cardrank
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"iter"
 	"slices"
 	"strconv"
 	"strings"
@@ -24,7 +26,7 @@ import (
 // prior to the board cards being dealt.
 //
 // [Split] is the Hi/Lo variant of [Holdem], using a [Eight]-or-better
-// qualifier (see [RankEightOrBetter]) for the Lo.
+// qualifier by default (see [WithLowQualifier]) for the Lo.
 //
 // [Short] is a [Holdem] variant using a Short deck of 36 cards, having only
 // cards with ranks of 6+ (see [DeckShort]). [Flush] ranks over [FullHouse].
@@ -69,7 +71,7 @@ import (
 // pocket cards can be drawn (exchanged) on the 6th street.
 //
 // [DrawHiLo] is the Hi/Lo variant of [Draw], using a [Eight]-or-better
-// qualifier (see [RankEightOrBetter]) for the Lo.
+// qualifier by default (see [WithLowQualifier]) for the Lo.
 //
 // [Stud] is a best-5 card game, using a standard deck of 52 cards (see
 // [DeckFrench]), comprising a pocket of 7 cards, no community cards, with
@@ -79,7 +81,7 @@ import (
 // the 7th street.
 //
 // [StudHiLo] is the Hi/Lo variant of [Stud], using a [Eight]-or-better
-// qualifier (see [RankEightOrBetter]) for the Lo.
+// qualifier by default (see [WithLowQualifier]) for the Lo.
 //
 // [StudFive] is a best-5 card game using a standard deck of 52 cards (see
 // [DeckFrench]), comprising a pocket of 5 cards, no community cards, with
@@ -99,7 +101,7 @@ import (
 // best-5.
 //
 // [OmahaHiLo] is the Hi/Lo variant of [Omaha], using a [Eight]-or-better
-// qualifier (see [RankEightOrBetter]) for the Lo.
+// qualifier by default (see [WithLowQualifier]) for the Lo.
 //
 // [OmahaDouble] is a [Omaha] variant having two separate Hi and Lo community
 // boards.
@@ -119,13 +121,13 @@ import (
 // Pre-Flop, and only 2 board cards dealt on the Flop.
 //
 // [CourchevelHiLo] is the Hi/Lo variant of [Courchevel], using a
-// [Eight]-or-better qualifier (see [RankEightOrBetter]) for the Lo.
+// [Eight]-or-better qualifier by default (see [WithLowQualifier]) for the Lo.
 //
 // [Fusion] is a [Holdem]/[Omaha] variant where only 2 pocket cards are dealt
 // on the Pre-Flop, with 1 additional pocket card dealt on the Flop and Turn.
 //
 // [FusionHiLo] is the Hi/Lo variant of [Fusion], using a [Eight]-or-better
-// qualifier (see [RankEightOrBetter]) for the Lo.
+// qualifier by default (see [WithLowQualifier]) for the Lo.
 //
 // [Soko] is a [Stud]/[StudFive] variant with 2 additional ranks, a Four Flush
 // (4 cards of the same suit), and a Four Straight (4 cards in sequential rank,
@@ -134,7 +136,7 @@ import (
 // cards are dealt, up, on the River.
 //
 // [SokoHiLo] is the Hi/Lo variant of [Soko], using a [Eight]-or-better
-// qualifier (see [RankEightOrBetter]) for the Lo.
+// qualifier by default (see [WithLowQualifier]) for the Lo.
 //
 // [Lowball] is a best-5 low card game using a standard deck of 52 cards (see
 // [DeckFrench]), comprising 5 pocket cards, no community cards, and a Ante,
@@ -146,16 +148,36 @@ import (
 // [LowballTriple] is a [Lowball] variant, where up to 5 pocket cards may be
 // drawn (exchanged) on any of the 6th, 7th, or River streets.
 //
+// [LowballAceSix] is a [Lowball] variant using a [Ace]-to-[Six] low ranking
+// system (see [RankAceSixLow]), where [Ace]'s are always low, and
+// non-[Flush], and non-[Straight] lows are best. Commonly known as London
+// Lowball.
+//
 // [Razz] is a [Stud] low variant, using a [Ace]-to-[Five] ranking (see
 // [RankRazz]), where [Ace]'s play low, and [Flush]'s and [Straight]'s do not
 // affect ranking.
 //
+// [California] is a [Lowball] variant using a [Ace]-to-[Five] ranking (see
+// [RankCalifornia]), played with a 53 card deck (see [DeckJoker]) having an
+// additional [Joker] (the bug), that plays as the lowest rank not already in
+// the hand.
+//
 // [Badugi] is a best-4 low non-matching-suit card game, using a standard deck
 // of 52 cards (see [DeckFrench]), comprising 4 pocket cards, no community
 // cards, and Ante, 5th, 6th, and River streets. Up to 4 cards can be drawn
 // (exchanged) multiple times on the 5th, 6th, or River streets. See
 // [NewBadugiEval] for more details.
 //
+// [Guts] is a best-3 card game, using a standard deck of 52 cards (see
+// [DeckFrench]), comprising 3 pocket cards, no community cards, and a Declare
+// street where each position declares whether they stay in or drop out. The
+// hand ranks are evaluated using [RankThree], where a [Straight] beats a
+// [Flush]. See [Result.Guts] for settling the pot, where each position that
+// stayed and lost matches the pot.
+//
+// [GutsTwo] is the 2 pocket card variant of [Guts], where only a [Pair] or
+// high card is possible.
+//
 // [Kuhn] is a best high card game, using a 3 card deck ([King], [Queen],
 // [Jack]), having 1 pocket card and no community board cards. Useful for game
 // tree testing. See [Kuhn poker].
@@ -209,8 +231,12 @@ const (
 	SokoHiLo       Type = 'K'<<8 | 'l' // Kl
 	Lowball        Type = 'L'<<8 | '1' // L1
 	LowballTriple  Type = 'L'<<8 | '3' // L3
+	LowballAceSix  Type = 'L'<<8 | 'a' // La
+	California     Type = 'L'<<8 | 'c' // Lc
 	Razz           Type = 'R'<<8 | 'a' // Ra
 	Badugi         Type = 'B'<<8 | 'a' // Ba
+	Guts           Type = 'G'<<8 | '3' // G3
+	GutsTwo        Type = 'G'<<8 | '2' // G2
 )
 
 // DefaultTypes returns the default type descriptions. The returned
@@ -258,8 +284,12 @@ func DefaultTypes() []TypeDesc {
 		{"Kl", SokoHiLo, "SokoHiLo", WithSoko(true)},
 		{"L1", Lowball, "Lowball", WithLowball(false)},
 		{"L3", LowballTriple, "LowballTriple", WithLowball(true)},
+		{"La", LowballAceSix, "LowballAceSix", WithLowballAceSix(false)},
+		{"Lc", California, "California", WithCalifornia()},
 		{"Ra", Razz, "Razz", WithRazz()},
 		{"Ba", Badugi, "Badugi", WithBadugi()},
+		{"G3", Guts, "Guts", WithGuts(3)},
+		{"G2", GutsTwo, "GutsTwo", WithGuts(2)},
 		// {"Ku", Kuhn, "Kuhn", WithKuhn()},
 		// {"Le", Leduc, "Leduc", WithLeduc()},
 		// {"RI", RhodeIsland, "RhodeIsland", WithRhodeIsland()},
@@ -359,11 +389,17 @@ func (typ Type) Max() int {
 	return descs[typ].Max
 }
 
-// Low returns true when the type supports 8-or-better lo eval.
+// Low returns true when the type supports a qualified lo eval (see
+// [Type.Qualifier]).
 func (typ Type) Low() bool {
 	return descs[typ].Low
 }
 
+// Qualifier returns the type's Lo qualifier rank.
+func (typ Type) Qualifier() Rank {
+	return descs[typ].qualifier
+}
+
 // Double returns true when the type has double boards.
 func (typ Type) Double() bool {
 	return descs[typ].Double
@@ -431,6 +467,36 @@ func (typ Type) BoardDiscard() int {
 	return 0
 }
 
+// PocketCombos returns the count of possible pockets dealt from the type's
+// full deck, such as 1326 for [Holdem] or 270725 for [Omaha].
+func (typ Type) PocketCombos() int {
+	if desc, ok := descs[typ]; ok {
+		return binom(len(desc.Deck.v()), desc.pocket)
+	}
+	return 0
+}
+
+// BoardCombos returns the count of possible full boards dealt from the
+// remaining cards, as enumerated when calculating odds. Multiply by
+// [Type.EvalCombos] to budget the 5-card evaluations for each pocket.
+func (typ Type) BoardCombos(remaining int) int {
+	if desc, ok := descs[typ]; ok {
+		return binom(remaining, desc.board)
+	}
+	return 0
+}
+
+// EvalCombos returns the count of 5-card combinations taken by the type's
+// eval for a full pocket and board, such as 60 for [Omaha] (any 2 of 4
+// pocket cards, and any 3 of 5 board cards). Returns 0 when the eval has no
+// take table for the type's pocket and board counts. See [EvalType.Combos].
+func (typ Type) EvalCombos() int {
+	if desc, ok := descs[typ]; ok {
+		return desc.Eval.Combos(desc.pocket, desc.board)
+	}
+	return 0
+}
+
 // Draw returns true when one or more streets allows draws.
 func (typ Type) Draw() bool {
 	if desc, ok := descs[typ]; ok {
@@ -439,6 +505,16 @@ func (typ Type) Draw() bool {
 	return false
 }
 
+// Up returns true when one or more streets reveal pocket cards (ie, a [Stud]
+// type), where the order of betting is determined by the revealed cards
+// instead of the button.
+func (typ Type) Up() bool {
+	if desc, ok := descs[typ]; ok {
+		return desc.up
+	}
+	return false
+}
+
 // DeckType returns the type's deck type.
 func (typ Type) DeckType() DeckType {
 	return descs[typ].Deck
@@ -451,9 +527,9 @@ func (typ Type) Deck() *Deck {
 
 // Dealer creates a new dealer with a deck shuffled by shuffles, with specified
 // pocket count.
-func (typ Type) Dealer(shuffler Shuffler, shuffles, count int) *Dealer {
+func (typ Type) Dealer(shuffler Shuffler, shuffles, count int, opts ...DealerOption) *Dealer {
 	if desc, ok := descs[typ]; ok {
-		return NewShuffledDealer(desc, shuffler, shuffles, count)
+		return NewShuffledDealer(desc, shuffler, shuffles, count, opts...)
 	}
 	return nil
 }
@@ -486,14 +562,210 @@ func (typ Type) Eval(pocket, board []Card) *Eval {
 	return ev
 }
 
+// EvalErr creates a new eval for the type, evaluating the pocket and board.
+// Returns [ErrUnsupportedType] when the type is not registered, or
+// [ErrInsufficientCards] when the pocket or board has fewer cards than the
+// type's pocket or board and could not be evaluated. Returns [ErrInvalidCard]
+// for any other pocket and board that could not be evaluated.
+func (typ Type) EvalErr(pocket, board []Card) (*Eval, error) {
+	f, ok := evals[typ]
+	if !ok {
+		return nil, ErrUnsupportedType
+	}
+	ev := EvalOf(typ)
+	f(ev, pocket, board)
+	switch {
+	case ev.HiRank != Invalid:
+		return ev, nil
+	case len(pocket) < typ.Pocket(), len(board) < typ.Board():
+		return ev, ErrInsufficientCards
+	}
+	return ev, ErrInvalidCard
+}
+
+// Calc creates a new eval for the type, evaluating the pocket and board using
+// the type's unnormalized calc func, which skips ordering the best and unused
+// cards. Use [Eval.Normalize] to order the eval's cards before display.
+func (typ Type) Calc(pocket, board []Card) *Eval {
+	ev := EvalOf(typ)
+	calcs[typ](ev, pocket, board)
+	return ev
+}
+
 // EvalPockets creates new evals for the type, evaluating each of the pockets
-// and board.
+// and board. The board is prepared once and shared by all pockets (see
+// [BoardEval]).
 func (typ Type) EvalPockets(pockets [][]Card, board []Card) []*Eval {
-	evs := make([]*Eval, len(pockets))
-	for i, pocket := range pockets {
-		evs[i] = typ.Eval(pocket, board)
+	return NewBoardEval(typ, board).EvalPockets(pockets)
+}
+
+// EvalPocketsContext creates new evals for the type, evaluating each of the
+// pockets and board. See [BoardEval.EvalPocketsContext].
+func (typ Type) EvalPocketsContext(ctx context.Context, pockets [][]Card, board []Card) ([]*Eval, error) {
+	if _, ok := evals[typ]; !ok {
+		return nil, ErrUnsupportedType
+	}
+	return NewBoardEval(typ, board).EvalPocketsContext(ctx, pockets)
+}
+
+// EvalPocketsParallel creates new evals for the type, evaluating each of the
+// pockets and board in parallel. See [BoardEval.EvalPocketsParallel].
+func (typ Type) EvalPocketsParallel(ctx context.Context, pockets [][]Card, board []Card) ([]*Eval, error) {
+	if _, ok := evals[typ]; !ok {
+		return nil, ErrUnsupportedType
+	}
+	return NewBoardEval(typ, board).EvalPocketsParallel(ctx, pockets, 0)
+}
+
+// EvalBoard creates a board eval for the type, for evaluating multiple
+// pockets against the same board.
+func (typ Type) EvalBoard(board []Card) *BoardEval {
+	return NewBoardEval(typ, board)
+}
+
+// NutRank returns the best possible hi eval rank for the board, and the first
+// pocket making it. Only the pockets of unused cards that can make a distinct
+// best-5 are evaluated, such as each 2 card combination for [Omaha], stopping
+// at the best possible rank. Returns [Invalid] when the type does not have a
+// board.
+func (typ Type) NutRank(board []Card) (EvalRank, []Card) {
+	if typ.Board() == 0 {
+		return Invalid, nil
+	}
+	b := newBoardEval(typ, board, false)
+	nuts, pocket := Invalid, []Card(nil)
+	for v := range pocketCores(typ, unusedCards(typ, board)) {
+		if ev := b.Eval(v); ev.HiRank < nuts {
+			if nuts, pocket = ev.HiRank, slices.Clone(v); nuts == 1 {
+				break
+			}
+		}
+	}
+	return nuts, pocket
+}
+
+// IsNuts returns true when no pocket of unused cards makes a better hi hand
+// than the pocket on the board, stopping at the first better pocket. Returns
+// false when the type does not have a board. See [Type.NutRank].
+func (typ Type) IsNuts(pocket, board []Card) bool {
+	if typ.Board() == 0 {
+		return false
+	}
+	b := newBoardEval(typ, board, false)
+	hero := b.Eval(pocket)
+	if hero.HiRank == Invalid {
+		return false
+	}
+	for v := range pocketCores(typ, unusedCards(typ, pocket, board)) {
+		if b.Eval(v).HiRank < hero.HiRank {
+			return false
+		}
+	}
+	return true
+}
+
+// cores returns the count of pocket cards that can be used in a best-5 with
+// the board, such as 2 for [Omaha].
+func (typ Type) cores() int {
+	switch descs[typ].Eval {
+	case EvalOmaha, EvalManila, EvalSpanish:
+		return min(typ.Pocket(), 2)
+	}
+	return min(typ.Pocket(), 5)
+}
+
+// pocketCores returns a iterator over the pockets of unused cards that can
+// make a distinct best-5 with a board, as each combination of core cards (see
+// [Type.cores]) filled with the first of the remaining unused cards. As any
+// pocket's best-5 is made with at most the core cards, the best pocket on a
+// board is the best of the core pockets. The yielded pocket is reused.
+func pocketCores(typ Type, unused []Card) iter.Seq[[]Card] {
+	return func(yield func([]Card) bool) {
+		n, k := typ.Pocket(), typ.cores()
+		if len(unused) < n {
+			return
+		}
+		pocket := make([]Card, n)
+		for g, v := NewCombinGen(unused, k); g.Next(); {
+			copy(pocket, v)
+			for i, j := k, 0; i < n; j++ {
+				if !slices.Contains(v, unused[j]) {
+					pocket[i] = unused[j]
+					i++
+				}
+			}
+			if !yield(pocket) {
+				return
+			}
+		}
 	}
-	return evs
+}
+
+// BeatingCombos returns the count of pockets of unused cards that make a
+// better hi hand than the pocket on the board. When list is true, the beating
+// pockets are also returned. See [Type.IsNuts].
+func BeatingCombos(typ Type, pocket, board []Card, list bool) (int, [][]Card) {
+	b := newBoardEval(typ, board, false)
+	hero := b.Eval(pocket)
+	var count int
+	var v [][]Card
+	for g, c := NewCombinGen(unusedCards(typ, pocket, board), typ.Pocket()); g.Next(); {
+		if b.Eval(c).HiRank < hero.HiRank {
+			if count++; list {
+				v = append(v, slices.Clone(c))
+			}
+		}
+	}
+	return count, v
+}
+
+// WeakestWinningHand returns the weakest (highest) hi eval rank that beats the
+// hero's pocket on the board, and the first pocket of unused cards making it,
+// such as when describing that the hero loses to any pocket making a pair.
+// The hero's pocket cards are not used. Returns [Invalid] when no pocket beats
+// the hero. See [BeatingCombos].
+func WeakestWinningHand(typ Type, pocket, board []Card) (EvalRank, []Card) {
+	if typ.Board() == 0 {
+		return Invalid, nil
+	}
+	b := newBoardEval(typ, board, false)
+	hero := b.Eval(pocket).HiRank
+	weakest, winner := EvalRank(0), []Card(nil)
+	for g, v := NewCombinGen(unusedCards(typ, pocket, board), typ.Pocket()); g.Next(); {
+		if r := b.Eval(v).HiRank; r < hero && weakest < r {
+			weakest, winner = r, slices.Clone(v)
+		}
+	}
+	if winner == nil {
+		return Invalid, nil
+	}
+	return weakest, winner
+}
+
+// Potential calculates the distribution of final hi hand categories for the
+// pocket and board. See [NewPotential].
+func (typ Type) Potential(ctx context.Context, pocket, board []Card) (*Potential, bool) {
+	return NewPotential(ctx, typ, pocket, board)
+}
+
+// StartingExpValue returns the starting expected value for the pocket, using
+// the type's registered starting pocket data, or the [Holdem] starting pocket
+// data when not registered. See [RegisterStarting].
+func (typ Type) StartingExpValue(pocket []Card) *ExpValue {
+	if starting, ok := startings[typ]; ok {
+		if starting.ExpValue == nil {
+			return nil
+		}
+		return starting.ExpValue(pocket)
+	}
+	return StartingExpValue(pocket)
+}
+
+// StartingEvalRank returns the worst (highest) possible resulting 5-card rank
+// for the pocket, using the type's registered starting pocket data, or the
+// [Holdem] starting pocket data when not registered. See [RegisterStarting].
+func (typ Type) StartingEvalRank(pocket []Card) EvalRank {
+	return startingEvalRank(typ, pocket, true)
 }
 
 // Odds calculates the odds for the pockets, board.
@@ -501,12 +773,35 @@ func (typ Type) Odds(ctx context.Context, pockets [][]Card, board []Card, opts .
 	return NewOddsCalc(typ, append(opts, WithPocketsBoard(pockets, board))...).Calc(ctx)
 }
 
+// OddsErr calculates the odds for the pockets, board. See [OddsCalc.CalcErr].
+func (typ Type) OddsErr(ctx context.Context, pockets [][]Card, board []Card, opts ...CalcOption) (*Odds, *Odds, error) {
+	return NewOddsCalc(typ, append(opts, WithPocketsBoard(pockets, board))...).CalcErr(ctx)
+}
+
+// RangeOdds calculates the odds of multiple ranges of pockets for the board,
+// with card removal. See [RangeCalc].
+func (typ Type) RangeOdds(ctx context.Context, ranges [][][]Card, board []Card, opts ...CalcOption) (*Odds, *Odds, bool) {
+	return NewRangeCalc(typ, ranges, board, opts...).Calc(ctx)
+}
+
+// StreetOdds calculates the odds of the pockets for each street of a specific
+// board runout. See [CalcStreetOdds].
+func (typ Type) StreetOdds(ctx context.Context, pockets [][]Card, board []Card, opts ...CalcOption) ([]StreetOdds, bool) {
+	return CalcStreetOdds(ctx, typ, pockets, board, opts...)
+}
+
 // ExpValue calculates expected value for a single pocket. Use [WithBoard] to
 // pass a board.
 func (typ Type) ExpValue(ctx context.Context, pocket []Card, opts ...CalcOption) (*ExpValue, bool) {
 	return NewExpValueCalc(typ, pocket, opts...).Calc(ctx)
 }
 
+// ExpValueErr calculates expected value for a single pocket. See
+// [ExpValueCalc.CalcErr].
+func (typ Type) ExpValueErr(ctx context.Context, pocket []Card, opts ...CalcOption) (*ExpValue, error) {
+	return NewExpValueCalc(typ, pocket, opts...).CalcErr(ctx)
+}
+
 // TypeDesc is a type description.
 type TypeDesc struct {
 	// Num is the registered number.
@@ -517,8 +812,9 @@ type TypeDesc struct {
 	Name string
 	// Max is the max number of players.
 	Max int
-	// Low is true when the enabling the Hi/Lo variant, with an 8-or-better
-	// evaluated Lo.
+	// Low is true when the enabling the Hi/Lo variant, with a Lo evaluated
+	// using the type's qualifier, [Eight]-or-better by default (see
+	// [WithLowQualifier]).
 	Low bool
 	// Double is true when there are double community boards where the first
 	// and second board is evaluated as the Hi and Lo, respectively.
@@ -545,6 +841,10 @@ type TypeDesc struct {
 	board         int
 	boardDiscard  int
 	draw          bool
+	up            bool
+	qualifier     Rank
+	// qualified is true when the qualifier has been set.
+	qualified bool
 }
 
 // NewType creates a new type description. Created type descriptions must be
@@ -557,22 +857,26 @@ func NewType(id string, typ Type, name string, opts ...TypeOption) (*TypeDesc, e
 		return nil, ErrInvalidId
 	}
 	desc := &TypeDesc{
-		Type:   typ,
-		Name:   name,
-		Deck:   DeckFrench,
-		Eval:   EvalCactus,
-		HiDesc: DescCactus,
-		LoDesc: DescLow,
+		Type:      typ,
+		Name:      name,
+		Deck:      DeckFrench,
+		Eval:      EvalCactus,
+		HiDesc:    DescCactus,
+		LoDesc:    DescLow,
+		qualifier: Eight,
+		qualified: true,
 	}
 	for _, o := range opts {
 		o(desc)
 	}
-	for _, street := range desc.Streets {
+	for i, street := range desc.Streets {
+		desc.Streets[i].Round = i
 		desc.pocket += street.Pocket
 		desc.pocketDiscard += street.PocketDiscard
 		desc.board += street.Board
 		desc.boardDiscard += street.BoardDiscard
-		desc.draw = desc.draw || street.PocketDraw != 0
+		desc.draw = desc.draw || street.IsDraw()
+		desc.up = desc.up || street.IsUp()
 	}
 	return desc, nil
 }
@@ -592,6 +896,18 @@ func (desc *TypeDesc) Apply(opts ...StreetOption) {
 // StreetOption is a street option.
 type StreetOption func(int, *StreetDesc)
 
+// WithPocketKeep is a street option to set the count of pocket cards each
+// position keeps after discarding on the street with the id, such as the
+// [Manila] or [Spanish] Drop street house rule where positions dealt 3
+// pocket cards discard down to 2.
+func WithPocketKeep(id byte, keep int) StreetOption {
+	return func(_ int, desc *StreetDesc) {
+		if desc.Id == id {
+			desc.PocketKeep = keep
+		}
+	}
+}
+
 // TypeOption is a type description option.
 type TypeOption func(*TypeDesc)
 
@@ -606,6 +922,16 @@ func WithHoldem(low bool, opts ...StreetOption) TypeOption {
 	}
 }
 
+// WithLowQualifier is a type description option to set the Lo qualifier rank
+// used by Hi/Lo types (see [RankLowQualifier]). Types default to a [Eight]
+// qualifier. Use [Ace] for a Lo without a qualifier. Qualifiers lower than
+// [Five] cannot be satisfied, and are rejected by [RegisterType].
+func WithLowQualifier(qualifier Rank) TypeOption {
+	return func(desc *TypeDesc) {
+		desc.qualifier, desc.qualified = qualifier, true
+	}
+}
+
 // WithShort is a type description option to set [Short] definitions.
 func WithShort(opts ...StreetOption) TypeOption {
 	return func(desc *TypeDesc) {
@@ -902,6 +1228,17 @@ func WithSoko(low bool, opts ...StreetOption) TypeOption {
 	}
 }
 
+// WithSokoUnder is a type description option to set [Soko] definitions, where
+// a Four Flush and Four Straight rank under a [Pair] (see [RankSokoUnder]).
+func WithSokoUnder(low bool, opts ...StreetOption) TypeOption {
+	return func(desc *TypeDesc) {
+		WithSoko(low)(desc)
+		desc.Eval = EvalSokoUnder
+		desc.HiDesc = DescSokoUnder
+		desc.Apply(opts...)
+	}
+}
+
 // WithLowball is a type description option to set [Lowball] definitions.
 func WithLowball(multi bool, opts ...StreetOption) TypeOption {
 	return func(desc *TypeDesc) {
@@ -918,6 +1255,28 @@ func WithLowball(multi bool, opts ...StreetOption) TypeOption {
 	}
 }
 
+// WithLowballAceSix is a type description option to set [LowballAceSix]
+// definitions.
+func WithLowballAceSix(multi bool, opts ...StreetOption) TypeOption {
+	return func(desc *TypeDesc) {
+		WithLowball(multi)(desc)
+		desc.Eval = EvalAceSix
+		desc.Apply(opts...)
+	}
+}
+
+// WithCalifornia is a type description option to set [California]
+// definitions.
+func WithCalifornia(opts ...StreetOption) TypeOption {
+	return func(desc *TypeDesc) {
+		WithLowball(false)(desc)
+		desc.Deck = DeckJoker
+		desc.Eval = EvalCalifornia
+		desc.HiDesc = DescRazz
+		desc.Apply(opts...)
+	}
+}
+
 // WithRazz is a type description option to set [Razz] definitions.
 func WithRazz(opts ...StreetOption) TypeOption {
 	return func(desc *TypeDesc) {
@@ -989,6 +1348,29 @@ func WithLeduc(opts ...StreetOption) TypeOption {
 	}
 }
 
+// WithGuts is a type description option to set [Guts] definitions for the
+// number of pocket cards (2 or 3).
+func WithGuts(pocket int, opts ...StreetOption) TypeOption {
+	return func(desc *TypeDesc) {
+		desc.Max = 10
+		desc.Blinds = []string{"Ante"}
+		desc.Streets = []StreetDesc{
+			{
+				Id:     'p',
+				Name:   "Pocket",
+				Pocket: pocket,
+			},
+			{
+				Id:   'd',
+				Name: "Declare",
+			},
+		}
+		desc.Eval = EvalThree
+		desc.HiDesc = DescThree
+		desc.Apply(opts...)
+	}
+}
+
 /*
 // WithRhodeIsland is a type description option to set [RhodeIsland] definitions.
 func WithRhodeIsland(opts ...StreetOption) TypeOption {
@@ -1022,6 +1404,39 @@ type StreetDesc struct {
 	Board int
 	// BoardDiscard is the count of cards to discard before board dealt.
 	BoardDiscard int
+	// PocketKeep is the count of pocket cards each position keeps after
+	// discarding on the street (see [Dealer.DiscardPocket]).
+	PocketKeep int
+	// Round is the betting round index of the street.
+	Round int
+}
+
+// IsPocket returns true when the street deals pocket cards.
+func (desc StreetDesc) IsPocket() bool {
+	return 0 < desc.Pocket
+}
+
+// IsUp returns true when the street reveals dealt pocket cards (ie, a [Stud]
+// street), where the order of betting is determined by the revealed cards
+// instead of the button.
+func (desc StreetDesc) IsUp() bool {
+	return 0 < desc.PocketUp
+}
+
+// IsDraw returns true when the street allows pocket cards to be drawn.
+func (desc StreetDesc) IsDraw() bool {
+	return 0 < desc.PocketDraw
+}
+
+// IsBoard returns true when the street deals board cards.
+func (desc StreetDesc) IsBoard() bool {
+	return 0 < desc.Board
+}
+
+// IsKeep returns true when positions discard pocket cards down to the keep
+// count on the street.
+func (desc StreetDesc) IsKeep() bool {
+	return 0 < desc.PocketKeep
 }
 
 // Desc returns a description of the street.
@@ -1045,6 +1460,9 @@ func (desc StreetDesc) Desc() string {
 	if 0 < desc.PocketDraw {
 		v = append(v, fmt.Sprintf("w: %d", desc.PocketDraw))
 	}
+	if 0 < desc.PocketKeep {
+		v = append(v, fmt.Sprintf("k: %d", desc.PocketKeep))
+	}
 	var s string
 	if len(v) != 0 {
 		s = " (" + strings.Join(v, ", ") + ")"
@@ -1142,45 +1560,80 @@ const (
 	EvalSpanish       EvalType = 'p'
 	EvalOmaha         EvalType = 'o'
 	EvalSoko          EvalType = 'k'
+	EvalSokoUnder     EvalType = 'q'
 	EvalLowball       EvalType = 'l'
 	EvalRazz          EvalType = 'r'
+	EvalAceSix        EvalType = 'a'
+	EvalCalifornia    EvalType = 'w'
 	EvalBadugi        EvalType = 'b'
 	EvalHigh          EvalType = 'h'
+	EvalThree         EvalType = '3'
 )
 
 // New creates a eval func for the type.
 func (typ EvalType) New(board int, normalize, low bool) EvalFunc {
+	return typ.NewWith(board, normalize, low, Eight)
+}
+
+// NewWith creates a eval func for the type, using the qualifier for the Lo
+// eval (see [RankLowQualifier]).
+func (typ EvalType) NewWith(board int, normalize, low bool, qualifier Rank) EvalFunc {
 	switch typ {
 	case EvalCactus:
-		return NewCactusEval(board, normalize, low)
+		return NewCactusEvalWith(board, normalize, low, qualifier)
 	case EvalJacksOrBetter:
 		return NewJacksOrBetterEval(normalize)
 	case EvalShort:
-		return NewModifiedEval(RankShort, Rank(DeckShort), EvalRank.FromFlushOver, normalize, false)
+		return NewModifiedEvalWith(RankShort, Rank(DeckShort), EvalRank.FromFlushOver, normalize, false, qualifier)
 	case EvalManila:
-		return NewOmahaEval(RankManila, Rank(DeckManila), EvalRank.FromFlushOver, normalize, false)
+		return NewOmahaEvalWith(RankManila, Rank(DeckManila), EvalRank.FromFlushOver, normalize, false, qualifier)
 	case EvalSpanish:
-		return NewOmahaEval(RankSpanish, Rank(DeckSpanish), EvalRank.FromFlushOver, normalize, false)
+		return NewOmahaEvalWith(RankSpanish, Rank(DeckSpanish), EvalRank.FromFlushOver, normalize, false, qualifier)
 	case EvalOmaha:
-		return NewOmahaEval(RankCactus, Rank(DeckFrench), nil, normalize, low)
+		return NewOmahaEvalWith(RankCactus, Rank(DeckFrench), nil, normalize, low, qualifier)
 	case EvalSoko:
-		return NewSokoEval(normalize, low)
+		return NewSokoEvalWith(normalize, low, qualifier)
+	case EvalSokoUnder:
+		return NewSokoUnderEvalWith(normalize, low, qualifier)
 	case EvalLowball:
 		return NewLowballEval(normalize)
 	case EvalRazz:
 		return NewRazzEval(normalize)
+	case EvalAceSix:
+		return NewAceSixEval(normalize)
+	case EvalCalifornia:
+		return NewCaliforniaEval(normalize)
 	case EvalBadugi:
 		return NewBadugiEval(normalize)
 	case EvalHigh:
 		return NewHighEval()
-		/*
-			case EvalThree:
-				return NewThreeEval()
-		*/
+	case EvalThree:
+		return NewThreeEval(normalize)
 	}
 	return nil
 }
 
+// Combos returns the count of 5-card combinations taken by the eval for the
+// pocket and board counts, or 0 when the eval has no take table for the
+// counts. [Omaha] evals take any 2 of 2 to 6 pocket cards and any 3 of 3 to
+// 5 board cards, while other 5-card evals take any 5 of 5 to 9 pocket and
+// board cards. Evals not taking 5-card combinations return 1.
+func (typ EvalType) Combos(pocket, board int) int {
+	switch typ {
+	case EvalManila, EvalSpanish, EvalOmaha:
+		if pocket < 2 || 6 < pocket || board < 3 || 5 < board {
+			return 0
+		}
+		return binom(pocket, 2) * binom(board, 3)
+	case EvalBadugi, EvalHigh, EvalThree:
+		return 1
+	}
+	if n := pocket + board; n < 5 || 9 < n {
+		return 0
+	}
+	return binom(pocket+board, 5)
+}
+
 // Cactus returns true when the eval is a Cactus eval.
 func (typ EvalType) Cactus() bool {
 	switch typ {
@@ -1189,7 +1642,8 @@ func (typ EvalType) Cactus() bool {
 		EvalManila,
 		EvalSpanish,
 		EvalOmaha,
-		EvalSoko:
+		EvalSoko,
+		EvalSokoUnder:
 		return true
 	}
 	return false
@@ -1231,11 +1685,14 @@ func (typ EvalType) Byte() byte {
 		EvalSpanish,
 		EvalOmaha,
 		EvalSoko,
+		EvalSokoUnder,
 		EvalLowball,
 		EvalRazz,
+		EvalAceSix,
+		EvalCalifornia,
 		EvalBadugi,
-		EvalHigh:
-		// EvalThree:
+		EvalHigh,
+		EvalThree:
 		return byte(typ)
 	}
 	return ' '
@@ -1258,18 +1715,22 @@ func (typ EvalType) Name() string {
 		return "Omaha"
 	case EvalSoko:
 		return "Soko"
+	case EvalSokoUnder:
+		return "SokoUnder"
 	case EvalLowball:
 		return "Lowball"
 	case EvalRazz:
 		return "Razz"
+	case EvalAceSix:
+		return "AceSix"
+	case EvalCalifornia:
+		return "California"
 	case EvalBadugi:
 		return "Badugi"
 	case EvalHigh:
 		return "High"
-		/*
-			case EvalThree:
-				return "Three"
-		*/
+	case EvalThree:
+		return "Three"
 	}
 	return ""
 }
@@ -1282,11 +1743,13 @@ const (
 	DescCactus    DescType = 0
 	DescFlushOver DescType = 'f'
 	DescSoko      DescType = 'k'
+	DescSokoUnder DescType = 'q'
 	DescLow       DescType = 'l'
 	DescLowball   DescType = 'b'
 	DescRazz      DescType = 'r'
 	DescHigh      DescType = 'h'
 	DescThree     DescType = '3'
+	DescPartial   DescType = 'p'
 )
 
 // Format satisfies the [fmt.Formatter] interface.
@@ -1312,11 +1775,13 @@ func (typ DescType) Byte() byte {
 		return 'c'
 	case DescFlushOver,
 		DescSoko,
+		DescSokoUnder,
 		DescLow,
 		DescLowball,
 		DescRazz,
 		DescHigh,
-		DescThree:
+		DescThree,
+		DescPartial:
 		return byte(typ)
 	}
 	return ' '
@@ -1331,6 +1796,8 @@ func (typ DescType) Name() string {
 		return "FlushOver"
 	case DescSoko:
 		return "Soko"
+	case DescSokoUnder:
+		return "SokoUnder"
 	case DescLow:
 		return "Low"
 	case DescLowball:
@@ -1341,6 +1808,8 @@ func (typ DescType) Name() string {
 		return "High"
 	case DescThree:
 		return "Three"
+	case DescPartial:
+		return "Partial"
 	}
 	return ""
 }
@@ -1353,14 +1822,15 @@ func (typ DescType) Name() string {
 //	e - best description, eval rank only (Two Pair, Pair, Flush, 7-Low, ...)
 //	s - best full description (Four of a Kind, Ace, kickers King)
 //	S - best description, no kickers
-//	u - unused cards with [CardFormatter]
+//	u - unused cards ([Ah Kd 9c])
+//	U - unused card rank names (Ace, King, Nine)
 //	v - same as s
 func (typ DescType) Desc(f fmt.State, verb rune, rank EvalRank, best, unused []Card) {
 	switch verb {
 	case 'd':
 		fmt.Fprintf(f, "%d", int(rank))
-	case 'u':
-		Formatter(unused).Format(f, 's')
+	case 'u', 'U':
+		unusedDesc(f, verb, unused)
 	default:
 		switch typ {
 		case DescCactus:
@@ -1375,14 +1845,70 @@ func (typ DescType) Desc(f fmt.State, verb rune, rank EvalRank, best, unused []C
 			LowballDesc(f, verb, rank, best, unused)
 		case DescSoko:
 			SokoDesc(f, verb, rank, best, unused)
+		case DescSokoUnder:
+			SokoUnderDesc(f, verb, rank, best, unused)
 		case DescHigh:
 			HighDesc(f, verb, rank, best, unused)
 		case DescThree:
 			ThreeDesc(f, verb, rank, best, unused)
+		case DescPartial:
+			PartialDesc(f, verb, rank, best, unused)
 		}
 	}
 }
 
+// WithVerb returns a description func for the desc type, writing the
+// description using the verb in place of the 's' and 'v' verbs, such as 'S'
+// for terse descriptions.
+func (typ DescType) WithVerb(verb rune) DescFunc {
+	return func(f fmt.State, v rune, rank EvalRank, best, unused []Card) {
+		if v == 's' || v == 'v' {
+			v = verb
+		}
+		typ.Desc(f, v, rank, best, unused)
+	}
+}
+
+// DescFunc is a description func, writing a description to f for the rank,
+// best, and unused cards. The 'd', 'u', and 'U' verbs are always written by
+// [DescType.Desc] (see [EvalDesc.Format]).
+type DescFunc func(f fmt.State, verb rune, rank EvalRank, best, unused []Card)
+
+// DescProfile is a description profile, overriding the Hi and Lo
+// descriptions registered for a type (see [TypeDesc]) without registering an
+// additional type, such as terse descriptions for a HUD and verbose
+// descriptions for a broadcast. Descriptions not overridden use the
+// registered descriptions. See [Eval.ProfileDesc].
+//
+// Example:
+//
+//	hud := &cardrank.DescProfile{
+//		Name: "hud",
+//		Hi: map[cardrank.DescType]cardrank.DescFunc{
+//			cardrank.DescCactus: cardrank.DescCactus.WithVerb('S'),
+//		},
+//	}
+type DescProfile struct {
+	// Name is the profile name.
+	Name string
+	// Hi are the Hi description overrides, by registered desc type.
+	Hi map[DescType]DescFunc
+	// Lo are the Lo description overrides, by registered desc type.
+	Lo map[DescType]DescFunc
+}
+
+// Func returns the profile's description func for the registered desc type.
+// Returns nil when the profile does not override the desc type.
+func (p *DescProfile) Func(typ DescType, low bool) DescFunc {
+	switch {
+	case p == nil:
+		return nil
+	case low:
+		return p.Lo[typ]
+	}
+	return p.Hi[typ]
+}
+
 // CactusDesc writes a Cactus description to f for the rank, best, and unused
 // cards.
 //
@@ -1416,7 +1942,7 @@ func CactusDesc(f fmt.State, verb rune, rank EvalRank, best, unused []Card) {
 		if verb != 'e' {
 			fmt.Fprintf(f, ", %P", best[0])
 			if verb != 'S' {
-				fmt.Fprintf(f, ", kicker %N", best[4])
+				kickers(f, best[4:])
 			}
 		}
 	case r == FullHouse:
@@ -1429,7 +1955,7 @@ func CactusDesc(f fmt.State, verb rune, rank EvalRank, best, unused []Card) {
 		if verb != 'e' {
 			fmt.Fprintf(f, ", %N-high", best[0])
 			if verb != 'S' {
-				fmt.Fprintf(f, ", kickers %N, %N, %N, %N", best[1], best[2], best[3], best[4])
+				kickers(f, best[1:])
 			}
 		}
 	case r == Straight:
@@ -1442,7 +1968,7 @@ func CactusDesc(f fmt.State, verb rune, rank EvalRank, best, unused []Card) {
 		if verb != 'e' {
 			fmt.Fprintf(f, ", %P", best[0])
 			if verb != 'S' {
-				fmt.Fprintf(f, ", kickers %N, %N", best[3], best[4])
+				kickers(f, best[3:])
 			}
 		}
 	case r == TwoPair:
@@ -1450,7 +1976,7 @@ func CactusDesc(f fmt.State, verb rune, rank EvalRank, best, unused []Card) {
 		if verb != 'e' {
 			fmt.Fprintf(f, ", %P over %P", best[0], best[2])
 			if verb != 'S' {
-				fmt.Fprintf(f, ", kicker %N", best[4])
+				kickers(f, best[4:])
 			}
 		}
 	case r == Pair:
@@ -1458,7 +1984,7 @@ func CactusDesc(f fmt.State, verb rune, rank EvalRank, best, unused []Card) {
 		if verb != 'e' {
 			fmt.Fprintf(f, ", %P", best[0])
 			if verb != 'S' {
-				fmt.Fprintf(f, ", kickers %N, %N, %N", best[2], best[3], best[4])
+				kickers(f, best[2:])
 			}
 		}
 	case r == Nothing:
@@ -1469,7 +1995,26 @@ func CactusDesc(f fmt.State, verb rune, rank EvalRank, best, unused []Card) {
 		// case 'S':
 		//	fmt.Fprintf(f, "%N, %N-high", best[0], best[1])
 		default:
-			fmt.Fprintf(f, "%N-high, kickers %N, %N, %N, %N", best[0], best[1], best[2], best[3], best[4])
+			fmt.Fprintf(f, "%N-high", best[0])
+			kickers(f, best[1:])
+		}
+	}
+}
+
+// kickers writes the kickers in v to f. Writes nothing when v is empty, such
+// as for a partial eval (see [Eval.HiPartial]).
+func kickers(f fmt.State, v []Card) {
+	switch len(v) {
+	case 0:
+	case 1:
+		fmt.Fprintf(f, ", kicker %N", v[0])
+	default:
+		fmt.Fprint(f, ", kickers ")
+		for i, c := range v {
+			if i != 0 {
+				_, _ = f.Write(elemSep)
+			}
+			c.Format(f, 'N')
 		}
 	}
 }
@@ -1482,10 +2027,22 @@ func FlushOverDesc(f fmt.State, verb rune, rank EvalRank, best, unused []Card) {
 
 // SokoDesc writes a [Soko] description to f for the rank, best, and unused cards.
 func SokoDesc(f fmt.State, verb rune, rank EvalRank, best, unused []Card) {
+	sokoDesc(f, verb, rank, TwoPair, best, unused)
+}
+
+// SokoUnderDesc writes a [Soko] description to f for the rank, best, and
+// unused cards, where a Four Flush and Four Straight are under a [Pair].
+func SokoUnderDesc(f fmt.State, verb rune, rank EvalRank, best, unused []Card) {
+	sokoDesc(f, verb, rank, Pair, best, unused)
+}
+
+// sokoDesc writes a [Soko] description to f for the rank, best, and unused
+// cards, where over is the rank directly over a Four Flush.
+func sokoDesc(f fmt.State, verb rune, rank, over EvalRank, best, unused []Card) {
 	switch {
-	case rank <= TwoPair:
+	case rank <= over:
 		CactusDesc(f, verb, rank, best, unused)
-	case rank <= sokoFlush:
+	case rank <= over+sokoFourFlushes:
 		fmt.Fprint(f, "Four Flush")
 		if verb != 'e' {
 			fmt.Fprintf(f, ", %N-high", best[0])
@@ -1493,7 +2050,7 @@ func SokoDesc(f fmt.State, verb rune, rank EvalRank, best, unused []Card) {
 				fmt.Fprintf(f, ", kickers %N, %N, %N, %N", best[1], best[2], best[3], best[4])
 			}
 		}
-	case rank <= sokoStraight:
+	case rank <= over+sokoFourFlushes+sokoFourStraights:
 		fmt.Fprint(f, "Four Straight")
 		if verb != 'e' {
 			fmt.Fprintf(f, ", %N-high", best[0])
@@ -1502,7 +2059,7 @@ func SokoDesc(f fmt.State, verb rune, rank EvalRank, best, unused []Card) {
 			}
 		}
 	default:
-		CactusDesc(f, verb, rank-sokoStraight+TwoPair, best, unused)
+		CactusDesc(f, verb, fromSoko(rank, over), best, unused)
 	}
 }
 
@@ -1525,14 +2082,40 @@ func LowDesc(f fmt.State, verb rune, rank EvalRank, best, unused []Card) {
 	}
 }
 
+// LowballNumber returns the canonical hand number for a [Lowball] rank, where
+// Seven, Five, Four, Three, Two-low is No. 1. Returns 0 when the rank is not
+// a numbered (unpaired, non-straight and non-flush) hand.
+func LowballNumber(rank EvalRank) int {
+	if r := rank.FromLowball(); rank != 0 && rank != Invalid && (Pair < r && r <= Nothing || r == Straight) {
+		return int(rank)
+	}
+	return 0
+}
+
 // LowballDesc writes a [Lowball] description to f for the rank, best, and
-// unused cards.
+// unused cards. The best 10 hands are described with their hand number (see
+// [LowballNumbered]).
 func LowballDesc(f fmt.State, verb rune, rank EvalRank, best, unused []Card) {
-	switch r := rank.FromLowball(); {
-	case rank <= StraightFlush:
+	lowballDesc(f, verb, rank, best, unused, 10)
+}
+
+// LowballNumbered returns a [Lowball] description func describing the best n
+// hands with their hand number (see [LowballNumber]), such as for a
+// [DescProfile] overriding [DescLowball].
+func LowballNumbered(n int) DescFunc {
+	return func(f fmt.State, verb rune, rank EvalRank, best, unused []Card) {
+		lowballDesc(f, verb, rank, best, unused, n)
+	}
+}
+
+// lowballDesc writes a [Lowball] description to f for the rank, best, and
+// unused cards, describing the best numbered hands with their hand number.
+func lowballDesc(f fmt.State, verb rune, rank EvalRank, best, unused []Card, numbered int) {
+	switch r, n := rank.FromLowball(), LowballNumber(rank); {
+	case n != 0 && n <= numbered:
 		LowDesc(f, verb, r, best, unused)
 		if verb != 'e' {
-			fmt.Fprintf(f, ", No. %d", int(rank))
+			fmt.Fprintf(f, ", No. %d", n)
 		}
 	case Pair < r && r <= Nothing || r == Straight:
 		LowDesc(f, verb, r, best, unused)
@@ -1544,16 +2127,90 @@ func LowballDesc(f fmt.State, verb rune, rank EvalRank, best, unused []Card) {
 }
 
 // RazzDesc writes a [Razz] description to f for the rank, best, and unused
-// cards.
+// cards. A [Joker] in best is described as the card it plays as (see
+// [RankCalifornia]).
 func RazzDesc(f fmt.State, verb rune, rank EvalRank, best, unused []Card) {
+	best = jokerLow(best)
 	switch {
-	case rank < aceFiveMax:
+	case rank == 0, rank == Invalid, rank < aceFiveMax:
 		LowDesc(f, verb, rank, best, unused)
 	default:
 		CactusDesc(f, verb, Invalid-rank, best, unused)
 	}
 }
 
+// RazzHand is the structured category of a [Razz] rank, allowing paired
+// hands to be displayed without parsing the description.
+type RazzHand struct {
+	// Category is the Cactus category of the hand, [Nothing] for an unpaired
+	// low, or [Invalid].
+	Category EvalRank
+	// Ranks are the ranks of the hand. For an unpaired low, the ranks are
+	// ordered high to low, with [Ace]'s low. Otherwise, the ranks are ordered
+	// by count and then high to low, with [Ace]'s high.
+	Ranks []Rank
+	// Qualified is true for an unpaired low.
+	Qualified bool
+}
+
+// NewRazzHand creates the structured category for a [Razz] rank and best
+// cards.
+func NewRazzHand(rank EvalRank, best []Card) RazzHand {
+	best = jokerLow(best)
+	switch {
+	case rank == 0, rank == Invalid, len(best) == 0:
+		return RazzHand{Category: Invalid}
+	case rank < aceFiveMax:
+		v := make([]Rank, len(best))
+		for i, c := range best {
+			v[i] = c.Rank()
+		}
+		slices.SortFunc(v, func(a, b Rank) int {
+			return cmp.Compare((b+1)%13, (a+1)%13)
+		})
+		return RazzHand{Category: Nothing, Ranks: v, Qualified: true}
+	}
+	var counts [13]int
+	for _, c := range best {
+		counts[c.Rank()]++
+	}
+	var v []Rank
+	for i := int(Ace); i >= 0; i-- {
+		if counts[i] != 0 {
+			v = append(v, Rank(i))
+		}
+	}
+	slices.SortStableFunc(v, func(a, b Rank) int {
+		return cmp.Compare(counts[b], counts[a])
+	})
+	return RazzHand{Category: (Invalid - rank).Fixed(), Ranks: v}
+}
+
+// Format satisfies the [fmt.Formatter] interface.
+func (h RazzHand) Format(f fmt.State, verb rune) {
+	switch {
+	case h.Category == Invalid:
+		fmt.Fprint(f, "None")
+		return
+	case h.Qualified:
+		fmt.Fprintf(f, "%s-low", h.Ranks[0].Name())
+		return
+	case h.Category == Pair:
+		fmt.Fprintf(f, "Pair of %s", h.Ranks[0].PluralName())
+	case h.Category == TwoPair:
+		fmt.Fprintf(f, "Two Pair, %s and %s", h.Ranks[0].PluralName(), h.Ranks[1].PluralName())
+	case h.Category == ThreeOfAKind:
+		fmt.Fprintf(f, "Three %s", h.Ranks[0].PluralName())
+	case h.Category == FullHouse:
+		fmt.Fprintf(f, "%s full of %s", h.Ranks[0].PluralName(), h.Ranks[1].PluralName())
+	case h.Category == FourOfAKind:
+		fmt.Fprintf(f, "Four %s", h.Ranks[0].PluralName())
+	default:
+		fmt.Fprint(f, h.Category.Title())
+	}
+	fmt.Fprint(f, " for low")
+}
+
 // HighDesc writes a [High] description to f for the rank, best, and unused
 // cards.
 func HighDesc(f fmt.State, verb rune, rank EvalRank, best, unused []Card) {
@@ -1564,9 +2221,67 @@ func HighDesc(f fmt.State, verb rune, rank EvalRank, best, unused []Card) {
 	}
 }
 
+// PartialDesc writes a partial description (see [RankPartial]) to f for the
+// rank, best, and unused cards.
+func PartialDesc(f fmt.State, verb rune, rank EvalRank, best, unused []Card) {
+	CactusDesc(f, verb, rank.FromPartial(), best, unused)
+}
+
 // ThreeDesc writes a [Three] description to f for the rank, best, and unused
 // cards.
 func ThreeDesc(f fmt.State, verb rune, rank EvalRank, best, unused []Card) {
+	switch {
+	case rank == 0, rank == Invalid:
+		fmt.Fprint(f, "None")
+	case len(best) == 2 && rank <= twoCardPair:
+		fmt.Fprint(f, Pair.Title())
+		if verb != 'e' {
+			fmt.Fprintf(f, ", %P", best[0])
+		}
+	case len(best) == 2:
+		if verb != 'e' && verb != 'S' {
+			fmt.Fprintf(f, "%N-high, kicker %N", best[0], best[1])
+		} else {
+			fmt.Fprintf(f, "%N-high", best[0])
+		}
+	case rank <= ThreeCardStraightFlush, ThreeCardThreeOfAKind < rank && rank <= ThreeCardStraight:
+		r := StraightFlush
+		if ThreeCardThreeOfAKind < rank {
+			r = Straight
+		}
+		fmt.Fprint(f, r.Title())
+		if verb != 'e' {
+			fmt.Fprintf(f, ", %N-high", best[0])
+		}
+	case rank <= ThreeCardThreeOfAKind:
+		fmt.Fprint(f, ThreeOfAKind.Title())
+		if verb != 'e' {
+			fmt.Fprintf(f, ", %P", best[0])
+		}
+	case rank <= ThreeCardFlush:
+		fmt.Fprint(f, Flush.Title())
+		if verb != 'e' {
+			fmt.Fprintf(f, ", %N-high", best[0])
+			if verb != 'S' {
+				fmt.Fprintf(f, ", kickers %N, %N", best[1], best[2])
+			}
+		}
+	case rank <= ThreeCardPair:
+		fmt.Fprint(f, Pair.Title())
+		if verb != 'e' {
+			fmt.Fprintf(f, ", %P", best[0])
+			if verb != 'S' {
+				fmt.Fprintf(f, ", kicker %N", best[2])
+			}
+		}
+	default:
+		switch verb {
+		case 'e', 'S':
+			fmt.Fprintf(f, "%N-high", best[0])
+		default:
+			fmt.Fprintf(f, "%N-high, kickers %N, %N", best[0], best[1], best[2])
+		}
+	}
 }
 
 // ordinal returns the ordinal string for n (1st, 2nd, ...).
@@ -1584,3 +2299,18 @@ func ordinal(n int) string {
 
 // elemSep is the element separator used for emitting slices.
 var elemSep = []byte{',', ' '}
+
+// unusedDesc writes the unused cards to f for the 'u' (as cards) and 'U' (as
+// rank names) verbs.
+func unusedDesc(f fmt.State, verb rune, unused []Card) {
+	if verb == 'u' {
+		Formatter(unused).Format(f, 's')
+		return
+	}
+	for i, c := range unused {
+		if i != 0 {
+			_, _ = f.Write(elemSep)
+		}
+		c.Format(f, 'N')
+	}
+}
@@ -1,6 +1,7 @@
 package cardrank
 
 import (
+	"errors"
 	"fmt"
 	"math/rand"
 	"slices"
@@ -218,6 +219,69 @@ func TestRazz(t *testing.T) {
 	}
 }
 
+func TestRazzHand(t *testing.T) {
+	tests := []struct {
+		v         string
+		category  EvalRank
+		qualified bool
+		s         string
+	}{
+		{"Kh Qh Jh Th 9h Ks Qs", Nothing, true, "King-low"},
+		{"Ah 2c 3d 4s 8h Kc Kd", Nothing, true, "Eight-low"},
+		{"6h 6c Kh Qd Jd Ks Qs", Pair, false, "Pair of Sixes for low"},
+		{"Kh Kd Qd Qs Jh Ks Js", TwoPair, false, "Two Pair, Queens and Jacks for low"},
+		{"2h 2c 2d 2s As Ks Qs", Pair, false, "Pair of Twos for low"},
+		{"Ah Ac Ad Ks Kh Ks Qs", TwoPair, false, "Two Pair, Aces and Kings for low"},
+		{"Ah Ac Ad 9s 9h 9d 9c", FullHouse, false, "Nines full of Aces for low"},
+	}
+	for i, test := range tests {
+		ev := Razz.Eval(Must(test.v), nil)
+		h := NewRazzHand(ev.HiRank, ev.HiBest)
+		if h.Category != test.category {
+			t.Errorf("test %d expected %s, got: %s", i, test.category, h.Category)
+		}
+		if h.Qualified != test.qualified {
+			t.Errorf("test %d expected %t, got: %t", i, test.qualified, h.Qualified)
+		}
+		if s := fmt.Sprintf("%s", h); s != test.s {
+			t.Errorf("test %d expected %q, got: %q", i, test.s, s)
+		}
+	}
+	if h := NewRazzHand(Invalid, nil); h.Category != Invalid || h.Qualified {
+		t.Errorf("expected %s, got: %v", Invalid, h)
+	}
+}
+
+func TestCalifornia(t *testing.T) {
+	tests := []struct {
+		v   string
+		b   string
+		exp EvalRank
+		s   string
+	}{
+		{"Jk 4h 3h 2c Ah", "Jk 4h 3h 2c Ah", 31, "Five, Four, Three, Two, Ace-low"},
+		{"Ah 2c 3h Jk 5h", "5h Jk 3h 2c Ah", 31, "Five, Four, Three, Two, Ace-low"},
+		{"Kh Jk 3h 2c Ah", "Kh Jk 3h 2c Ah", 4111, "King, Four, Three, Two, Ace-low"},
+		{"7h 5h 4h 3c 2h", "7h 5h 4h 3c 2h", 94, "Seven, Five, Four, Three, Two-low"},
+		{"Jk 7h 6h 5c 4h 3d 2s", "5c 4h 3d 2s Jk", 31, "Five, Four, Three, Two, Ace-low"},
+		{"Jk Ah Ac 2c 2d", "Ac Ah 2c 2d Jk", 62936, "Two Pair, Aces over Twos, kicker Three"},
+		{"Jk Ac Ad As 2h", "Ac Ad As Jk 2h", 63860, "Three of a Kind, Aces, kickers Three, Two"},
+	}
+	for i, test := range tests {
+		pocket := Must(test.v)
+		ev := California.Eval(pocket, nil)
+		if ev.HiRank != test.exp {
+			t.Errorf("test %d %v expected rank %d, got: %d", i, pocket, test.exp, ev.HiRank)
+		}
+		if best := Must(test.b); !slices.Equal(ev.HiBest, best) {
+			t.Errorf("test %d expected %v, got: %v", i, best, ev.HiBest)
+		}
+		if s, exp := fmt.Sprintf("%s", ev.Desc(false)), test.s; s != exp {
+			t.Errorf("test %d expected %q, got: %q", i, exp, s)
+		}
+	}
+}
+
 func TestBadugi(t *testing.T) {
 	tests := []struct {
 		v   string
@@ -316,6 +380,287 @@ func TestLowball(t *testing.T) {
 	}
 }
 
+func TestLowballNumber(t *testing.T) {
+	desc := LowballNumbered(20)
+	tests := []struct {
+		v   string
+		exp int
+		s   string
+	}{
+		{"3h 5h 7h 4h 2c", 1, "Seven, Five, Four, Three, Two-low, No. 1"},
+		{"4h 7h 8h 6h 2c", 15, "Eight, Seven, Six, Four, Two-low, No. 15"},
+		{"3h 5h 9h 4h 2c", 19, "Nine, Five, Four, Three, Two-low, No. 19"},
+		{"3c 5c As 4s 2d", 785, "Ace, Five, Four, Three, Two-low"},
+		{"Kh Ac Ad As Kd", 0, "Full House, Aces full of Kings"},
+	}
+	for i, test := range tests {
+		ev := Lowball.Eval(Must(test.v), nil)
+		if n := LowballNumber(ev.HiRank); n != test.exp {
+			t.Errorf("test %d expected %d, got: %d", i, test.exp, n)
+		}
+		if s := fmt.Sprintf("%s", descFormatter{desc, ev}); s != test.s {
+			t.Errorf("test %d expected %q, got: %q", i, test.s, s)
+		}
+	}
+	// default numbers the best 10
+	if s, exp := fmt.Sprintf("%s", Lowball.Eval(Must("4h 7h 8h 6h 2c"), nil).Desc(false)), "Eight, Seven, Six, Four, Two-low"; s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+	if n := LowballNumber(Invalid); n != 0 {
+		t.Errorf("expected 0, got: %d", n)
+	}
+}
+
+// descFormatter formats the Hi of an eval using a description func.
+type descFormatter struct {
+	f  DescFunc
+	ev *Eval
+}
+
+// Format satisfies the [fmt.Formatter] interface.
+func (d descFormatter) Format(f fmt.State, verb rune) {
+	d.f(f, verb, d.ev.HiRank, d.ev.HiBest, d.ev.HiUnused)
+}
+
+func TestLowballAceSix(t *testing.T) {
+	tests := []struct {
+		v   string
+		b   string
+		exp EvalRank
+		s   string
+	}{
+		{"6h 4h 3h 2c Ah", "6h 4h 3h 2c Ah", 1, "Six, Four, Three, Two, Ace-low, No. 1"},
+		{"Ah 6h 5h 3h 2c", "6h 5h 3h 2c Ah", 2, "Six, Five, Three, Two, Ace-low, No. 2"},
+		{"3h 7h 4h 2c Ah", "7h 4h 3h 2c Ah", 5, "Seven, Four, Three, Two, Ace-low, No. 5"},
+		{"Kh 4h 3h 2c Ah", "Kh 4h 3h 2c Ah", 785, "King, Four, Three, Two, Ace-low"},
+		{"Kh Qh Jh Tc Ah", "Kh Qh Jh Tc Ah", 1271, "King, Queen, Jack, Ten, Ace-low"},
+		{"Ac Ad 2h 3s 4h", "Ad Ac 4h 3s 2h", 1279, "Pair, Aces, kickers Four, Three, Two"},
+		{"2c 2d Ah 3s 4h", "2d 2c 4h 3s Ah", 1499, "Pair, Twos, kickers Four, Three, Ace"},
+		{"Ac Ad 2h 2s 4h", "2s 2h Ad Ac 4h", 4140, "Two Pair, Twos over Aces, kicker Four"},
+		{"5h 4h 3h 2c Ah", "5h 4h 3h 2c Ah", 5855, "Straight, Five-high"},
+		{"2h 5h 4h 3c 6h", "6h 5h 4h 3c 2h", 5856, "Straight, Six-high"},
+		{"Kh Qh Jh Tc 9h", "Kh Qh Jh Tc 9h", 5863, "Straight, King-high"},
+		{"7h 6h 4h 3h Ah", "7h 6h 4h 3h Ah", 5875, "Flush, Seven-high, kickers Six, Four, Three, Ace"},
+		{"Kh Ac Ad As Kd", "As Ad Ac Kh Kd", 7153, "Full House, Aces full of Kings"},
+		{"Kc Kd Ks Kh Qh", "Ks Kh Kd Kc Qh", 7453, "Four of a Kind, Kings, kicker Queen"},
+	}
+	for i, test := range tests {
+		pocket := Must(test.v)
+		ev := LowballAceSix.Eval(pocket, nil)
+		if ev.HiRank != test.exp {
+			t.Errorf("test %d %v expected rank %d, got: %d", i, pocket, test.exp, ev.HiRank)
+		}
+		if best := Must(test.b); !slices.Equal(ev.HiBest, best) {
+			t.Errorf("test %d expected %v, got: %v", i, best, ev.HiBest)
+		}
+		if s, exp := fmt.Sprintf("%s", ev.Desc(false)), test.s; s != exp {
+			t.Errorf("test %d expected %q, got: %q", i, exp, s)
+		}
+	}
+}
+
+func TestGuts(t *testing.T) {
+	tests := []struct {
+		typ Type
+		v   string
+		b   string
+		exp EvalRank
+		s   string
+	}{
+		{Guts, "Ah Kh Qh", "Ah Kh Qh", 1, "Straight Flush, Ace-high"},
+		{Guts, "3h 2h Ah", "3h 2h Ah", 12, "Straight Flush, Three-high"},
+		{Guts, "As Ah Ad", "Ad Ah As", 13, "Three of a Kind, Aces"},
+		{Guts, "2s 2h 2d", "2d 2h 2s", 25, "Three of a Kind, Twos"},
+		{Guts, "Ac Kh Qh", "Ac Kh Qh", 26, "Straight, Ace-high"},
+		{Guts, "2c 3h Ad", "3h 2c Ad", 37, "Straight, Three-high"},
+		{Guts, "Ah Kh Jh", "Ah Kh Jh", 38, "Flush, Ace-high, kickers King, Jack"},
+		{Guts, "Kd Ac As", "Ac As Kd", 312, "Pair, Aces, kicker King"},
+		{Guts, "Ac Kd Jh", "Ac Kd Jh", 468, "Ace-high, kickers King, Jack"},
+		{Guts, "5c 3d 2h", "5c 3d 2h", 741, "Five-high, kickers Three, Two"},
+		{GutsTwo, "As Ac", "Ac As", 1, "Pair, Aces"},
+		{GutsTwo, "2s 2c", "2c 2s", 13, "Pair, Twos"},
+		{GutsTwo, "Kd As", "As Kd", 14, "Ace-high, kicker King"},
+		{GutsTwo, "2d 3s", "3s 2d", 91, "Three-high, kicker Two"},
+	}
+	for i, test := range tests {
+		pocket := Must(test.v)
+		ev := test.typ.Eval(pocket, nil)
+		if ev.HiRank != test.exp {
+			t.Errorf("test %d %v expected rank %d, got: %d", i, pocket, test.exp, ev.HiRank)
+		}
+		if best := Must(test.b); !slices.Equal(ev.HiBest, best) {
+			t.Errorf("test %d expected %v, got: %v", i, best, ev.HiBest)
+		}
+		if s, exp := fmt.Sprintf("%s", ev.Desc(false)), test.s; s != exp {
+			t.Errorf("test %d expected %q, got: %q", i, exp, s)
+		}
+	}
+}
+
+func TestNuts(t *testing.T) {
+	tests := []struct {
+		typ    Type
+		board  string
+		pocket string
+		exp    EvalRank
+		nuts   bool
+	}{
+		{Holdem, "Ah Kh Qh 2c 3d", "Jh Th", 1, true},
+		{Holdem, "Ah Kh Qh 2c 3d", "As Ad", 1, false},
+		{Holdem, "As Ks 7d 7c 2h", "7h 7s", 95, true},
+		{Holdem, "As Ks 7d 7c 2h", "Ac Ad", 95, false},
+		{Holdem, "2c 7d 9h Js", "Tc 8c", 1603, true},
+		{Holdem, "2c 7d 9h Js", "Jc Jd", 1603, false},
+		{Omaha, "Ah Kh Qh 2c 3d", "Jh Th 2s 3s", 1, true},
+		{Omaha, "Ah Kh Qh 2c 3d", "9h 8h 2s 3s", 1, false},
+	}
+	for i, test := range tests {
+		board := Must(test.board)
+		r, pocket := test.typ.NutRank(board)
+		if r != test.exp {
+			t.Errorf("test %d expected %d, got: %d", i, test.exp, r)
+		}
+		if ev := test.typ.Eval(pocket, board); ev.HiRank != r {
+			t.Errorf("test %d expected %v to make %d, got: %d", i, pocket, r, ev.HiRank)
+		}
+		if nuts := test.typ.IsNuts(Must(test.pocket), board); nuts != test.nuts {
+			t.Errorf("test %d expected %t, got: %t", i, test.nuts, nuts)
+		}
+	}
+	if r, _ := Stud.NutRank(nil); r != Invalid {
+		t.Errorf("expected %d, got: %d", Invalid, r)
+	}
+	r := rand.New(rand.NewSource(1))
+	for _, typ := range []Type{Holdem, Omaha, Short, Manila} {
+		for i := range 3 {
+			board := typ.DeckType().Shuffle(r, 1).Draw(typ.Board())
+			b, nuts := newBoardEval(typ, board, false), Invalid
+			for g, v := NewCombinGen(unusedCards(typ, board), typ.Pocket()); g.Next(); {
+				nuts = min(nuts, b.Eval(v).HiRank)
+			}
+			if n, _ := typ.NutRank(board); n != nuts {
+				t.Errorf("%s test %d expected %d, got: %d", typ, i, nuts, n)
+			}
+		}
+	}
+}
+
+func TestBeatingCombos(t *testing.T) {
+	tests := []struct {
+		typ    Type
+		pocket string
+		board  string
+		exp    int
+	}{
+		{Holdem, "Jh Th", "Ah Kh Qh 2c 3d", 0},
+		{Holdem, "Qs Qd", "Ah Kh Qc 7c 2d", 22},
+		{Holdem, "Ac Kc", "As Ks 7d 7c 2h", 92},
+		{Holdem, "As Ad", "Ah 7c 2d 9s 4h", 16},
+		{Holdem, "2s 2h", "Ac Kd 7s 2c 2d", 0},
+		{Omaha, "Jh Th 2s 3s", "Ah Kh Qh 2c 3d", 0},
+	}
+	for i, test := range tests {
+		pocket, board := Must(test.pocket), Must(test.board)
+		count, v := BeatingCombos(test.typ, pocket, board, true)
+		if count != test.exp || len(v) != count {
+			t.Errorf("test %d expected %d, got: %d (%d)", i, test.exp, count, len(v))
+		}
+		hero := test.typ.Eval(pocket, board)
+		for _, c := range v {
+			if ev := test.typ.Eval(c, board); hero.HiRank <= ev.HiRank {
+				t.Errorf("test %d expected %v to beat %v", i, c, pocket)
+			}
+		}
+		if n, v := BeatingCombos(test.typ, pocket, board, false); n != count || v != nil {
+			t.Errorf("test %d expected %d, got: %d (%d)", i, count, n, len(v))
+		}
+		if nuts := test.typ.IsNuts(pocket, board); nuts != (count == 0) {
+			t.Errorf("test %d expected %t, got: %t", i, count == 0, nuts)
+		}
+	}
+}
+
+func TestTypeCombos(t *testing.T) {
+	tests := []struct {
+		typ       Type
+		remaining int
+		pocket    int
+		board     int
+		eval      int
+	}{
+		{Holdem, 48, 1326, 1712304, 21},
+		{Omaha, 44, 270725, 1086008, 60},
+		{OmahaFive, 42, 2598960, 850668, 100},
+		{OmahaSix, 40, 20358520, 658008, 150},
+		{Manila, 28, 496, 98280, 10},
+		{Stud, 45, 133784560, 1, 21},
+		{Badugi, 48, 270725, 1, 1},
+	}
+	for i, test := range tests {
+		if n := test.typ.PocketCombos(); n != test.pocket {
+			t.Errorf("test %d %s expected %d, got: %d", i, test.typ, test.pocket, n)
+		}
+		if n := test.typ.BoardCombos(test.remaining); n != test.board {
+			t.Errorf("test %d %s expected %d, got: %d", i, test.typ, test.board, n)
+		}
+		if n := test.typ.EvalCombos(); n != test.eval {
+			t.Errorf("test %d %s expected %d, got: %d", i, test.typ, test.eval, n)
+		}
+	}
+	for _, typ := range Types() {
+		if typ.EvalCombos() == 0 {
+			t.Errorf("%s expected take table", typ)
+		}
+	}
+	if n := EvalOmaha.Combos(7, 5); n != 0 {
+		t.Errorf("expected 0, got: %d", n)
+	}
+	if n := EvalCactus.Combos(5, 5); n != 0 {
+		t.Errorf("expected 0, got: %d", n)
+	}
+}
+
+func TestWeakestWinningHand(t *testing.T) {
+	tests := []struct {
+		typ    Type
+		pocket string
+		board  string
+		exp    string
+	}{
+		{Holdem, "Qc Jd", "Ah Kd 7c 4s 2h", "2c 3c"},
+		{Holdem, "Qs Qd", "Ah Kh Qc 7c 2d", "Ks Kd"},
+		{Holdem, "Jh Th", "Ah Kh Qh 2c 3d", ""},
+		{Omaha, "Jh Th 2s 3s", "Ah Kh Qh 2c 3d", ""},
+		{Holdem, "2c Th", "Ah Kd 7c 4s 9h", "Td 8c"},
+	}
+	for i, test := range tests {
+		board := Must(test.board)
+		hero := test.typ.Eval(Must(test.pocket), board)
+		r, pocket := WeakestWinningHand(test.typ, Must(test.pocket), board)
+		if slices.ContainsFunc(pocket, func(c Card) bool { return slices.Contains(Must(test.pocket), c) }) {
+			t.Errorf("test %d expected %v to not use the hero's cards", i, pocket)
+		}
+		if test.exp == "" {
+			if r != Invalid || pocket != nil {
+				t.Errorf("test %d expected %d, got: %d %v", i, Invalid, r, pocket)
+			}
+			continue
+		}
+		if exp := test.typ.Eval(Must(test.exp), board).HiRank; r != exp {
+			t.Errorf("test %d expected %d, got: %d", i, exp, r)
+		}
+		if ev := test.typ.Eval(pocket, board); ev.HiRank != r || hero.HiRank <= r {
+			t.Errorf("test %d expected %v to make %d, got: %d", i, pocket, r, ev.HiRank)
+		}
+		_, v := BeatingCombos(test.typ, Must(test.pocket), board, true)
+		for _, c := range v {
+			if ev := test.typ.Eval(c, board); r < ev.HiRank {
+				t.Errorf("test %d expected %v to not be weaker than %d", i, c, r)
+			}
+		}
+	}
+}
+
 func TestTypeComp(t *testing.T) {
 	tests := []struct {
 		typ   Type
@@ -391,6 +736,46 @@ func TestNumberedStreets(t *testing.T) {
 	}
 }
 
+func TestStreetDescKind(t *testing.T) {
+	tests := []struct {
+		typ    Type
+		up     bool
+		pocket string
+		board  string
+		draw   string
+	}{
+		{Holdem, false, "p", "ftr", ""},
+		{Stud, true, "34567", "", ""},
+		{Draw, false, "5", "", "6"},
+		{LowballTriple, false, "5", "", "678"},
+		{Fusion, false, "pft", "ftr", ""},
+		{StudFive, true, "2345", "", ""},
+	}
+	for i, test := range tests {
+		if up := test.typ.Up(); up != test.up {
+			t.Errorf("test %d %s expected %t, got: %t", i, test.typ, test.up, up)
+		}
+		var pocket, board, draw string
+		for j, street := range test.typ.Streets() {
+			if street.Round != j {
+				t.Errorf("test %d %s expected round %d, got: %d", i, test.typ, j, street.Round)
+			}
+			if street.IsPocket() {
+				pocket += string(street.Id)
+			}
+			if street.IsBoard() {
+				board += string(street.Id)
+			}
+			if street.IsDraw() {
+				draw += string(street.Id)
+			}
+		}
+		if pocket != test.pocket || board != test.board || draw != test.draw {
+			t.Errorf("test %d %s expected %q %q %q, got: %q %q %q", i, test.typ, test.pocket, test.board, test.draw, pocket, board, draw)
+		}
+	}
+}
+
 func TestTypeUnmarshal(t *testing.T) {
 	tests := []struct {
 		s   string
@@ -492,3 +877,173 @@ func TestDescType(t *testing.T) {
 		}
 	}
 }
+
+func TestDescUnused(t *testing.T) {
+	tests := []struct {
+		typ    Type
+		v      string
+		f      DescFunc
+		u      string
+		exp    string
+		expDsc string
+	}{
+		{Holdem, "Ah Kh Qh Jh Th 3c 2d", CactusDesc, "[3c 2d]", "Three, Two", "Straight Flush, Ace-high, Royal"},
+		{Short, "Ah Kh Qh 9h 8h 7c 6d", FlushOverDesc, "[7c 6d]", "Seven, Six", "Flush, Ace-high, kickers King, Queen, Nine, Eight"},
+		{Lowball, "7h 2h 3c 4h 6h Kc Kd", LowballDesc, "[Kc Kd]", "King, King", "Seven, Six, Four, Three, Two-low, No. 2"},
+		{Razz, "5h 4h 3h 2h Ah Qs Js", RazzDesc, "[Qs Js]", "Queen, Jack", "Five, Four, Three, Two, Ace-low"},
+		{Soko, "4h Th 6h 9c 7h", SokoDesc, "[]", "", "Four Flush, Ten-high, kickers Seven, Six, Four, Nine"},
+	}
+	for i, test := range tests {
+		ev := test.typ.Eval(Must(test.v), nil)
+		desc := ev.Desc(false)
+		if s := fmt.Sprintf("%u", desc); s != test.u {
+			t.Errorf("test %d %s expected %q, got: %q", i, test.typ, test.u, s)
+		}
+		if s := fmt.Sprintf("%U", desc); s != test.exp {
+			t.Errorf("test %d %s expected %q, got: %q", i, test.typ, test.exp, s)
+		}
+		if s := fmt.Sprintf("%U", ev); s != test.exp {
+			t.Errorf("test %d %s expected %q, got: %q", i, test.typ, test.exp, s)
+		}
+		if s := fmt.Sprintf("%s", descFunc{test.f, desc}); s != test.expDsc {
+			t.Errorf("test %d %s expected %q, got: %q", i, test.typ, test.expDsc, s)
+		}
+	}
+	// low
+	ev := OmahaHiLo.Eval(Must("Ah 2h Kc Kd"), Must("3c 4d 8s Ts Js"))
+	if s, exp := fmt.Sprintf("%u", ev.Desc(true)), "[Kc Kd Js Ts]"; s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+	if s, exp := fmt.Sprintf("%U", ev.Desc(true)), "King, King, Jack, Ten"; s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+}
+
+// descFunc wraps a desc func as a [fmt.Formatter].
+type descFunc struct {
+	f    DescFunc
+	desc *EvalDesc
+}
+
+// Format satisfies the [fmt.Formatter] interface.
+func (d descFunc) Format(f fmt.State, verb rune) {
+	d.f(f, verb, d.desc.Rank, d.desc.Best, d.desc.Unused)
+}
+
+func TestDescProfile(t *testing.T) {
+	hud := &DescProfile{
+		Name: "hud",
+		Hi: map[DescType]DescFunc{
+			DescCactus: DescCactus.WithVerb('S'),
+		},
+		Lo: map[DescType]DescFunc{
+			DescLow: func(f fmt.State, verb rune, rank EvalRank, best, unused []Card) {
+				fmt.Fprintf(f, "%N-low", best[0])
+			},
+		},
+	}
+	ev := OmahaHiLo.Eval(Must("Ah 2h Kc Kd"), Must("3c 4d 8s Ks Js"))
+	tests := []struct {
+		p   *DescProfile
+		low bool
+		exp string
+	}{
+		{nil, false, "Three of a Kind, Kings, kickers Jack, Eight"},
+		{nil, true, "Eight, Four, Three, Two, Ace-low"},
+		{hud, false, "Three of a Kind, Kings"},
+		{hud, true, "Eight-low"},
+		{&DescProfile{}, false, "Three of a Kind, Kings, kickers Jack, Eight"},
+	}
+	for i, test := range tests {
+		if s := fmt.Sprintf("%s", ev.ProfileDesc(test.p, test.low)); s != test.exp {
+			t.Errorf("test %d expected %q, got: %q", i, test.exp, s)
+		}
+	}
+	if s, exp := fmt.Sprintf("%e", ev.ProfileDesc(hud, false)), "Three of a Kind"; s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+	// lowball numbered
+	lowball := &DescProfile{
+		Hi: map[DescType]DescFunc{
+			DescLowball: LowballNumbered(20),
+		},
+	}
+	if s, exp := fmt.Sprintf("%s", Lowball.Eval(Must("7h 5c 4s 3d 2c"), nil).ProfileDesc(lowball, false)), "Seven, Five, Four, Three, Two-low, No. 1"; s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+	// unused verbs are written for profile desc funcs
+	f := Holdem.Eval(Must("Ah Kh Qh Jh Th 3c 2d"), nil).ProfileDesc(hud, false)
+	if s, exp := fmt.Sprintf("%u", f), "[3c 2d]"; s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+	if s, exp := fmt.Sprintf("%U", f), "Three, Two"; s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+	lo := OmahaHiLo.Eval(Must("Ah 2h Kc Kd"), Must("3c 4d 8s Ts Js"))
+	if s, exp := fmt.Sprintf("%U", lo.ProfileDesc(hud, true)), "King, King, Jack, Ten"; s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+	// dealer
+	v := DeckFrench.Unshuffled()
+	d := NewDealer(Holdem.Desc(), DeckOf(v...), 2, WithDescProfile(hud))
+	for d.Next() {
+	}
+	for d.NextResult() {
+		_, res := d.Result()
+		hi, _ := res.Win()
+		if s, exp := fmt.Sprintf("%S", hi), "0, 1 split with Flush, King-high"; s != exp {
+			t.Errorf("expected %q, got: %q", exp, s)
+		}
+	}
+}
+
+func TestWithLowQualifier(t *testing.T) {
+	tests := []struct {
+		id   string
+		opts []TypeOption
+		exp  Rank
+		err  error
+	}{
+		{"q1", nil, Eight, nil},
+		{"q2", []TypeOption{WithLowQualifier(Six)}, Six, nil},
+		{"q3", []TypeOption{WithLowQualifier(Ace)}, Ace, nil},
+		{"q4", []TypeOption{WithLowQualifier(Two)}, InvalidRank, ErrInvalidType},
+		{"q5", []TypeOption{WithLowQualifier(Four)}, InvalidRank, ErrInvalidType},
+	}
+	for i, test := range tests {
+		typ, err := IdToType(test.id)
+		if err != nil {
+			t.Fatalf("test %d expected no error, got: %v", i, err)
+		}
+		desc, err := NewType(test.id, typ, test.id, append([]TypeOption{WithHoldem(true)}, test.opts...)...)
+		if err != nil {
+			t.Fatalf("test %d expected no error, got: %v", i, err)
+		}
+		switch err := RegisterType(*desc); {
+		case test.err != nil && !errors.Is(err, test.err):
+			t.Errorf("test %d expected error %v, got: %v", i, test.err, err)
+		case test.err == nil && err != nil:
+			t.Errorf("test %d expected no error, got: %v", i, err)
+		case test.err == nil:
+			if q := typ.Qualifier(); q != test.exp {
+				t.Errorf("test %d expected %s, got: %s", i, test.exp.Name(), q.Name())
+			}
+		}
+		delete(descs, typ)
+		delete(calcs, typ)
+		delete(evals, typ)
+	}
+	// unset qualifier on a registered description defaults to eight
+	typ, _ := IdToType("q6")
+	if err := RegisterType(TypeDesc{Type: typ, Name: "q6", Low: true, Eval: EvalCactus}); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	defer func() {
+		delete(descs, typ)
+		delete(calcs, typ)
+		delete(evals, typ)
+	}()
+	if q := typ.Qualifier(); q != Eight {
+		t.Errorf("expected %s, got: %s", Eight.Name(), q.Name())
+	}
+}
@@ -0,0 +1,420 @@
+package cardrank
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"math/bits"
+)
+
+// PayHand is a video poker pay table hand.
+type PayHand uint8
+
+// Pay hands.
+const (
+	PayNothing PayHand = iota
+	PayJacksOrBetter
+	PayTwoPair
+	PayThreeOfAKind
+	PayStraight
+	PayFlush
+	PayFullHouse
+	PayFourOfAKind
+	PayStraightFlush
+	PayFiveOfAKind
+	PayWildRoyalFlush
+	PayFourDeuces
+	PayRoyalFlush
+	PayPair
+	PayFourFlush
+	PayFourStraight
+)
+
+// Name returns the pay hand name.
+func (h PayHand) Name() string {
+	switch h {
+	case PayNothing:
+		return "Nothing"
+	case PayJacksOrBetter:
+		return "Jacks or Better"
+	case PayTwoPair:
+		return "Two Pair"
+	case PayThreeOfAKind:
+		return "Three of a Kind"
+	case PayStraight:
+		return "Straight"
+	case PayFlush:
+		return "Flush"
+	case PayFullHouse:
+		return "Full House"
+	case PayFourOfAKind:
+		return "Four of a Kind"
+	case PayStraightFlush:
+		return "Straight Flush"
+	case PayFiveOfAKind:
+		return "Five of a Kind"
+	case PayWildRoyalFlush:
+		return "Wild Royal Flush"
+	case PayFourDeuces:
+		return "Four Deuces"
+	case PayRoyalFlush:
+		return "Royal Flush"
+	case PayPair:
+		return "Pair"
+	case PayFourFlush:
+		return "Four Flush"
+	case PayFourStraight:
+		return "Four Straight"
+	}
+	return ""
+}
+
+// String satisfies the [fmt.Stringer] interface.
+func (h PayHand) String() string {
+	return h.Name()
+}
+
+// PayTable is a video poker pay table.
+type PayTable struct {
+	// Name is the pay table name.
+	Name string
+	// Wild is whether deuces are wild.
+	Wild bool
+	// Pays are the amounts paid per unit bet for each hand.
+	Pays Payout
+}
+
+// NewJacksOrBetterTable creates a [Jack]'s-or-better pay table, with the
+// pays for a [FullHouse] and [Flush] (ie, 9/6 for a "full pay" table).
+func NewJacksOrBetterTable(fullHouse, flush float64) *PayTable {
+	return &PayTable{
+		Name: fmt.Sprintf("%g/%g Jacks or Better", fullHouse, flush),
+		Pays: map[PayHand]float64{
+			PayRoyalFlush:    800,
+			PayStraightFlush: 50,
+			PayFourOfAKind:   25,
+			PayFullHouse:     fullHouse,
+			PayFlush:         flush,
+			PayStraight:      4,
+			PayThreeOfAKind:  3,
+			PayTwoPair:       2,
+			PayJacksOrBetter: 1,
+		},
+	}
+}
+
+// NewDeucesWildTable creates a "full pay" Deuces Wild pay table.
+func NewDeucesWildTable() *PayTable {
+	return &PayTable{
+		Name: "Deuces Wild",
+		Wild: true,
+		Pays: map[PayHand]float64{
+			PayRoyalFlush:     800,
+			PayFourDeuces:     200,
+			PayWildRoyalFlush: 25,
+			PayFiveOfAKind:    15,
+			PayStraightFlush:  9,
+			PayFourOfAKind:    5,
+			PayFullHouse:      3,
+			PayFlush:          2,
+			PayStraight:       2,
+			PayThreeOfAKind:   1,
+		},
+	}
+}
+
+// Hand returns the pay hand for the 5 card hand. Returns [PayNothing] when
+// the hand does not have exactly 5 cards.
+func (t *PayTable) Hand(hand []Card) PayHand {
+	switch {
+	case len(hand) != 5:
+		return PayNothing
+	case t.Wild:
+		return deucesWildHand(hand)
+	}
+	if h := payHand(RankCactus(hand[0], hand[1], hand[2], hand[3], hand[4])); h != PayPair {
+		return h
+	}
+	return PayNothing
+}
+
+// payHand returns the pay hand for the Cactus rank, where a [Pair] lower
+// than [Jack]'s is a [PayPair].
+func payHand(r EvalRank) PayHand {
+	switch {
+	case r == 1:
+		return PayRoyalFlush
+	case r <= StraightFlush:
+		return PayStraightFlush
+	case r <= FourOfAKind:
+		return PayFourOfAKind
+	case r <= FullHouse:
+		return PayFullHouse
+	case r <= Flush:
+		return PayFlush
+	case r <= Straight:
+		return PayStraight
+	case r <= ThreeOfAKind:
+		return PayThreeOfAKind
+	case r <= TwoPair:
+		return PayTwoPair
+	case r <= jacksOrBetterMax:
+		return PayJacksOrBetter
+	case r <= Pair:
+		return PayPair
+	}
+	return PayNothing
+}
+
+// Pay returns the amount paid per unit bet for the 5 card hand.
+func (t *PayTable) Pay(hand []Card) float64 {
+	return t.Pays[t.Hand(hand)]
+}
+
+// HoldValue returns the expected value per unit bet of holding the cards of
+// the hand in the hold mask (where bit i holds hand[i]), by enumerating every
+// draw from the remaining cards of the deck.
+func (t *PayTable) HoldValue(ctx context.Context, hand []Card, hold uint8) (float64, error) {
+	if len(hand) != 5 {
+		return 0, ErrInsufficientCards
+	}
+	v := heldCards(hand, hold)
+	k, held := 5-len(v), len(v)
+	if k == 0 {
+		return t.Pay(v), nil
+	}
+	v = append(v, make([]Card, k)...)
+	var total float64
+	var count int
+	for g, d := NewCombinGen(DeckFrench.Exclude(hand), k); g.Next(); {
+		if count&0xfff == 0 {
+			select {
+			case <-ctx.Done():
+				return 0, cancelled(ctx)
+			default:
+			}
+		}
+		copy(v[held:], d)
+		total += t.Pay(v)
+		count++
+	}
+	return total / float64(max(count, 1)), nil
+}
+
+// BestHold returns the hold mask with the highest expected value for the
+// hand (see [PayTable.HoldValue]), and its expected value. Ties are broken
+// by the lowest hold mask.
+//
+// Enumerates all 32 holds, which evaluates over 2.5 million draws.
+func (t *PayTable) BestHold(ctx context.Context, hand []Card) (uint8, float64, error) {
+	var best uint8
+	expv := -1.0
+	for hold := range holds(len(hand), len(hand)) {
+		f, err := t.HoldValue(ctx, hand, hold)
+		if err != nil {
+			return 0, 0, err
+		}
+		if expv <= f {
+			best, expv = hold, f
+		}
+	}
+	return best, expv, nil
+}
+
+// Optimal returns the optimal strategy for the pay table, holding the cards
+// with the highest expected value (see [PayTable.BestHold]).
+//
+// The strategy enumerates over 2.5 million draws for every dealt hand, and as
+// such the optimal return to player can only be practically sampled from a
+// small count of hands (see [PayTable.RTP]). Precompute the holds of the
+// dealt hands of interest when a larger count is needed.
+func (t *PayTable) Optimal() VideoStrategy {
+	return func(hand []Card) uint8 {
+		hold, _, _ := t.BestHold(context.Background(), hand)
+		return hold
+	}
+}
+
+// RTP returns the return to player for the strategy, by dealing the count
+// of hands from decks shuffled by the shuffler, holding the cards returned
+// by the strategy, and drawing the replacement cards. The returned value is
+// the average amount paid per unit bet (ie, 0.99 for a 99% return).
+//
+// The result is a sampled estimate, with the accuracy determined by the
+// count of hands dealt. The cost of each hand dealt is that of the strategy,
+// which for the [PayTable.Optimal] strategy is several orders of magnitude
+// more than the draw itself.
+func (t *PayTable) RTP(ctx context.Context, strategy VideoStrategy, shuffler Shuffler, count int) (float64, error) {
+	if count <= 0 {
+		return 0, fmt.Errorf("%w: %d hands", ErrInvalidCount, count)
+	}
+	var total float64
+	v := make([]Card, 5)
+	for i := range count {
+		select {
+		case <-ctx.Done():
+			return total / float64(max(i, 1)), cancelled(ctx)
+		default:
+		}
+		d := DeckFrench.Shuffle(shuffler, 1)
+		hand := d.Draw(5)
+		hold := strategy(hand)
+		for j := range 5 {
+			if hold&(1<<j) != 0 {
+				v[j] = hand[j]
+			} else {
+				v[j] = d.Draw(1)[0]
+			}
+		}
+		total += t.Pay(v)
+	}
+	return total / float64(count), nil
+}
+
+// Payout is a fixed-odds pay table, mapping pay hands to the amount paid per
+// unit bet, for games paying on the eval of a type, such as [Soko]'s
+// [PayFourFlush] and [PayFourStraight]. See [Eval.Payout].
+type Payout map[PayHand]float64
+
+// Hand returns the pay hand for the type's eval rank. Returns [PayNothing]
+// when the rank is invalid, or when the type's eval is not a Cactus or
+// JacksOrBetter eval.
+func (p Payout) Hand(typ Type, rank EvalRank) PayHand {
+	if rank == Invalid {
+		return PayNothing
+	}
+	switch sokoRank(typ, rank) {
+	case SokoFourFlush:
+		return PayFourFlush
+	case SokoFourStraight:
+		return PayFourStraight
+	}
+	r := rank
+	if typ.Desc().Eval != EvalJacksOrBetter {
+		r = cactusRank(typ, rank)
+	}
+	return payHand(r)
+}
+
+// Pay returns the amount paid per unit bet for the type's eval rank. A
+// [PayRoyalFlush] or [PayJacksOrBetter] not in the pay table is paid as a
+// [PayStraightFlush] or [PayPair].
+func (p Payout) Pay(typ Type, rank EvalRank) float64 {
+	h := p.Hand(typ, rank)
+	if f, ok := p[h]; ok {
+		return f
+	}
+	switch h {
+	case PayRoyalFlush:
+		return p[PayStraightFlush]
+	case PayJacksOrBetter:
+		return p[PayPair]
+	}
+	return 0
+}
+
+// VideoStrategy is a video poker strategy, returning the hold mask (where
+// bit i holds hand[i]) for the dealt hand.
+type VideoStrategy func(hand []Card) uint8
+
+// StandPat is a video poker strategy that holds every card.
+func StandPat([]Card) uint8 {
+	return 0x1f
+}
+
+// holds returns an iterator over the hold masks of n cards (where bit i holds
+// card i) discarding at most limit cards, ordered from holding every card to
+// holding none. Used by the video poker and draw advisors (see
+// [PayTable.BestHold] and [Type.DrawDecisions]).
+func holds(n, limit int) iter.Seq[uint8] {
+	return func(yield func(uint8) bool) {
+		all := uint(1)<<n - 1
+		for discard := range all + 1 {
+			if limit < bits.OnesCount(discard) {
+				continue
+			}
+			if !yield(uint8(all ^ discard)) {
+				return
+			}
+		}
+	}
+}
+
+// heldCards returns the cards of v held by the hold mask (where bit i holds
+// v[i]).
+func heldCards(v []Card, hold uint8) []Card {
+	var held []Card
+	for i, c := range v {
+		if hold&(1<<i) != 0 {
+			held = append(held, c)
+		}
+	}
+	return held
+}
+
+// deucesWildHand returns the pay hand for the 5 card hand, with [Two]'s
+// wild.
+func deucesWildHand(hand []Card) PayHand {
+	var wild, maximum int
+	var counts [13]int
+	var mask uint16
+	suited, suit := true, InvalidSuit
+	for _, c := range hand {
+		r := c.Rank()
+		if r == Two {
+			wild++
+			continue
+		}
+		counts[r]++
+		maximum = max(maximum, counts[r])
+		mask |= 1 << r
+		switch s := c.Suit(); {
+		case suit == InvalidSuit:
+			suit = s
+		case s != suit:
+			suited = false
+		}
+	}
+	distinct := bits.OnesCount16(mask) == 5-wild
+	straight := distinct && wildStraight(mask)
+	const royal = 1<<Ten | 1<<Jack | 1<<Queen | 1<<King | 1<<Ace
+	switch {
+	case wild == 0 && suited && mask == royal:
+		return PayRoyalFlush
+	case wild == 4:
+		return PayFourDeuces
+	case suited && distinct && mask&^royal == 0:
+		return PayWildRoyalFlush
+	case 5 <= maximum+wild:
+		return PayFiveOfAKind
+	case suited && straight:
+		return PayStraightFlush
+	case 4 <= maximum+wild:
+		return PayFourOfAKind
+	case wild == 0 && maximum == 3 && bits.OnesCount16(mask) == 2,
+		wild == 1 && maximum == 2 && bits.OnesCount16(mask) == 2:
+		return PayFullHouse
+	case suited:
+		return PayFlush
+	case straight:
+		return PayStraight
+	case 3 <= maximum+wild:
+		return PayThreeOfAKind
+	}
+	return PayNothing
+}
+
+// wildStraight returns true when the rank mask fits within 5 consecutive
+// ranks, with an [Ace] playing high or low.
+func wildStraight(mask uint16) bool {
+	const wheel = 1<<Ace | 1<<Two | 1<<Three | 1<<Four | 1<<Five
+	if mask&^wheel == 0 {
+		return true
+	}
+	for r := range 9 {
+		if mask&^(0x1f<<r) == 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+package cardrank
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestPayTableHand(t *testing.T) {
+	tests := []struct {
+		wild bool
+		v    string
+		exp  PayHand
+	}{
+		{false, "Ah Kh Qh Jh Th", PayRoyalFlush},
+		{false, "9h Kh Qh Jh Th", PayStraightFlush},
+		{false, "9h 9c 9s 9d Th", PayFourOfAKind},
+		{false, "9h 9c 9s Td Th", PayFullHouse},
+		{false, "2h 9h Qh Jh Th", PayFlush},
+		{false, "Ah 2c 3h 4h 5h", PayStraight},
+		{false, "9h 9c 9s Kd Th", PayThreeOfAKind},
+		{false, "9h 9c Ks Kd Th", PayTwoPair},
+		{false, "Jh Jc 9s 8d 2h", PayJacksOrBetter},
+		{false, "Th Tc 9s 8d 2h", PayNothing},
+		{true, "Ah Kh Qh Jh Th", PayRoyalFlush},
+		{true, "2h 2c 2s 2d Th", PayFourDeuces},
+		{true, "Ah 2c Qh Jh Th", PayWildRoyalFlush},
+		{true, "9h 9c 2s 2d 9s", PayFiveOfAKind},
+		{true, "9h 8h 2s 6h 5h", PayStraightFlush},
+		{true, "Ah 2c 3h 4h 5h", PayStraightFlush},
+		{true, "9h 9c 2s Kd Th", PayThreeOfAKind},
+		{true, "9h 9c 2s Kd Kh", PayFullHouse},
+		{true, "9h 9c 2s 9d Kh", PayFourOfAKind},
+		{true, "3h 9h 2s Kh 7h", PayFlush},
+		{true, "Ac 2c 3h 4d 5h", PayStraight},
+		{true, "9h 9c Ks Kd Th", PayNothing},
+	}
+	for i, test := range tests {
+		table := NewJacksOrBetterTable(9, 6)
+		if test.wild {
+			table = NewDeucesWildTable()
+		}
+		if h := table.Hand(Must(test.v)); h != test.exp {
+			t.Errorf("test %d %s expected %s, got: %s", i, test.v, test.exp, h)
+		}
+	}
+}
+
+func TestPayTableHoldValue(t *testing.T) {
+	table := NewJacksOrBetterTable(9, 6)
+	hand := Must("Ah Kh Qh Jh 2c")
+	expv, err := table.HoldValue(context.Background(), hand, 0x0f)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if exp := 872.0 / 47.0; math.Abs(expv-exp) > 1e-9 {
+		t.Errorf("expected %f, got: %f", exp, expv)
+	}
+	if expv, _ := table.HoldValue(context.Background(), hand, 0x1f); expv != 0 {
+		t.Errorf("expected 0, got: %f", expv)
+	}
+}
+
+func TestPayTableRTP(t *testing.T) {
+	// stand pat return for 9/6 jacks or better
+	exp := 875504.0 / 2598960.0
+	rtp, err := NewJacksOrBetterTable(9, 6).RTP(context.Background(), StandPat, rand.New(rand.NewSource(0)), 100000)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if math.Abs(rtp-exp) > 0.02 {
+		t.Errorf("expected %f, got: %f", exp, rtp)
+	}
+	if _, err := NewJacksOrBetterTable(9, 6).RTP(context.Background(), StandPat, rand.New(rand.NewSource(0)), 0); !errors.Is(err, ErrInvalidCount) {
+		t.Errorf("expected error %v, got: %v", ErrInvalidCount, err)
+	}
+}
+
+func TestHolds(t *testing.T) {
+	tests := []struct {
+		n, limit int
+		exp      int
+	}{
+		{5, 5, 32},
+		{5, 3, 26},
+		{4, 0, 1},
+		{8, 8, 256},
+	}
+	for i, test := range tests {
+		var masks []uint8
+		for hold := range holds(test.n, test.limit) {
+			masks = append(masks, hold)
+		}
+		if len(masks) != test.exp {
+			t.Errorf("test %d expected %d, got: %d", i, test.exp, len(masks))
+		}
+		if masks[0] != uint8(uint(1)<<test.n-1) {
+			t.Errorf("test %d expected first to hold every card, got: %b", i, masks[0])
+		}
+	}
+	if v, exp := heldCards(Must("Ah Kh Qh Jh 2c"), 0x15), Must("Ah Qh 2c"); !slices.Equal(v, exp) {
+		t.Errorf("expected %v, got: %v", exp, v)
+	}
+}
+
+func TestPayout(t *testing.T) {
+	video := NewJacksOrBetterTable(9, 6).Pays
+	soko := Payout{
+		PayStraightFlush: 100,
+		PayFlush:         5,
+		PayTwoPair:       2,
+		PayFourFlush:     1.5,
+		PayFourStraight:  1.25,
+		PayPair:          1,
+	}
+	tests := []struct {
+		typ    Type
+		p      Payout
+		v      string
+		exp    PayHand
+		payout float64
+	}{
+		{Video, video, "Ah Kh Qh Jh Th", PayRoyalFlush, 800},
+		{Video, video, "9h Kh Qh Jh Th", PayStraightFlush, 50},
+		{Video, video, "9h 9c 9s Td Th", PayFullHouse, 9},
+		{Video, video, "9h 9c Ks Kd Th", PayTwoPair, 2},
+		{Video, video, "Jh Jc 9s 8d 2h", PayJacksOrBetter, 1},
+		{Video, video, "Th Tc 9s 8d 2h", PayNothing, 0},
+		{Holdem, video, "Th Tc 9s 8d 2h", PayPair, 0},
+		{Holdem, soko, "Ah Kh Qh Jh Th", PayRoyalFlush, 100},
+		{Soko, soko, "Ah Kh Qh Jh Th", PayRoyalFlush, 100},
+		{Soko, soko, "Ah 7h Qh Jh Tc", PayFourFlush, 1.5},
+		{Soko, soko, "9h 8c 7s 6d 2h", PayFourStraight, 1.25},
+		{Soko, soko, "Ah Ac Qh Jh Th", PayFourFlush, 1.5},
+		{Soko, soko, "Ah Ac Qd Jh Tc", PayJacksOrBetter, 1},
+		{Soko, soko, "Ah 3c Qd Jh Tc", PayNothing, 0},
+		{Razz, soko, "Ah 2c 3d 4h 5c", PayNothing, 0},
+	}
+	for i, test := range tests {
+		ev := test.typ.Eval(Must(test.v), nil)
+		if h := test.p.Hand(test.typ, ev.HiRank); h != test.exp {
+			t.Errorf("test %d %s %s expected %s, got: %s", i, test.typ, test.v, test.exp, h)
+		}
+		if f := ev.Payout(test.p); f != test.payout {
+			t.Errorf("test %d %s %s expected %f, got: %f", i, test.typ, test.v, test.payout, f)
+		}
+	}
+}